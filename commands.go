@@ -0,0 +1,95 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+// runCommand runs a CLI subcommand instead of the MCP server.
+func runCommand(c *todoist.Client, name string, args []string, stdout io.Writer) error {
+	switch name {
+	case "backup":
+		return runBackup(c, args, stdout)
+	case "restore":
+		return runRestore(c, args, stdout)
+	default:
+		return fmt.Errorf("unknown command %q (available: backup, restore)", name)
+	}
+}
+
+// runBackup writes a JSON archive of the account to a file or stdout.
+func runBackup(c *todoist.Client, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("backup", flag.ContinueOnError)
+	out := fs.String("o", "", "write the backup to this file instead of stdout")
+	days := fs.Int("completed-days", todoist.DefaultBackupCompletedDays, "include tasks completed in the last N days (0 to skip)")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if *days < 0 {
+		return fmt.Errorf("invalid -completed-days %d: use 0 to skip completed tasks", *days)
+	}
+
+	var since time.Time
+	if *days > 0 {
+		since = time.Now().AddDate(0, 0, -*days)
+	}
+	b, err := c.CreateBackup(since)
+	if err != nil {
+		return err
+	}
+
+	if *out == "" {
+		return b.Write(stdout)
+	}
+	f, err := os.Create(*out)
+	if err != nil {
+		return err
+	}
+	if err := b.Write(f); err != nil {
+		_ = f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	fmt.Fprintf(os.Stderr, "Backed up %s to %s\n", b.Summary(), *out)
+	return nil
+}
+
+// runRestore recreates a backup archive in the account.
+func runRestore(c *todoist.Client, args []string, stdout io.Writer) error {
+	fs := flag.NewFlagSet("restore", flag.ContinueOnError)
+	dryRun := fs.Bool("dry-run", false, "only describe the backup without creating anything")
+	if err := fs.Parse(args); err != nil {
+		return err
+	}
+	if fs.NArg() != 1 {
+		return fmt.Errorf("usage: mcp-todoist restore [-dry-run] <backup.json>")
+	}
+
+	f, err := os.Open(fs.Arg(0))
+	if err != nil {
+		return err
+	}
+	defer func() { _ = f.Close() }()
+	b, err := todoist.ReadBackup(f)
+	if err != nil {
+		return err
+	}
+
+	if *dryRun {
+		_, err := fmt.Fprintf(stdout, "Backup from %s contains %s\n", b.CreatedAt.Format(time.RFC3339), b.Summary())
+		return err
+	}
+	result, err := c.RestoreBackup(b)
+	if err != nil {
+		return fmt.Errorf("restore stopped after %s: %w", result.Summary(), err)
+	}
+	_, err = fmt.Fprintf(stdout, "Restored %s\n", result.Summary())
+	return err
+}
@@ -2,7 +2,10 @@ module github.com/nsega/mcp-todoist
 
 go 1.25.7
 
-require github.com/modelcontextprotocol/go-sdk v1.3.0
+require (
+	github.com/modelcontextprotocol/go-sdk v1.3.0
+	gopkg.in/yaml.v3 v3.0.1
+)
 
 require (
 	github.com/google/jsonschema-go v0.4.2 // indirect
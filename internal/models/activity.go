@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// ActivityEvent is one entry of the Todoist activity log. ObjectType is
+// "item" (task), "project" or "note" (comment); ExtraData carries
+// event-specific details such as the object's content or name.
+type ActivityEvent struct {
+	ID              string                 `json:"id"`
+	ObjectType      string                 `json:"object_type"`
+	ObjectID        string                 `json:"object_id"`
+	EventType       string                 `json:"event_type"`
+	EventDate       time.Time              `json:"event_date"`
+	ParentProjectID string                 `json:"parent_project_id,omitempty"`
+	ParentItemID    string                 `json:"parent_item_id,omitempty"`
+	InitiatorID     string                 `json:"initiator_id,omitempty"`
+	ExtraData       map[string]interface{} `json:"extra_data,omitempty"`
+}
@@ -0,0 +1,12 @@
+package models
+
+// Collaborator represents a user who shares a project or workspace.
+type Collaborator struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Email       string `json:"email"`
+	Timezone    string `json:"timezone,omitempty"`
+	ImageID     string `json:"image_id,omitempty"`
+	Role        string `json:"role,omitempty"`
+	WorkspaceID string `json:"workspace_id,omitempty"`
+}
@@ -4,11 +4,25 @@ import "time"
 
 // Comment represents a Todoist comment.
 type Comment struct {
-	ID        string    `json:"id"`
-	TaskID    string    `json:"task_id,omitempty"`
-	ProjectID string    `json:"project_id,omitempty"`
-	Content   string    `json:"content"`
-	PostedAt  time.Time `json:"posted_at"`
-	PostedUID string    `json:"posted_uid,omitempty"`
-	IsDeleted bool      `json:"is_deleted,omitempty"`
+	ID         string          `json:"id"`
+	TaskID     string          `json:"task_id,omitempty"`
+	ProjectID  string          `json:"project_id,omitempty"`
+	Content    string          `json:"content"`
+	PostedAt   time.Time       `json:"posted_at"`
+	PostedUID  string          `json:"posted_uid,omitempty"`
+	IsDeleted  bool            `json:"is_deleted,omitempty"`
+	Attachment *FileAttachment `json:"file_attachment,omitempty"`
+}
+
+// FileAttachment is a file or link attached to a comment. Files are
+// uploaded first and then referenced from the comment by FileURL.
+type FileAttachment struct {
+	ResourceType string `json:"resource_type,omitempty"`
+	FileName     string `json:"file_name,omitempty"`
+	FileType     string `json:"file_type,omitempty"`
+	FileSize     int64  `json:"file_size,omitempty"`
+	FileURL      string `json:"file_url,omitempty"`
+	URL          string `json:"url,omitempty"`
+	Title        string `json:"title,omitempty"`
+	UploadState  string `json:"upload_state,omitempty"`
 }
@@ -0,0 +1,18 @@
+package models
+
+// Invitation represents a pending invitation to join a shared project.
+// Received invitations come with an ID and the Secret needed to accept or
+// reject them. Sent invitations have Sent set and name the invitee in
+// ToEmail; the Sync API does not expose their IDs.
+type Invitation struct {
+	ID          string `json:"invitation_id,omitempty"`
+	Secret      string `json:"invitation_secret,omitempty"`
+	ProjectID   string `json:"project_id"`
+	ProjectName string `json:"project_name,omitempty"`
+	FromEmail   string `json:"from_email,omitempty"`
+	FromName    string `json:"from_name,omitempty"`
+	Sent        bool   `json:"sent,omitempty"`
+	ToEmail     string `json:"to_email,omitempty"`
+	State       string `json:"state"`
+	CreatedAt   string `json:"created_at,omitempty"`
+}
@@ -0,0 +1,19 @@
+package models
+
+// Reminder represents a Todoist task reminder. Type is one of
+// "relative" (MinuteOffset before the task's due time), "absolute"
+// (at Due) or "location" (on entering or leaving a place).
+type Reminder struct {
+	ID           string   `json:"id"`
+	TaskID       string   `json:"item_id"`
+	NotifyUID    string   `json:"notify_uid,omitempty"`
+	Type         string   `json:"type"`
+	Due          *DueDate `json:"due,omitempty"`
+	MinuteOffset int      `json:"minute_offset,omitempty"`
+	Name         string   `json:"name,omitempty"`
+	LocLat       string   `json:"loc_lat,omitempty"`
+	LocLong      string   `json:"loc_long,omitempty"`
+	LocTrigger   string   `json:"loc_trigger,omitempty"`
+	Radius       int      `json:"radius,omitempty"`
+	IsDeleted    bool     `json:"is_deleted,omitempty"`
+}
@@ -0,0 +1,63 @@
+package models
+
+// ProductivityStats is the completion and karma summary returned by the
+// Todoist stats endpoint.
+type ProductivityStats struct {
+	CompletedCount  int              `json:"completed_count"`
+	Karma           float64          `json:"karma"`
+	KarmaTrend      string           `json:"karma_trend"`
+	KarmaLastUpdate float64          `json:"karma_last_update"`
+	KarmaUpdates    []KarmaUpdate    `json:"karma_update_reasons,omitempty"`
+	DaysItems       []DayCompletion  `json:"days_items,omitempty"`
+	WeekItems       []WeekCompletion `json:"week_items,omitempty"`
+	Goals           Goals            `json:"goals"`
+}
+
+// DayCompletion counts tasks completed on one day.
+type DayCompletion struct {
+	Date           string              `json:"date"`
+	TotalCompleted int                 `json:"total_completed"`
+	Items          []ProjectCompletion `json:"items,omitempty"`
+}
+
+// WeekCompletion counts tasks completed in one week.
+type WeekCompletion struct {
+	From           string              `json:"from"`
+	To             string              `json:"to"`
+	TotalCompleted int                 `json:"total_completed"`
+	Items          []ProjectCompletion `json:"items,omitempty"`
+}
+
+// ProjectCompletion is a per-project completed count within a day or week.
+type ProjectCompletion struct {
+	ProjectID string `json:"id"`
+	Completed int    `json:"completed"`
+}
+
+// KarmaUpdate records a change in karma.
+type KarmaUpdate struct {
+	Time          string  `json:"time"`
+	NewKarma      float64 `json:"new_karma"`
+	PositiveKarma float64 `json:"positive_karma"`
+	NegativeKarma float64 `json:"negative_karma"`
+}
+
+// Goals holds the user's daily and weekly goals and streaks.
+type Goals struct {
+	DailyGoal           int    `json:"daily_goal"`
+	WeeklyGoal          int    `json:"weekly_goal"`
+	IgnoreDays          []int  `json:"ignore_days,omitempty"`
+	VacationMode        int    `json:"vacation_mode"`
+	KarmaDisabled       int    `json:"karma_disabled"`
+	CurrentDailyStreak  Streak `json:"current_daily_streak"`
+	MaxDailyStreak      Streak `json:"max_daily_streak"`
+	CurrentWeeklyStreak Streak `json:"current_weekly_streak"`
+	MaxWeeklyStreak     Streak `json:"max_weekly_streak"`
+}
+
+// Streak is a run of consecutive days or weeks meeting the goal.
+type Streak struct {
+	Count int    `json:"count"`
+	Start string `json:"start,omitempty"`
+	End   string `json:"end,omitempty"`
+}
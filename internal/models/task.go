@@ -21,6 +21,7 @@ type Task struct {
 	AssigneeID    string    `json:"responsible_uid,omitempty"`
 	CreatedAt     time.Time `json:"added_at"`
 	Duration      *Duration `json:"duration,omitempty"`
+	Deadline      *Deadline `json:"deadline,omitempty"`
 	UserID        string    `json:"user_id,omitempty"`
 	AssignedByUID string    `json:"assigned_by_uid,omitempty"`
 	UpdatedAt     string    `json:"updated_at,omitempty"`
@@ -35,6 +36,7 @@ type DueDate struct {
 	Datetime  string `json:"datetime,omitempty"`
 	Recurring bool   `json:"recurring"`
 	Timezone  string `json:"timezone,omitempty"`
+	Lang      string `json:"lang,omitempty"`
 }
 
 // Duration represents a task's duration.
@@ -42,3 +44,10 @@ type Duration struct {
 	Amount int    `json:"amount"`
 	Unit   string `json:"unit"`
 }
+
+// Deadline represents a task's deadline, a date the task must be done by
+// that is independent of when it is scheduled.
+type Deadline struct {
+	Date string `json:"date"`
+	Lang string `json:"lang,omitempty"`
+}
@@ -0,0 +1,18 @@
+package models
+
+// User represents the authenticated Todoist user.
+type User struct {
+	ID             string  `json:"id"`
+	Email          string  `json:"email"`
+	FullName       string  `json:"full_name"`
+	InboxProjectID string  `json:"inbox_project_id,omitempty"`
+	TZInfo         *TZInfo `json:"tz_info,omitempty"`
+	Karma          float64 `json:"karma,omitempty"`
+	DailyGoal      int     `json:"daily_goal,omitempty"`
+	WeeklyGoal     int     `json:"weekly_goal,omitempty"`
+}
+
+// TZInfo describes a user's time zone.
+type TZInfo struct {
+	Timezone string `json:"timezone"`
+}
@@ -0,0 +1,83 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// ActivityQuery narrows an activity log request. Zero values are ignored.
+type ActivityQuery struct {
+	ObjectType      string
+	ObjectID        string
+	EventType       string
+	ParentProjectID string
+	ParentItemID    string
+	InitiatorID     string
+	Since           time.Time
+	Until           time.Time
+	Limit           int
+	Cursor          string
+}
+
+// GetActivity returns one page of activity log events, newest first,
+// together with the cursor for the next page ("" when there are no more).
+func (c *Client) GetActivity(q ActivityQuery) ([]models.ActivityEvent, string, error) {
+	params := url.Values{}
+	set := func(key, value string) {
+		if value != "" {
+			params.Set(key, value)
+		}
+	}
+	set("object_type", q.ObjectType)
+	set("object_id", q.ObjectID)
+	set("event_type", q.EventType)
+	set("parent_project_id", q.ParentProjectID)
+	set("parent_item_id", q.ParentItemID)
+	set("initiator_id", q.InitiatorID)
+	set("cursor", q.Cursor)
+	if !q.Since.IsZero() {
+		params.Set("date_from", q.Since.UTC().Format(time.RFC3339))
+	}
+	if !q.Until.IsZero() {
+		params.Set("date_to", q.Until.UTC().Format(time.RFC3339))
+	}
+	if q.Limit > 0 {
+		params.Set("limit", strconv.Itoa(q.Limit))
+	}
+
+	endpoint := "/activities"
+	if len(params) > 0 {
+		endpoint += "?" + params.Encode()
+	}
+	data, err := c.do("GET", endpoint, nil)
+	if err != nil {
+		return nil, "", err
+	}
+
+	var page PaginatedResponse[models.ActivityEvent]
+	if err := json.Unmarshal(data, &page); err != nil {
+		return nil, "", fmt.Errorf("failed to parse activity: %w", err)
+	}
+
+	// Apply the date range locally as well so callers get consistent
+	// results regardless of how the server treats the bounds.
+	events := page.Results[:0]
+	next := page.NextCursor
+	for _, e := range page.Results {
+		if !q.Until.IsZero() && e.EventDate.After(q.Until) {
+			continue
+		}
+		if !q.Since.IsZero() && e.EventDate.Before(q.Since) {
+			// Events are newest first, so nothing further can match.
+			next = ""
+			continue
+		}
+		events = append(events, e)
+	}
+	return events, next, nil
+}
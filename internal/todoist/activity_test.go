@@ -0,0 +1,62 @@
+package todoist
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestGetActivity(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/activities" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("object_type") != "item" || q.Get("parent_project_id") != "p1" || q.Get("limit") != "2" || q.Get("cursor") != "c1" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		if q.Get("date_from") != "2026-10-12T00:00:00Z" {
+			t.Errorf("date_from = %q", q.Get("date_from"))
+		}
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"e1","object_type":"item","object_id":"1","event_type":"completed","event_date":"2026-10-18T09:00:00Z","parent_project_id":"p1","extra_data":{"content":"Write report"}},
+			{"id":"e2","object_type":"item","object_id":"2","event_type":"added","event_date":"2026-10-01T09:00:00Z","parent_project_id":"p1"}
+		],"next_cursor":"c2"}`))
+	})
+	defer srv.Close()
+
+	events, next, err := c.GetActivity(ActivityQuery{
+		ObjectType:      "item",
+		ParentProjectID: "p1",
+		Since:           time.Date(2026, 10, 12, 0, 0, 0, 0, time.UTC),
+		Limit:           2,
+		Cursor:          "c1",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || events[0].ID != "e1" || events[0].ExtraData["content"] != "Write report" {
+		t.Errorf("unexpected events: %+v", events)
+	}
+	if next != "" {
+		t.Errorf("next = %q, want no cursor once past the range", next)
+	}
+}
+
+func TestGetActivity_nextCursor(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.RawQuery != "" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"e1","object_type":"project","object_id":"p1","event_type":"updated","event_date":"2026-10-18T09:00:00Z"}],"next_cursor":"c2"}`))
+	})
+	defer srv.Close()
+
+	events, next, err := c.GetActivity(ActivityQuery{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(events) != 1 || next != "c2" {
+		t.Errorf("events=%+v next=%q", events, next)
+	}
+}
@@ -0,0 +1,379 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// BackupVersion is the archive format written by CreateBackup.
+const BackupVersion = 1
+
+// DefaultBackupCompletedDays is how many days of completed tasks a
+// backup includes unless told otherwise; 0 skips them.
+const DefaultBackupCompletedDays = 90
+
+// completedWindow is the longest range the completed-tasks endpoint
+// accepts in one request.
+const completedWindow = 12 * 7 * 24 * time.Hour
+
+// Backup is a versioned JSON archive of an account.
+type Backup struct {
+	Version        int              `json:"version"`
+	CreatedAt      time.Time        `json:"created_at"`
+	CompletedSince time.Time        `json:"completed_since,omitempty"`
+	Projects       []models.Project `json:"projects"`
+	Sections       []models.Section `json:"sections"`
+	Tasks          []models.Task    `json:"tasks"`
+	CompletedTasks []models.Task    `json:"completed_tasks"`
+	Comments       []models.Comment `json:"comments"`
+	Labels         []models.Label   `json:"labels"`
+}
+
+// CreateBackup dumps projects, sections, active tasks, tasks completed
+// since completedSince (none if zero), comments and personal labels.
+// Every list is read to its last page.
+func (c *Client) CreateBackup(completedSince time.Time) (*Backup, error) {
+	b := &Backup{Version: BackupVersion, CreatedAt: time.Now().UTC(), CompletedSince: completedSince}
+
+	var err error
+	if b.Projects, err = c.GetProjects(); err != nil {
+		return nil, err
+	}
+	if b.Sections, err = c.GetSections(""); err != nil {
+		return nil, err
+	}
+	if b.Tasks, err = c.GetTasks("", ""); err != nil {
+		return nil, err
+	}
+	if b.Labels, err = c.GetLabels(); err != nil {
+		return nil, err
+	}
+
+	if !completedSince.IsZero() {
+		for until := b.CreatedAt; until.After(completedSince); until = until.Add(-completedWindow) {
+			since := until.Add(-completedWindow)
+			if since.Before(completedSince) {
+				since = completedSince
+			}
+			done, err := c.GetCompletedTasks("", since, until)
+			if err != nil {
+				return nil, err
+			}
+			b.CompletedTasks = append(b.CompletedTasks, done...)
+		}
+	}
+
+	for _, p := range b.Projects {
+		comments, err := c.GetComments("", p.ID)
+		if err != nil {
+			return nil, err
+		}
+		b.Comments = append(b.Comments, comments...)
+	}
+	for _, list := range [][]models.Task{b.Tasks, b.CompletedTasks} {
+		for _, t := range list {
+			if t.CommentCount == 0 {
+				continue
+			}
+			comments, err := c.GetComments(t.ID, "")
+			if err != nil {
+				return nil, err
+			}
+			b.Comments = append(b.Comments, comments...)
+		}
+	}
+	return b, nil
+}
+
+// Write encodes the backup as indented JSON.
+func (b *Backup) Write(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(b)
+}
+
+// Summary describes the backup's contents in one line.
+func (b *Backup) Summary() string {
+	return fmt.Sprintf("%d projects, %d sections, %d active tasks, %d completed tasks, %d comments, %d labels",
+		len(b.Projects), len(b.Sections), len(b.Tasks), len(b.CompletedTasks), len(b.Comments), len(b.Labels))
+}
+
+// ReadBackup decodes an archive written by Backup.Write.
+func ReadBackup(r io.Reader) (*Backup, error) {
+	var b Backup
+	if err := json.NewDecoder(r).Decode(&b); err != nil {
+		return nil, fmt.Errorf("failed to parse backup: %w", err)
+	}
+	if b.Version < 1 || b.Version > BackupVersion {
+		return nil, fmt.Errorf("unsupported backup version %d", b.Version)
+	}
+	return &b, nil
+}
+
+// RestoreResult maps IDs in the backup to the IDs of the objects created
+// for them, and counts what was skipped.
+type RestoreResult struct {
+	Projects      map[string]string
+	Sections      map[string]string
+	Tasks         map[string]string
+	Comments      map[string]string
+	LabelsCreated int
+	LabelsExisted int
+	// TasksLeftOpen counts completed tasks restored as active because
+	// they have active subtasks.
+	TasksLeftOpen int
+}
+
+// Summary describes what was restored in one line.
+func (r *RestoreResult) Summary() string {
+	s := fmt.Sprintf("%d projects, %d sections, %d tasks, %d comments, %d labels created (%d labels already existed)",
+		len(r.Projects), len(r.Sections), len(r.Tasks), len(r.Comments), r.LabelsCreated, r.LabelsExisted)
+	if r.TasksLeftOpen > 0 {
+		s += fmt.Sprintf("; %d completed tasks left open because they have active subtasks", r.TasksLeftOpen)
+	}
+	return s
+}
+
+// RestoreBackup recreates a backup in the client's account. The backup's
+// inbox maps onto the account's inbox, labels are matched by name, and
+// completed tasks are created and then closed. On error the result
+// describes what had been created so far.
+func (c *Client) RestoreBackup(b *Backup) (*RestoreResult, error) {
+	r := &RestoreResult{
+		Projects: map[string]string{},
+		Sections: map[string]string{},
+		Tasks:    map[string]string{},
+		Comments: map[string]string{},
+	}
+
+	labels, err := c.GetLabels()
+	if err != nil {
+		return r, err
+	}
+	have := map[string]bool{}
+	for _, l := range labels {
+		have[strings.ToLower(l.Name)] = true
+	}
+	for _, l := range b.Labels {
+		if have[strings.ToLower(l.Name)] {
+			r.LabelsExisted++
+			continue
+		}
+		body := map[string]interface{}{"name": l.Name, "is_favorite": l.IsFavorite}
+		if l.Color != "" {
+			body["color"] = l.Color
+		}
+		if _, err := c.CreateLabel(body); err != nil {
+			return r, err
+		}
+		r.LabelsCreated++
+	}
+
+	if err := c.restoreProjects(b, r); err != nil {
+		return r, err
+	}
+
+	sections := append([]models.Section(nil), b.Sections...)
+	sort.SliceStable(sections, func(i, j int) bool { return sections[i].Order < sections[j].Order })
+	for _, s := range sections {
+		projectID, ok := r.Projects[s.ProjectID]
+		if !ok {
+			continue
+		}
+		ns, err := c.CreateSection(map[string]interface{}{"name": s.Name, "project_id": projectID})
+		if err != nil {
+			return r, err
+		}
+		r.Sections[s.ID] = ns.ID
+	}
+
+	// Parents are created before their subtasks; completed tasks are
+	// closed afterwards, deepest first. Closing a parent in Todoist also
+	// completes all of its subtasks, so a completed parent with an active
+	// subtask is left open rather than losing the subtask's state.
+	tasks := append(append([]models.Task(nil), b.Tasks...), b.CompletedTasks...)
+	var toClose []string
+	var createErr error
+	BuildTaskTree(tasks).Walk(func(n *TaskNode, depth int) {
+		if createErr != nil {
+			return
+		}
+		t := n.Task
+		projectID, ok := r.Projects[t.ProjectID]
+		if !ok {
+			return
+		}
+		body := restoreTaskBody(t)
+		body["project_id"] = projectID
+		if id, ok := r.Sections[t.SectionID]; ok {
+			body["section_id"] = id
+		}
+		if id, ok := r.Tasks[t.ParentID]; ok {
+			body["parent_id"] = id
+		}
+		nt, err := c.CreateTask(body)
+		if err != nil {
+			createErr = err
+			return
+		}
+		r.Tasks[t.ID] = nt.ID
+		if t.IsCompleted {
+			if done, total := n.Progress(); done < total {
+				r.TasksLeftOpen++
+				return
+			}
+			toClose = append(toClose, nt.ID)
+		}
+	})
+	if createErr != nil {
+		return r, createErr
+	}
+	for i := len(toClose) - 1; i >= 0; i-- {
+		if err := c.CloseTask(toClose[i]); err != nil {
+			return r, err
+		}
+	}
+
+	for _, cm := range b.Comments {
+		body := map[string]interface{}{"content": cm.Content}
+		if cm.TaskID != "" {
+			id, ok := r.Tasks[cm.TaskID]
+			if !ok {
+				continue
+			}
+			body["task_id"] = id
+		} else {
+			id, ok := r.Projects[cm.ProjectID]
+			if !ok {
+				continue
+			}
+			body["project_id"] = id
+		}
+		if cm.Attachment != nil {
+			body["attachment"] = cm.Attachment
+		}
+		ncm, err := c.CreateComment(body)
+		if err != nil {
+			return r, err
+		}
+		r.Comments[cm.ID] = ncm.ID
+	}
+	return r, nil
+}
+
+// restoreProjects creates the backup's projects parent-first, mapping the
+// backup's inbox onto the account's existing inbox.
+func (c *Client) restoreProjects(b *Backup, r *RestoreResult) error {
+	existing, err := c.GetProjects()
+	if err != nil {
+		return err
+	}
+	for _, bp := range b.Projects {
+		if !bp.IsInboxProject {
+			continue
+		}
+		for _, p := range existing {
+			if p.IsInboxProject {
+				r.Projects[bp.ID] = p.ID
+			}
+		}
+	}
+
+	pending := append([]models.Project(nil), b.Projects...)
+	sort.SliceStable(pending, func(i, j int) bool { return pending[i].Order < pending[j].Order })
+	for len(pending) > 0 {
+		var next []models.Project
+		for _, p := range pending {
+			if _, done := r.Projects[p.ID]; done {
+				continue
+			}
+			parentID, hasParent := "", p.ParentID != ""
+			if hasParent {
+				id, ok := r.Projects[p.ParentID]
+				if !ok && projectInBackup(b, p.ParentID) {
+					next = append(next, p) // parent not created yet
+					continue
+				}
+				parentID = id
+			}
+
+			body := map[string]interface{}{"name": p.Name, "is_favorite": p.IsFavorite}
+			if p.Color != "" {
+				body["color"] = p.Color
+			}
+			if p.ViewStyle != "" {
+				body["view_style"] = p.ViewStyle
+			}
+			if p.Description != "" {
+				body["description"] = p.Description
+			}
+			if parentID != "" {
+				body["parent_id"] = parentID
+			}
+			np, err := c.CreateProject(body)
+			if err != nil {
+				return err
+			}
+			r.Projects[p.ID] = np.ID
+		}
+		if len(next) == len(pending) {
+			return fmt.Errorf("backup has a project parent cycle")
+		}
+		pending = next
+	}
+	return nil
+}
+
+func projectInBackup(b *Backup, id string) bool {
+	for _, p := range b.Projects {
+		if p.ID == id {
+			return true
+		}
+	}
+	return false
+}
+
+// restoreTaskBody builds the CreateTask fields that do not depend on ID
+// remapping. Recurring due dates keep their pattern; completed tasks get
+// a plain date so that closing them does not reschedule.
+func restoreTaskBody(t models.Task) map[string]interface{} {
+	body := map[string]interface{}{"content": t.Content}
+	if t.Description != "" {
+		body["description"] = t.Description
+	}
+	if t.Priority > 1 {
+		body["priority"] = t.Priority
+	}
+	if len(t.Labels) > 0 {
+		body["labels"] = t.Labels
+	}
+	if d := t.Due; d != nil {
+		switch {
+		case d.Recurring && d.String != "" && !t.IsCompleted:
+			body["due_string"] = d.String
+			if d.Lang != "" {
+				body["due_lang"] = d.Lang
+			}
+		case d.Datetime != "":
+			body["due_datetime"] = d.Datetime
+		case len(d.Date) > 10:
+			body["due_datetime"] = d.Date
+		case d.Date != "":
+			body["due_date"] = d.Date
+		}
+	}
+	if t.Duration != nil && t.Duration.Amount > 0 {
+		body["duration"] = t.Duration.Amount
+		body["duration_unit"] = t.Duration.Unit
+	}
+	if t.Deadline != nil && t.Deadline.Date != "" {
+		body["deadline_date"] = t.Deadline.Date
+	}
+	return body
+}
@@ -0,0 +1,128 @@
+package todoist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestBackupAndRestore(t *testing.T) {
+	src := &fakeAccount{
+		pageSize: 2, // exercise cursor paging
+		projects: []models.Project{
+			{ID: "in", Name: "Inbox", IsInboxProject: true},
+			{ID: "c", Name: "Client", ParentID: "w", Order: 1},
+			{ID: "w", Name: "Work", Order: 0},
+		},
+		sections: []models.Section{{ID: "s", Name: "Doing", ProjectID: "w"}},
+		tasks: []models.Task{
+			{ID: "1", Content: "Parent", ProjectID: "w", SectionID: "s", Priority: 4, Labels: []string{"focus"}, CommentCount: 1},
+			{ID: "2", Content: "Child", ProjectID: "w", SectionID: "s", ParentID: "1",
+				Due: &models.DueDate{Date: "2026-10-20", String: "every tue", Recurring: true}},
+			{ID: "3", Content: "Capture", ProjectID: "in"},
+			{ID: "4", Content: "Contract", ProjectID: "c", Due: &models.DueDate{Date: "2026-10-22"}},
+			{ID: "8", Content: "Follow up", ProjectID: "w", ParentID: "6"},
+		},
+		completed: []models.Task{
+			{ID: "5", Content: "Done already", ProjectID: "w", ParentID: "1", IsCompleted: true},
+			{ID: "6", Content: "Reopened parent", ProjectID: "w", IsCompleted: true},
+			{ID: "7", Content: "Filed", ProjectID: "in", IsCompleted: true},
+		},
+		comments: []models.Comment{
+			{ID: "n1", TaskID: "1", Content: "Task note"},
+			{ID: "n2", ProjectID: "c", Content: "Project note"},
+		},
+		labels: []models.Label{{ID: "l1", Name: "focus"}, {ID: "l2", Name: "errand"}},
+	}
+	sc, ssrv := testServer(t, src.handler(t))
+	defer ssrv.Close()
+
+	b, err := sc.CreateBackup(time.Now().AddDate(0, 0, -30))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := b.Summary(); got != "3 projects, 1 sections, 5 active tasks, 3 completed tasks, 2 comments, 2 labels" {
+		t.Fatalf("summary = %q", got)
+	}
+
+	var buf bytes.Buffer
+	if err := b.Write(&buf); err != nil {
+		t.Fatal(err)
+	}
+	b, err = ReadBackup(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	dst := &fakeAccount{
+		projects: []models.Project{{ID: "inbox2", Name: "Inbox", IsInboxProject: true}},
+		labels:   []models.Label{{ID: "x", Name: "Focus"}},
+	}
+	dc, dsrv := testServer(t, dst.handler(t))
+	defer dsrv.Close()
+
+	result, err := dc.RestoreBackup(b)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Projects["in"] != "inbox2" || len(dst.projects) != 3 {
+		t.Fatalf("projects = %+v, mapping %v", dst.projects, result.Projects)
+	}
+	if result.LabelsCreated != 1 || result.LabelsExisted != 1 {
+		t.Errorf("labels created=%d existed=%d", result.LabelsCreated, result.LabelsExisted)
+	}
+
+	byID := map[string]models.Project{}
+	for _, p := range dst.projects {
+		byID[p.ID] = p
+	}
+	if client := byID[result.Projects["c"]]; client.ParentID != result.Projects["w"] {
+		t.Errorf("sub-project parent = %q, want %q", client.ParentID, result.Projects["w"])
+	}
+
+	parent, child := dst.task("Parent"), dst.task("Child")
+	if parent == nil || child == nil {
+		t.Fatalf("tasks not restored: %+v", dst.tasks)
+	}
+	if parent.SectionID != result.Sections["s"] || child.ParentID != parent.ID || parent.Priority != 4 {
+		t.Errorf("parent=%+v child=%+v", parent, child)
+	}
+	if child.Due == nil || child.Due.String != "every tue" {
+		t.Errorf("recurring due not kept: %+v", child.Due)
+	}
+	if dst.task("Capture").ProjectID != "inbox2" {
+		t.Errorf("inbox task restored to %q", dst.task("Capture").ProjectID)
+	}
+	closed := map[string]string{}
+	for _, tk := range dst.completed {
+		closed[tk.Content] = tk.ParentID
+	}
+	if pid, ok := closed["Done already"]; len(closed) != 2 || !ok || pid != parent.ID {
+		t.Errorf("completed = %+v", dst.completed)
+	}
+	// Closing "Reopened parent" would complete its active subtask.
+	if dst.task("Reopened parent") == nil || result.TasksLeftOpen != 1 {
+		t.Errorf("parent of active subtask closed; left open = %d", result.TasksLeftOpen)
+	}
+
+	var notes []string
+	for _, cm := range dst.comments {
+		notes = append(notes, cm.Content+"@"+cm.TaskID+cm.ProjectID)
+	}
+	want := "Project note@" + result.Projects["c"] + ",Task note@" + parent.ID
+	if strings.Join(notes, ",") != want {
+		t.Errorf("comments = %v, want %s", notes, want)
+	}
+}
+
+func TestReadBackup_version(t *testing.T) {
+	if _, err := ReadBackup(strings.NewReader(`{"version":9}`)); err == nil || !strings.Contains(err.Error(), "unsupported backup version 9") {
+		t.Errorf("err = %v", err)
+	}
+	if _, err := ReadBackup(strings.NewReader(`not json`)); err == nil {
+		t.Error("expected parse error")
+	}
+}
@@ -0,0 +1,645 @@
+package todoist
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+	"gopkg.in/yaml.v3"
+)
+
+// Blueprint declares projects, their sections and tasks. It is written in
+// YAML or JSON and applied idempotently: objects are matched by name (tasks
+// by content within the same project, section and parent) so re-applying
+// updates what exists instead of creating duplicates. Nothing is deleted.
+type Blueprint struct {
+	Version  int                `yaml:"version" json:"version"`
+	Start    string             `yaml:"start" json:"start"`
+	Projects []BlueprintProject `yaml:"projects" json:"projects"`
+}
+
+// BlueprintProject is a project with optional sub-projects.
+type BlueprintProject struct {
+	Name     string             `yaml:"name" json:"name"`
+	Color    string             `yaml:"color" json:"color"`
+	Sections []BlueprintSection `yaml:"sections" json:"sections"`
+	Tasks    []BlueprintTask    `yaml:"tasks" json:"tasks"`
+	Projects []BlueprintProject `yaml:"projects" json:"projects"`
+}
+
+// BlueprintSection is a section and its tasks.
+type BlueprintSection struct {
+	Name  string          `yaml:"name" json:"name"`
+	Tasks []BlueprintTask `yaml:"tasks" json:"tasks"`
+}
+
+// BlueprintTask is a task with optional subtasks. Key names the task for
+// depends_on and "after" due dates. Due is "start", a relative offset such
+// as "+3d", "+2w from start" or "+1d after <key>", an ISO date, or any
+// other phrase, which Todoist parses. Priority is p1-p4, or 1-4 in API
+// numbering. Duration is minutes ("30", "30m", "1h30m") or days ("2d").
+type BlueprintTask struct {
+	Key         string          `yaml:"id" json:"id"`
+	Content     string          `yaml:"content" json:"content"`
+	Description string          `yaml:"description" json:"description"`
+	Due         string          `yaml:"due" json:"due"`
+	Priority    string          `yaml:"priority" json:"priority"`
+	Labels      []string        `yaml:"labels" json:"labels"`
+	Duration    string          `yaml:"duration" json:"duration"`
+	DependsOn   []string        `yaml:"depends_on" json:"depends_on"`
+	Subtasks    []BlueprintTask `yaml:"subtasks" json:"subtasks"`
+}
+
+// ParseBlueprint decodes a YAML or JSON blueprint (JSON is valid YAML) and
+// checks it for structural errors.
+func ParseBlueprint(data []byte) (*Blueprint, error) {
+	var bp Blueprint
+	dec := yaml.NewDecoder(strings.NewReader(string(data)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&bp); err != nil {
+		return nil, fmt.Errorf("invalid blueprint: %w", err)
+	}
+	if bp.Version > 1 {
+		return nil, fmt.Errorf("unsupported blueprint version %d", bp.Version)
+	}
+	if len(bp.Projects) == 0 {
+		return nil, fmt.Errorf("blueprint has no projects")
+	}
+	return &bp, nil
+}
+
+// BlueprintChange is one planned or applied change. Op is "create",
+// "update" or "unchanged"; Path names the object, e.g.
+// "Launch / Prep / Book venue".
+type BlueprintChange struct {
+	Op      string
+	Kind    string
+	Path    string
+	ID      string
+	Details []string
+}
+
+// String renders the change as a diff line.
+func (ch BlueprintChange) String() string {
+	sign := map[string]string{"create": "+", "update": "~", "unchanged": "="}[ch.Op]
+	s := fmt.Sprintf("%s %s %s", sign, ch.Kind, ch.Path)
+	switch {
+	case len(ch.Details) == 0:
+	case ch.Op == "create":
+		s += " (" + strings.Join(ch.Details, ", ") + ")"
+	default:
+		s += ": " + strings.Join(ch.Details, ", ")
+	}
+	return s
+}
+
+// blueprintTaskInfo is what the planner knows about a keyed task.
+type blueprintTaskInfo struct {
+	task    *BlueprintTask
+	due     time.Time
+	hasDate bool
+	state   int // 0 unvisited, 1 resolving, 2 done
+}
+
+var relativeDueRe = regexp.MustCompile(`^([+-]\d+)\s*([dwm])(?:\s+(?:from\s+start|after\s+(\S+)))?$`)
+
+// blueprintApplier walks a blueprint, diffing it against the account and
+// (unless dryRun) making the changes.
+type blueprintApplier struct {
+	c        *Client
+	dryRun   bool
+	start    time.Time
+	keys     map[string]*blueprintTaskInfo
+	tasks    []*BlueprintTask
+	projects []models.Project
+	changes  []BlueprintChange
+}
+
+// ApplyBlueprint diffs bp against the account and applies it. With dryRun
+// nothing is written and the returned changes are a preview. start is the
+// reference date for relative due dates; the blueprint's own start date
+// is used if start is zero. There is no default: counting from today
+// would move every relative due date each time the blueprint is
+// re-applied, so relative dates without a start are an error.
+func (c *Client) ApplyBlueprint(bp *Blueprint, start time.Time, dryRun bool) ([]BlueprintChange, error) {
+	a := &blueprintApplier{c: c, dryRun: dryRun, start: start, keys: map[string]*blueprintTaskInfo{}}
+	if a.start.IsZero() && bp.Start != "" {
+		t, err := time.ParseInLocation("2006-01-02", bp.Start, time.Local)
+		if err != nil {
+			return nil, fmt.Errorf("invalid start date %q: use YYYY-MM-DD", bp.Start)
+		}
+		a.start = t
+	}
+
+	if err := a.indexKeys(bp.Projects); err != nil {
+		return nil, err
+	}
+	for _, t := range a.tasks {
+		for _, dep := range t.DependsOn {
+			if _, ok := a.keys[dep]; !ok {
+				return nil, fmt.Errorf("task %q depends on unknown id %q", t.Content, dep)
+			}
+		}
+	}
+	for _, info := range a.keys {
+		if err := a.resolveDue(info); err != nil {
+			return nil, err
+		}
+	}
+
+	projects, err := c.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	a.projects = projects
+	for i := range bp.Projects {
+		if err := a.applyProject(&bp.Projects[i], "", ""); err != nil {
+			return a.changes, err
+		}
+	}
+	return a.changes, nil
+}
+
+func (a *blueprintApplier) indexKeys(projects []BlueprintProject) error {
+	var walk func(tasks []BlueprintTask) error
+	walk = func(tasks []BlueprintTask) error {
+		for i := range tasks {
+			t := &tasks[i]
+			if strings.TrimSpace(t.Content) == "" {
+				return fmt.Errorf("blueprint task without content")
+			}
+			a.tasks = append(a.tasks, t)
+			if t.Key != "" {
+				// "after" references match ids case-insensitively, so
+				// ids differing only in case would be ambiguous.
+				for key := range a.keys {
+					if key == t.Key {
+						return fmt.Errorf("duplicate task id %q", t.Key)
+					}
+					if strings.EqualFold(key, t.Key) {
+						return fmt.Errorf("task ids %q and %q differ only in case", key, t.Key)
+					}
+				}
+				a.keys[t.Key] = &blueprintTaskInfo{task: t}
+			}
+			if err := walk(t.Subtasks); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	for i := range projects {
+		p := &projects[i]
+		if strings.TrimSpace(p.Name) == "" {
+			return fmt.Errorf("blueprint project without a name")
+		}
+		if err := walk(p.Tasks); err != nil {
+			return err
+		}
+		for _, s := range p.Sections {
+			if strings.TrimSpace(s.Name) == "" {
+				return fmt.Errorf("section without a name in project %q", p.Name)
+			}
+			if err := walk(s.Tasks); err != nil {
+				return err
+			}
+		}
+		if err := a.indexKeys(p.Projects); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// resolveDue computes the fixed due date of a keyed task, following
+// "after" references and detecting dependency cycles.
+func (a *blueprintApplier) resolveDue(info *blueprintTaskInfo) error {
+	switch info.state {
+	case 1:
+		return fmt.Errorf("dependency cycle involving %q", info.task.Key)
+	case 2:
+		return nil
+	}
+	info.state = 1
+	for _, dep := range info.task.DependsOn {
+		if err := a.resolveDue(a.keys[dep]); err != nil {
+			return err
+		}
+	}
+	due, hasDate, _, err := a.due(info.task)
+	if err != nil {
+		return err
+	}
+	info.due, info.hasDate, info.state = due, hasDate, 2
+	return nil
+}
+
+// due interprets a task's due field. It returns either a fixed date or,
+// for phrases it does not understand, the phrase to pass to Todoist.
+func (a *blueprintApplier) due(t *BlueprintTask) (date time.Time, hasDate bool, phrase string, err error) {
+	s := strings.TrimSpace(t.Due)
+	switch {
+	case s == "":
+		return time.Time{}, false, "", nil
+	case strings.EqualFold(s, "start"):
+		if a.start.IsZero() {
+			return time.Time{}, false, "", errNoBlueprintStart(t)
+		}
+		return a.start, true, "", nil
+	}
+	if d, err := time.ParseInLocation("2006-01-02", s, time.Local); err == nil {
+		return d, true, "", nil
+	}
+
+	m := relativeDueRe.FindStringSubmatch(strings.ToLower(s))
+	if m == nil {
+		return time.Time{}, false, s, nil
+	}
+	n, _ := strconv.Atoi(m[1])
+	base := a.start
+	if ref := m[3]; ref != "" {
+		var info *blueprintTaskInfo
+		for key, i := range a.keys {
+			if strings.EqualFold(key, ref) {
+				info = i
+			}
+		}
+		if info == nil {
+			return time.Time{}, false, "", fmt.Errorf("task %q: due date refers to unknown id %q", t.Content, ref)
+		}
+		if err := a.resolveDue(info); err != nil {
+			return time.Time{}, false, "", err
+		}
+		if !info.hasDate {
+			return time.Time{}, false, "", fmt.Errorf("task %q: %q has no fixed due date to count from", t.Content, ref)
+		}
+		base = info.due
+	} else if base.IsZero() {
+		return time.Time{}, false, "", errNoBlueprintStart(t)
+	}
+	switch m[2] {
+	case "d":
+		return base.AddDate(0, 0, n), true, "", nil
+	case "w":
+		return base.AddDate(0, 0, 7*n), true, "", nil
+	default:
+		return base.AddDate(0, n, 0), true, "", nil
+	}
+}
+
+func errNoBlueprintStart(t *BlueprintTask) error {
+	return fmt.Errorf("task %q: relative due date %q needs a start date; set start in the blueprint", t.Content, t.Due)
+}
+
+func (a *blueprintApplier) record(ch BlueprintChange) {
+	a.changes = append(a.changes, ch)
+}
+
+func (a *blueprintApplier) applyProject(bp *BlueprintProject, parentID, parentPath string) error {
+	path := bp.Name
+	if parentPath != "" {
+		path = parentPath + " / " + bp.Name
+	}
+
+	var existing *models.Project
+	if parentID != "" || parentPath == "" {
+		for i := range a.projects {
+			p := &a.projects[i]
+			if strings.EqualFold(p.Name, bp.Name) && p.ParentID == parentID && !p.IsArchived {
+				existing = p
+				break
+			}
+		}
+	}
+
+	var projectID string
+	var sections []models.Section
+	var tasks []models.Task
+	if existing == nil {
+		body := map[string]interface{}{"name": bp.Name}
+		if bp.Color != "" {
+			body["color"] = bp.Color
+		}
+		if parentID != "" {
+			body["parent_id"] = parentID
+		}
+		ch := BlueprintChange{Op: "create", Kind: "project", Path: path}
+		if !a.dryRun {
+			p, err := a.c.CreateProject(body)
+			if err != nil {
+				return err
+			}
+			projectID, ch.ID = p.ID, p.ID
+		}
+		a.record(ch)
+	} else {
+		projectID = existing.ID
+		ch := BlueprintChange{Op: "unchanged", Kind: "project", Path: path, ID: existing.ID}
+		if bp.Color != "" && bp.Color != existing.Color {
+			ch.Op = "update"
+			ch.Details = append(ch.Details, fmt.Sprintf("color %s → %s", existing.Color, bp.Color))
+			if !a.dryRun {
+				if _, err := a.c.UpdateProject(existing.ID, map[string]interface{}{"color": bp.Color}); err != nil {
+					return err
+				}
+			}
+		}
+		a.record(ch)
+
+		var err error
+		if sections, err = a.c.GetSections(projectID); err != nil {
+			return err
+		}
+		if tasks, err = a.c.GetTasks(projectID, ""); err != nil {
+			return err
+		}
+	}
+
+	if err := a.applyTasks(bp.Tasks, projectID, "", "", path, tasks, existing == nil); err != nil {
+		return err
+	}
+	for _, bs := range bp.Sections {
+		spath := path + " / " + bs.Name
+		var sectionID string
+		fresh := existing == nil
+		for _, s := range sections {
+			if strings.EqualFold(s.Name, bs.Name) {
+				sectionID = s.ID
+			}
+		}
+		if sectionID != "" {
+			a.record(BlueprintChange{Op: "unchanged", Kind: "section", Path: spath, ID: sectionID})
+		} else {
+			fresh = true
+			ch := BlueprintChange{Op: "create", Kind: "section", Path: spath}
+			if !a.dryRun {
+				s, err := a.c.CreateSection(map[string]interface{}{"name": bs.Name, "project_id": projectID})
+				if err != nil {
+					return err
+				}
+				sectionID, ch.ID = s.ID, s.ID
+			}
+			a.record(ch)
+		}
+		if err := a.applyTasks(bs.Tasks, projectID, sectionID, "", spath, tasks, fresh); err != nil {
+			return err
+		}
+	}
+
+	for i := range bp.Projects {
+		if err := a.applyProject(&bp.Projects[i], projectID, path); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// applyTasks creates or updates tasks under one parent. existing holds
+// the project's active tasks. fresh reports that the parent was just
+// created (or would be, in a dry run), so nothing under it can match.
+func (a *blueprintApplier) applyTasks(bts []BlueprintTask, projectID, sectionID, parentID, path string, existing []models.Task, fresh bool) error {
+	for i := range bts {
+		bt := &bts[i]
+		tpath := path + " / " + bt.Content
+		want, err := a.taskFields(bt)
+		if err != nil {
+			return err
+		}
+
+		var match *models.Task
+		if !fresh {
+			for j := range existing {
+				t := &existing[j]
+				if t.ParentID == parentID && t.SectionID == sectionID && strings.EqualFold(strings.TrimSpace(t.Content), strings.TrimSpace(bt.Content)) {
+					match = t
+					break
+				}
+			}
+		}
+
+		var taskID string
+		if match == nil {
+			ch := BlueprintChange{Op: "create", Kind: "task", Path: tpath, Details: describeBlueprintFields(want)}
+			if !a.dryRun {
+				body := map[string]interface{}{"content": bt.Content, "project_id": projectID}
+				if sectionID != "" {
+					body["section_id"] = sectionID
+				}
+				if parentID != "" {
+					body["parent_id"] = parentID
+				}
+				for k, v := range want {
+					body[k] = v
+				}
+				t, err := a.c.CreateTask(body)
+				if err != nil {
+					return err
+				}
+				taskID, ch.ID = t.ID, t.ID
+			}
+			a.record(ch)
+		} else {
+			taskID = match.ID
+			update, details := diffBlueprintTask(match, want)
+			ch := BlueprintChange{Op: "unchanged", Kind: "task", Path: tpath, ID: match.ID}
+			if len(update) > 0 {
+				ch.Op, ch.Details = "update", details
+				if !a.dryRun {
+					if _, err := a.c.UpdateTask(match.ID, update); err != nil {
+						return err
+					}
+				}
+			}
+			a.record(ch)
+		}
+
+		if err := a.applyTasks(bt.Subtasks, projectID, sectionID, taskID, tpath, existing, match == nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// taskFields builds the API fields a blueprint task should have. Keys are
+// only present for fields the blueprint sets.
+func (a *blueprintApplier) taskFields(bt *BlueprintTask) (map[string]interface{}, error) {
+	f := map[string]interface{}{}
+
+	desc := strings.TrimSpace(bt.Description)
+	if len(bt.DependsOn) > 0 {
+		var names []string
+		for _, dep := range bt.DependsOn {
+			names = append(names, a.keys[dep].task.Content)
+		}
+		if desc != "" {
+			desc += "\n\n"
+		}
+		desc += "Depends on: " + strings.Join(names, ", ")
+	}
+	if desc != "" {
+		f["description"] = desc
+	}
+
+	if bt.Priority != "" {
+		p, err := parseBlueprintPriority(bt.Priority)
+		if err != nil {
+			return nil, fmt.Errorf("task %q: %w", bt.Content, err)
+		}
+		f["priority"] = p
+	}
+	if len(bt.Labels) > 0 {
+		f["labels"] = bt.Labels
+	}
+
+	date, hasDate, phrase, err := a.due(bt)
+	if err != nil {
+		return nil, err
+	}
+	if hasDate {
+		f["due_date"] = date.Format("2006-01-02")
+	} else if phrase != "" {
+		f["due_string"] = phrase
+	}
+
+	if bt.Duration != "" {
+		amount, unit, err := parseBlueprintDuration(bt.Duration)
+		if err != nil {
+			return nil, fmt.Errorf("task %q: %w", bt.Content, err)
+		}
+		f["duration"] = amount
+		f["duration_unit"] = unit
+	}
+	return f, nil
+}
+
+func parseBlueprintPriority(s string) (int, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasPrefix(s, "p") {
+		n, err := strconv.Atoi(s[1:])
+		if err != nil || n < 1 || n > 4 {
+			return 0, fmt.Errorf("invalid priority %q", s)
+		}
+		return 5 - n, nil
+	}
+	n, err := strconv.Atoi(s)
+	if err != nil || n < 1 || n > 4 {
+		return 0, fmt.Errorf("invalid priority %q", s)
+	}
+	return n, nil
+}
+
+func parseBlueprintDuration(s string) (int, string, error) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	if strings.HasSuffix(s, "d") {
+		n, err := strconv.Atoi(strings.TrimSuffix(s, "d"))
+		if err != nil || n <= 0 {
+			return 0, "", fmt.Errorf("invalid duration %q", s)
+		}
+		return n, "day", nil
+	}
+	if n, err := strconv.Atoi(s); err == nil && n > 0 {
+		return n, "minute", nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d < time.Minute {
+		return 0, "", fmt.Errorf("invalid duration %q", s)
+	}
+	return int(d.Minutes()), "minute", nil
+}
+
+func describeBlueprintFields(f map[string]interface{}) []string {
+	var out []string
+	if v, ok := f["due_date"]; ok {
+		out = append(out, fmt.Sprintf("due %v", v))
+	}
+	if v, ok := f["due_string"]; ok {
+		out = append(out, fmt.Sprintf("due \"%v\"", v))
+	}
+	if v, ok := f["priority"].(int); ok && v > 1 {
+		out = append(out, fmt.Sprintf("p%d", 5-v))
+	}
+	if v, ok := f["labels"].([]string); ok {
+		out = append(out, "@"+strings.Join(v, " @"))
+	}
+	if v, ok := f["duration"]; ok {
+		out = append(out, fmt.Sprintf("%v %s", v, f["duration_unit"]))
+	}
+	return out
+}
+
+// diffBlueprintTask returns the update body needed to bring t in line
+// with the wanted fields, and a description of each change.
+func diffBlueprintTask(t *models.Task, want map[string]interface{}) (map[string]interface{}, []string) {
+	update := map[string]interface{}{}
+	var details []string
+
+	if v, ok := want["description"].(string); ok && v != t.Description {
+		update["description"] = v
+		details = append(details, "description")
+	}
+	if v, ok := want["priority"].(int); ok && v != taskPriority(t) {
+		update["priority"] = v
+		details = append(details, fmt.Sprintf("priority p%d → p%d", 5-taskPriority(t), 5-v))
+	}
+	if v, ok := want["labels"].([]string); ok && !sameLabels(t.Labels, v) {
+		update["labels"] = v
+		details = append(details, fmt.Sprintf("labels [%s] → [%s]", strings.Join(t.Labels, ", "), strings.Join(v, ", ")))
+	}
+
+	current := ""
+	if t.Due != nil {
+		current = t.Due.Date
+		if len(current) > 10 {
+			current = current[:10]
+		}
+	}
+	if v, ok := want["due_date"].(string); ok && v != current {
+		update["due_date"] = v
+		details = append(details, fmt.Sprintf("due %s → %s", orNone(current), v))
+	}
+	if v, ok := want["due_string"].(string); ok && (t.Due == nil || !strings.EqualFold(t.Due.String, v)) {
+		update["due_string"] = v
+		old := ""
+		if t.Due != nil {
+			old = t.Due.String
+		}
+		details = append(details, fmt.Sprintf("due \"%s\" → \"%s\"", orNone(old), v))
+	}
+
+	if v, ok := want["duration"].(int); ok {
+		unit := want["duration_unit"].(string)
+		if t.Duration == nil || t.Duration.Amount != v || t.Duration.Unit != unit {
+			update["duration"] = v
+			update["duration_unit"] = unit
+			details = append(details, fmt.Sprintf("duration %d %s", v, unit))
+		}
+	}
+	return update, details
+}
+
+func sameLabels(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	x := append([]string(nil), a...)
+	y := append([]string(nil), b...)
+	sort.Strings(x)
+	sort.Strings(y)
+	for i := range x {
+		if !strings.EqualFold(x[i], y[i]) {
+			return false
+		}
+	}
+	return true
+}
+
+func orNone(s string) string {
+	if s == "" {
+		return "none"
+	}
+	return s
+}
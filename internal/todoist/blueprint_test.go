@@ -0,0 +1,190 @@
+package todoist
+
+import (
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+const testBlueprint = `
+version: 1
+start: 2026-11-02
+projects:
+  - name: Launch
+    tasks:
+      - content: Kickoff
+        id: kickoff
+        due: start
+        priority: p1
+    sections:
+      - name: Prep
+        tasks:
+          - content: Book venue
+            id: venue
+            due: +3d
+            labels: [calls]
+            duration: 1h30m
+            subtasks:
+              - content: Compare quotes
+                due: +1d from start
+          - content: Send invites
+            due: +2d after venue
+            depends_on: [venue, kickoff]
+    projects:
+      - name: Marketing
+        tasks:
+          - content: Draft post
+            due: every friday
+`
+
+func TestApplyBlueprint(t *testing.T) {
+	acct := &fakeAccount{projects: []models.Project{{ID: "inbox", Name: "Inbox", IsInboxProject: true}}}
+	c, srv := testServer(t, acct.handler(t))
+	defer srv.Close()
+
+	bp, err := ParseBlueprint([]byte(testBlueprint))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A dry run previews everything without writing.
+	changes, err := c.ApplyBlueprint(bp, time.Time{}, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acct.writes) != 0 {
+		t.Fatalf("dry run wrote: %v", acct.writes)
+	}
+	var preview []string
+	for _, ch := range changes {
+		preview = append(preview, ch.String())
+	}
+	want := []string{
+		"+ project Launch",
+		"+ task Launch / Kickoff (due 2026-11-02, p1)",
+		"+ section Launch / Prep",
+		"+ task Launch / Prep / Book venue (due 2026-11-05, @calls, 90 minute)",
+		"+ task Launch / Prep / Book venue / Compare quotes (due 2026-11-03)",
+		"+ task Launch / Prep / Send invites (due 2026-11-07)",
+		"+ project Launch / Marketing",
+		`+ task Launch / Marketing / Draft post (due "every friday")`,
+	}
+	if strings.Join(preview, "\n") != strings.Join(want, "\n") {
+		t.Fatalf("preview =\n%s\nwant\n%s", strings.Join(preview, "\n"), strings.Join(want, "\n"))
+	}
+
+	if _, err := c.ApplyBlueprint(bp, time.Time{}, false); err != nil {
+		t.Fatal(err)
+	}
+	if len(acct.projects) != 3 || len(acct.sections) != 1 || len(acct.tasks) != 5 {
+		t.Fatalf("projects=%d sections=%d tasks=%d", len(acct.projects), len(acct.sections), len(acct.tasks))
+	}
+	if p := acct.projects[2]; p.Name != "Marketing" || p.ParentID != acct.projects[1].ID {
+		t.Errorf("sub-project = %+v", p)
+	}
+	venue := acct.task("Book venue")
+	if sub := acct.task("Compare quotes"); sub.ParentID != venue.ID || sub.SectionID != venue.SectionID {
+		t.Errorf("subtask = %+v", sub)
+	}
+	if d := acct.task("Send invites").Description; d != "Depends on: Book venue, Kickoff" {
+		t.Errorf("description = %q", d)
+	}
+
+	// Re-applying with a changed blueprint updates instead of duplicating.
+	acct.writes = nil
+	bp2, _ := ParseBlueprint([]byte(strings.Replace(testBlueprint, "priority: p1", "priority: p2", 1)))
+	changes, err = c.ApplyBlueprint(bp2, time.Time{}, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(acct.tasks) != 5 || len(acct.projects) != 3 {
+		t.Fatalf("re-apply duplicated objects: %d tasks, %d projects", len(acct.tasks), len(acct.projects))
+	}
+	var updates []string
+	for _, ch := range changes {
+		if ch.Op != "unchanged" {
+			updates = append(updates, ch.String())
+		}
+	}
+	if len(updates) != 1 || updates[0] != "~ task Launch / Kickoff: priority p1 → p2" {
+		t.Errorf("updates = %v", updates)
+	}
+	if len(acct.writes) != 1 {
+		t.Errorf("writes = %v", acct.writes)
+	}
+}
+
+func TestApplyBlueprint_errors(t *testing.T) {
+	tests := []struct {
+		name, yaml, want string
+	}{
+		{"unknown dependency", "projects: [{name: P, tasks: [{content: A, depends_on: [nope]}]}]", `depends on unknown id "nope"`},
+		{"cycle", "projects: [{name: P, tasks: [{content: A, id: a, due: +1d after b}, {content: B, id: b, due: +1d after a}]}]", "dependency cycle"},
+		{"duplicate id", "projects: [{name: P, tasks: [{content: A, id: a}, {content: B, id: a}]}]", `duplicate task id "a"`},
+		{"ids differing in case", "projects: [{name: P, tasks: [{content: A, id: venue}, {content: B, id: Venue}]}]", `task ids "venue" and "Venue" differ only in case`},
+		{"floating base", "projects: [{name: P, tasks: [{content: A, id: a, due: every day}, {content: B, due: +1d after a}]}]", "no fixed due date"},
+		{"bad priority", "projects: [{name: P, tasks: [{content: A, priority: p7}]}]", "invalid priority"},
+		{"bad duration", "projects: [{name: P, tasks: [{content: A, duration: soon}]}]", "invalid duration"},
+	}
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	defer srv.Close()
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			bp, err := ParseBlueprint([]byte(tt.yaml))
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = c.ApplyBlueprint(bp, time.Date(2026, 11, 2, 0, 0, 0, 0, time.Local), true)
+			if err == nil || !strings.Contains(err.Error(), tt.want) {
+				t.Errorf("error = %v, want %q", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestApplyBlueprint_requiresStart(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	defer srv.Close()
+
+	for _, due := range []string{"start", "+3d", "+1w from start"} {
+		bp, err := ParseBlueprint([]byte("projects: [{name: P, tasks: [{content: A, due: " + due + "}]}]"))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := c.ApplyBlueprint(bp, time.Time{}, true); err == nil || !strings.Contains(err.Error(), "needs a start date") {
+			t.Errorf("due %q: error = %v", due, err)
+		}
+	}
+
+	// Fixed dates, phrases and offsets from a fixed-date task need no start.
+	bp, err := ParseBlueprint([]byte("projects: [{name: P, tasks: [{content: A, id: a, due: 2026-11-02}, {content: B, due: every day}, {content: C, due: +1d after a}]}]"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := c.ApplyBlueprint(bp, time.Time{}, true); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestParseBlueprint(t *testing.T) {
+	if _, err := ParseBlueprint([]byte(`{"projects":[{"name":"P","tasks":[{"content":"A","priority":4}]}]}`)); err != nil {
+		t.Errorf("JSON blueprint: %v", err)
+	}
+	if _, err := ParseBlueprint([]byte("projects: [{name: P, colour: red}]")); err == nil {
+		t.Error("expected error for unknown field")
+	}
+	if _, err := ParseBlueprint([]byte("version: 2\nprojects: [{name: P}]")); err == nil {
+		t.Error("expected error for unsupported version")
+	}
+	if _, err := ParseBlueprint([]byte("version: 1")); err == nil {
+		t.Error("expected error for empty blueprint")
+	}
+}
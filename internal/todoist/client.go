@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"io"
 	"net/http"
+	"net/url"
 	"strings"
 	"time"
 )
@@ -24,6 +25,37 @@ type PaginatedResponse[T any] struct {
 	NextCursor string `json:"next_cursor"`
 }
 
+// getAll fetches every page of a paginated list endpoint by following
+// next_cursor. what names the resource in parse errors.
+func getAll[T any](c *Client, endpoint string, params url.Values, what string) ([]T, error) {
+	q := url.Values{}
+	for k, v := range params {
+		q[k] = v
+	}
+
+	var all []T
+	for {
+		u := endpoint
+		if len(q) > 0 {
+			u += "?" + q.Encode()
+		}
+		data, err := c.do("GET", u, nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page PaginatedResponse[T]
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse %s: %w", what, err)
+		}
+		all = append(all, page.Results...)
+		if page.NextCursor == "" {
+			return all, nil
+		}
+		q.Set("cursor", page.NextCursor)
+	}
+}
+
 // Option configures a Client.
 type Option func(*Client)
 
@@ -61,14 +93,19 @@ func (c *Client) do(method, endpoint string, body interface{}) ([]byte, error) {
 		}
 		reqBody = strings.NewReader(string(data))
 	}
+	return c.send(method, endpoint, "application/json", reqBody)
+}
 
-	req, err := http.NewRequest(method, c.baseURL+endpoint, reqBody)
+// send executes a request with an already-encoded body of the given
+// content type. It is shared by JSON, form and multipart requests.
+func (c *Client) send(method, endpoint, contentType string, body io.Reader) ([]byte, error) {
+	req, err := http.NewRequest(method, c.baseURL+endpoint, body)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	req.Header.Set("Authorization", "Bearer "+c.token)
-	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Content-Type", contentType)
 
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
@@ -0,0 +1,96 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// GetProjectCollaborators returns the users a project is shared with.
+func (c *Client) GetProjectCollaborators(projectID string) ([]models.Collaborator, error) {
+	return getAll[models.Collaborator](c, "/projects/"+projectID+"/collaborators", nil, "collaborators")
+}
+
+// workspaceUser is the wire format of a workspace member.
+type workspaceUser struct {
+	UserID      string `json:"user_id"`
+	WorkspaceID string `json:"workspace_id"`
+	Email       string `json:"user_email"`
+	FullName    string `json:"full_name"`
+	Timezone    string `json:"timezone"`
+	Role        string `json:"role"`
+	ImageID     string `json:"image_id"`
+	IsDeleted   bool   `json:"is_deleted"`
+}
+
+// GetWorkspaceMembers returns the members of a workspace. An empty
+// workspaceID returns members of all workspaces the user belongs to.
+func (c *Client) GetWorkspaceMembers(workspaceID string) ([]models.Collaborator, error) {
+	endpoint := "/workspaces/users"
+	if workspaceID != "" {
+		endpoint += "?" + url.Values{"workspace_id": {workspaceID}}.Encode()
+	}
+
+	data, err := c.do("GET", endpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var resp struct {
+		WorkspaceUsers []workspaceUser `json:"workspace_users"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse workspace members: %w", err)
+	}
+
+	var members []models.Collaborator
+	for _, u := range resp.WorkspaceUsers {
+		if u.IsDeleted {
+			continue
+		}
+		members = append(members, models.Collaborator{
+			ID:          u.UserID,
+			Name:        u.FullName,
+			Email:       u.Email,
+			Timezone:    u.Timezone,
+			ImageID:     u.ImageID,
+			Role:        u.Role,
+			WorkspaceID: u.WorkspaceID,
+		})
+	}
+	return members, nil
+}
+
+// MatchCollaborator finds a collaborator by email or name. Exact email or
+// name matches (case-insensitive) win; otherwise a unique partial name or
+// email match is accepted. It returns nil if nothing matches and an error
+// if a partial query is ambiguous.
+func MatchCollaborator(collaborators []models.Collaborator, query string) (*models.Collaborator, error) {
+	q := strings.ToLower(strings.TrimSpace(query))
+	for i := range collaborators {
+		if strings.ToLower(collaborators[i].Email) == q || strings.ToLower(collaborators[i].Name) == q {
+			return &collaborators[i], nil
+		}
+	}
+
+	var matches []*models.Collaborator
+	for i := range collaborators {
+		if strings.Contains(strings.ToLower(collaborators[i].Name), q) || strings.Contains(strings.ToLower(collaborators[i].Email), q) {
+			matches = append(matches, &collaborators[i])
+		}
+	}
+	switch len(matches) {
+	case 0:
+		return nil, nil
+	case 1:
+		return matches[0], nil
+	}
+	names := make([]string, len(matches))
+	for i, m := range matches {
+		names[i] = fmt.Sprintf("%s <%s>", m.Name, m.Email)
+	}
+	return nil, fmt.Errorf("%q matches several collaborators: %s", query, strings.Join(names, ", "))
+}
@@ -0,0 +1,81 @@
+package todoist
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestGetProjectCollaborators(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/projects/p1/collaborators" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"u1","name":"Ada Lovelace","email":"ada@example.com"}],"next_cursor":""}`))
+	})
+	defer srv.Close()
+
+	people, err := c.GetProjectCollaborators("p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people) != 1 || people[0].Email != "ada@example.com" {
+		t.Errorf("unexpected collaborators: %+v", people)
+	}
+}
+
+func TestGetWorkspaceMembers(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/workspaces/users" || r.URL.Query().Get("workspace_id") != "w1" {
+			t.Errorf("url = %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"workspace_users":[
+			{"user_id":"u1","workspace_id":"w1","user_email":"ada@example.com","full_name":"Ada","role":"ADMIN"},
+			{"user_id":"u2","workspace_id":"w1","user_email":"gone@example.com","full_name":"Gone","is_deleted":true}
+		],"has_more":false}`))
+	})
+	defer srv.Close()
+
+	people, err := c.GetWorkspaceMembers("w1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(people) != 1 || people[0].ID != "u1" || people[0].Role != "ADMIN" {
+		t.Errorf("unexpected members: %+v", people)
+	}
+}
+
+func TestMatchCollaborator(t *testing.T) {
+	people := []models.Collaborator{
+		{ID: "u1", Name: "Ada Lovelace", Email: "ada@example.com"},
+		{ID: "u2", Name: "Alan Turing", Email: "alan@example.com"},
+		{ID: "u3", Name: "Ada", Email: "ada2@example.com"},
+	}
+	tests := []struct {
+		query   string
+		wantID  string
+		wantErr bool
+	}{
+		{"alan@example.com", "u2", false},
+		{"ADA", "u3", false},
+		{"turing", "u2", false},
+		{"lovelace", "u1", false},
+		{"a", "", true},
+		{"grace", "", false},
+	}
+	for _, tt := range tests {
+		got, err := MatchCollaborator(people, tt.query)
+		if (err != nil) != tt.wantErr {
+			t.Errorf("MatchCollaborator(%q) err = %v", tt.query, err)
+			continue
+		}
+		id := ""
+		if got != nil {
+			id = got.ID
+		}
+		if id != tt.wantID {
+			t.Errorf("MatchCollaborator(%q) = %q, want %q", tt.query, id, tt.wantID)
+		}
+	}
+}
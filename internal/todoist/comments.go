@@ -3,6 +3,7 @@ package todoist
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/nsega/mcp-todoist/internal/models"
 )
@@ -10,23 +11,13 @@ import (
 // GetComments returns comments for a task or project.
 // Exactly one of taskID or projectID should be non-empty.
 func (c *Client) GetComments(taskID, projectID string) ([]models.Comment, error) {
-	endpoint := "/comments?"
+	params := url.Values{}
 	if taskID != "" {
-		endpoint += "task_id=" + taskID
+		params.Set("task_id", taskID)
 	} else if projectID != "" {
-		endpoint += "project_id=" + projectID
+		params.Set("project_id", projectID)
 	}
-
-	data, err := c.do("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var page PaginatedResponse[models.Comment]
-	if err := json.Unmarshal(data, &page); err != nil {
-		return nil, fmt.Errorf("failed to parse comments: %w", err)
-	}
-	return page.Results, nil
+	return getAll[models.Comment](c, "/comments", params, "comments")
 }
 
 // CreateComment creates a new comment.
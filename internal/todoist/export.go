@@ -0,0 +1,228 @@
+package todoist
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// ExportColumns lists the columns ExportTasks can write, in their default
+// order.
+var ExportColumns = []string{
+	"id", "content", "description", "project", "section", "parent", "priority",
+	"due", "due_string", "recurring", "duration", "deadline", "labels",
+	"assignee_id", "comment_count", "created_at", "url",
+}
+
+// DefaultExportColumns are written when no columns are selected.
+var DefaultExportColumns = []string{"content", "project", "section", "priority", "due", "labels"}
+
+// ExportNames resolves IDs to names for export; missing IDs are written
+// as-is.
+type ExportNames struct {
+	Projects map[string]string
+	Sections map[string]string
+	Tasks    map[string]string
+}
+
+// ValidateExportColumns checks that every column is known.
+func ValidateExportColumns(cols []string) error {
+	known := map[string]bool{}
+	for _, c := range ExportColumns {
+		known[c] = true
+	}
+	for _, c := range cols {
+		if !known[c] {
+			return fmt.Errorf("unknown column %q (available: %s)", c, strings.Join(ExportColumns, ", "))
+		}
+	}
+	return nil
+}
+
+// exportValue returns a column's value: []string for labels, bool for
+// recurring, int for comment_count and string otherwise.
+func exportValue(t models.Task, col string, names ExportNames) interface{} {
+	name := func(m map[string]string, id string) string {
+		if n, ok := m[id]; ok {
+			return n
+		}
+		return id
+	}
+	switch col {
+	case "id":
+		return t.ID
+	case "content":
+		return t.Content
+	case "description":
+		return t.Description
+	case "project":
+		return name(names.Projects, t.ProjectID)
+	case "section":
+		return name(names.Sections, t.SectionID)
+	case "parent":
+		return name(names.Tasks, t.ParentID)
+	case "priority":
+		return fmt.Sprintf("p%d", 5-max(1, min(4, t.Priority)))
+	case "due":
+		if t.Due == nil {
+			return ""
+		}
+		if t.Due.Datetime != "" {
+			return t.Due.Datetime
+		}
+		return t.Due.Date
+	case "due_string":
+		if t.Due == nil {
+			return ""
+		}
+		return t.Due.String
+	case "recurring":
+		return t.Due != nil && t.Due.Recurring
+	case "duration":
+		if t.Duration == nil {
+			return ""
+		}
+		return fmt.Sprintf("%d %s", t.Duration.Amount, t.Duration.Unit)
+	case "deadline":
+		if t.Deadline == nil {
+			return ""
+		}
+		return t.Deadline.Date
+	case "labels":
+		if t.Labels == nil {
+			return []string{}
+		}
+		return t.Labels
+	case "assignee_id":
+		return t.AssigneeID
+	case "comment_count":
+		return t.CommentCount
+	case "created_at":
+		if t.CreatedAt.IsZero() {
+			return ""
+		}
+		return t.CreatedAt.UTC().Format("2006-01-02T15:04:05Z")
+	case "url":
+		return t.URL
+	}
+	return ""
+}
+
+// exportText flattens a column value for CSV and markdown.
+func exportText(v interface{}) string {
+	switch v := v.(type) {
+	case []string:
+		return strings.Join(v, ", ")
+	case bool:
+		if v {
+			return "yes"
+		}
+		return ""
+	}
+	return fmt.Sprint(v)
+}
+
+// ExportTasks writes tasks in the given format: "csv" with a header row,
+// "jsonl" with one JSON object per task, or "markdown" as a report with
+// one table per project. Columns default to DefaultExportColumns.
+func ExportTasks(w io.Writer, format string, tasks []models.Task, cols []string, names ExportNames) error {
+	if len(cols) == 0 {
+		cols = DefaultExportColumns
+	}
+	if err := ValidateExportColumns(cols); err != nil {
+		return err
+	}
+
+	switch format {
+	case "csv":
+		cw := csv.NewWriter(w)
+		if err := cw.Write(cols); err != nil {
+			return err
+		}
+		for _, t := range tasks {
+			row := make([]string, len(cols))
+			for i, col := range cols {
+				row[i] = exportText(exportValue(t, col, names))
+			}
+			if err := cw.Write(row); err != nil {
+				return err
+			}
+		}
+		cw.Flush()
+		return cw.Error()
+
+	case "jsonl":
+		enc := json.NewEncoder(w)
+		for _, t := range tasks {
+			// A map would lose the column order, so build the object
+			// by hand.
+			var sb strings.Builder
+			sb.WriteString("{")
+			for i, col := range cols {
+				v, err := json.Marshal(exportValue(t, col, names))
+				if err != nil {
+					return fmt.Errorf("failed to marshal %s: %w", col, err)
+				}
+				if i > 0 {
+					sb.WriteString(",")
+				}
+				fmt.Fprintf(&sb, "%q:%s", col, v)
+			}
+			sb.WriteString("}")
+			if err := enc.Encode(json.RawMessage(sb.String())); err != nil {
+				return err
+			}
+		}
+		return nil
+
+	case "markdown":
+		return writeMarkdownReport(w, tasks, cols, names)
+	}
+	return fmt.Errorf("unknown format %q (use csv, jsonl or markdown)", format)
+}
+
+// writeMarkdownReport groups tasks by project, in first-seen order, and
+// writes a table of the remaining columns for each.
+func writeMarkdownReport(w io.Writer, tasks []models.Task, cols []string, names ExportNames) error {
+	var tableCols []string
+	for _, c := range cols {
+		if c != "project" {
+			tableCols = append(tableCols, c)
+		}
+	}
+	if len(tableCols) == 0 {
+		tableCols = []string{"content"}
+	}
+
+	var order []string
+	byProject := map[string][]models.Task{}
+	for _, t := range tasks {
+		if _, ok := byProject[t.ProjectID]; !ok {
+			order = append(order, t.ProjectID)
+		}
+		byProject[t.ProjectID] = append(byProject[t.ProjectID], t)
+	}
+
+	cell := strings.NewReplacer("|", `\|`, "\r\n", "<br>", "\n", "<br>")
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "# Task Export\n\n%d tasks in %d projects\n", len(tasks), len(order))
+	for _, pid := range order {
+		project := exportText(exportValue(models.Task{ProjectID: pid}, "project", names))
+		fmt.Fprintf(&sb, "\n## %s (%d)\n\n", project, len(byProject[pid]))
+		sb.WriteString("| " + strings.Join(tableCols, " | ") + " |\n")
+		sb.WriteString("|" + strings.Repeat("---|", len(tableCols)) + "\n")
+		for _, t := range byProject[pid] {
+			row := make([]string, len(tableCols))
+			for i, col := range tableCols {
+				row[i] = cell.Replace(exportText(exportValue(t, col, names)))
+			}
+			sb.WriteString("| " + strings.Join(row, " | ") + " |\n")
+		}
+	}
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
@@ -0,0 +1,74 @@
+package todoist
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+var exportTasks = []models.Task{
+	{ID: "1", Content: "Draft | outline", ProjectID: "p1", SectionID: "s1", Priority: 4, Labels: []string{"writing", "deep"},
+		Due: &models.DueDate{Date: "2026-10-20", String: "every tue", Recurring: true}},
+	{ID: "2", Content: "Find sources", ProjectID: "p1", ParentID: "1", Priority: 1,
+		Duration: &models.Duration{Amount: 45, Unit: "minute"}},
+	{ID: "3", Content: "Buy stamps", ProjectID: "p2", Priority: 2, Description: "first class\nfor cards"},
+}
+
+var exportNames = ExportNames{
+	Projects: map[string]string{"p1": "Book", "p2": "Errands"},
+	Sections: map[string]string{"s1": "Research"},
+	Tasks:    map[string]string{"1": "Draft | outline"},
+}
+
+func TestExportTasks_csv(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportTasks(&buf, "csv", exportTasks, nil, exportNames); err != nil {
+		t.Fatal(err)
+	}
+	want := "content,project,section,priority,due,labels\n" +
+		"Draft | outline,Book,Research,p1,2026-10-20,\"writing, deep\"\n" +
+		"Find sources,Book,,p4,,\n" +
+		"Buy stamps,Errands,,p3,,\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestExportTasks_jsonl(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportTasks(&buf, "jsonl", exportTasks[:2], []string{"id", "parent", "recurring", "labels", "duration"}, exportNames); err != nil {
+		t.Fatal(err)
+	}
+	want := `{"id":"1","parent":"","recurring":true,"labels":["writing","deep"],"duration":""}` + "\n" +
+		`{"id":"2","parent":"Draft | outline","recurring":false,"labels":[],"duration":"45 minute"}` + "\n"
+	if buf.String() != want {
+		t.Errorf("got:\n%s\nwant:\n%s", buf.String(), want)
+	}
+}
+
+func TestExportTasks_markdown(t *testing.T) {
+	var buf bytes.Buffer
+	if err := ExportTasks(&buf, "markdown", exportTasks, []string{"project", "content", "description"}, exportNames); err != nil {
+		t.Fatal(err)
+	}
+	for _, want := range []string{
+		"3 tasks in 2 projects",
+		"## Book (2)\n\n| content | description |\n|---|---|\n| Draft \\| outline |  |\n",
+		"## Errands (1)\n\n| content | description |\n|---|---|\n| Buy stamps | first class<br>for cards |\n",
+	} {
+		if !strings.Contains(buf.String(), want) {
+			t.Errorf("report missing %q:\n%s", want, buf.String())
+		}
+	}
+}
+
+func TestExportTasks_errors(t *testing.T) {
+	if err := ExportTasks(&bytes.Buffer{}, "csv", nil, []string{"colour"}, ExportNames{}); err == nil || !strings.Contains(err.Error(), `unknown column "colour"`) {
+		t.Errorf("err = %v", err)
+	}
+	if err := ExportTasks(&bytes.Buffer{}, "xlsx", nil, nil, ExportNames{}); err == nil {
+		t.Error("expected error for unknown format")
+	}
+}
@@ -0,0 +1,202 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// fakeAccount is a minimal in-memory Todoist account for tests that
+// create and read back many objects, such as blueprints and backups.
+type fakeAccount struct {
+	mu        sync.Mutex
+	projects  []models.Project
+	sections  []models.Section
+	tasks     []models.Task
+	completed []models.Task
+	comments  []models.Comment
+	labels    []models.Label
+	writes    []string
+	nextID    int
+	pageSize  int
+}
+
+func (f *fakeAccount) id(prefix string) string {
+	f.nextID++
+	return fmt.Sprintf("%s%d", prefix, f.nextID)
+}
+
+func (f *fakeAccount) handler(t *testing.T) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		f.mu.Lock()
+		defer f.mu.Unlock()
+		var body map[string]interface{}
+		if r.Method == http.MethodPost {
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			f.writes = append(f.writes, r.URL.Path)
+		}
+		q := r.URL.Query()
+		str := func(k string) string { s, _ := body[k].(string); return s }
+		write := func(v interface{}) { _ = json.NewEncoder(w).Encode(v) }
+
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/projects":
+			writePage(w, f.projects, q.Get("cursor"), f.pageSize)
+		case r.Method == http.MethodGet && r.URL.Path == "/sections":
+			var out []models.Section
+			for _, s := range f.sections {
+				if q.Get("project_id") == "" || s.ProjectID == q.Get("project_id") {
+					out = append(out, s)
+				}
+			}
+			writePage(w, out, q.Get("cursor"), f.pageSize)
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks":
+			var out []models.Task
+			for _, tk := range f.tasks {
+				if q.Get("project_id") == "" || tk.ProjectID == q.Get("project_id") {
+					out = append(out, tk)
+				}
+			}
+			writePage(w, out, q.Get("cursor"), f.pageSize)
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks/completed/by_completion_date":
+			page, next := pageOf(f.completed, q.Get("cursor"), f.pageSize)
+			write(map[string]interface{}{"items": page, "next_cursor": next})
+		case r.Method == http.MethodGet && r.URL.Path == "/labels":
+			writePage(w, f.labels, q.Get("cursor"), f.pageSize)
+		case r.Method == http.MethodGet && r.URL.Path == "/comments":
+			var out []models.Comment
+			for _, cm := range f.comments {
+				if (q.Get("task_id") != "" && cm.TaskID == q.Get("task_id")) || (q.Get("project_id") != "" && cm.ProjectID == q.Get("project_id")) {
+					out = append(out, cm)
+				}
+			}
+			writePage(w, out, q.Get("cursor"), f.pageSize)
+		case r.URL.Path == "/projects":
+			p := models.Project{ID: f.id("p"), Name: str("name"), ParentID: str("parent_id"), Color: str("color")}
+			f.projects = append(f.projects, p)
+			write(p)
+		case r.URL.Path == "/sections":
+			s := models.Section{ID: f.id("s"), Name: str("name"), ProjectID: str("project_id")}
+			f.sections = append(f.sections, s)
+			write(s)
+		case r.URL.Path == "/labels":
+			l := models.Label{ID: f.id("l"), Name: str("name")}
+			f.labels = append(f.labels, l)
+			write(l)
+		case r.URL.Path == "/comments":
+			cm := models.Comment{ID: f.id("c"), Content: str("content"), TaskID: str("task_id"), ProjectID: str("project_id")}
+			f.comments = append(f.comments, cm)
+			for i := range f.tasks {
+				if f.tasks[i].ID == cm.TaskID {
+					f.tasks[i].CommentCount++
+				}
+			}
+			write(cm)
+		case r.URL.Path == "/tasks":
+			tk := models.Task{ID: f.id("t"), Content: str("content"), ProjectID: str("project_id"),
+				SectionID: str("section_id"), ParentID: str("parent_id"), Description: str("description"), Priority: 1}
+			f.apply(&tk, body)
+			f.tasks = append(f.tasks, tk)
+			write(tk)
+		case strings.HasSuffix(r.URL.Path, "/close"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/close")
+			for i := range f.tasks {
+				if f.tasks[i].ID == id {
+					tk := f.tasks[i]
+					tk.IsCompleted = true
+					f.completed = append(f.completed, tk)
+					f.tasks = append(f.tasks[:i], f.tasks[i+1:]...)
+					break
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case strings.HasSuffix(r.URL.Path, "/move"):
+			id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/tasks/"), "/move")
+			for i := range f.tasks {
+				if f.tasks[i].ID == id {
+					f.tasks[i].ProjectID = str("project_id")
+					write(f.tasks[i])
+				}
+			}
+		case strings.HasPrefix(r.URL.Path, "/tasks/"):
+			id := strings.TrimPrefix(r.URL.Path, "/tasks/")
+			for i := range f.tasks {
+				if f.tasks[i].ID == id {
+					f.apply(&f.tasks[i], body)
+					write(f.tasks[i])
+				}
+			}
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	}
+}
+
+// writePage serves items in pages of size n (all at once if n is 0),
+// using the item offset as the cursor.
+func writePage[T any](w http.ResponseWriter, items []T, cursor string, n int) {
+	page, next := pageOf(items, cursor, n)
+	_ = json.NewEncoder(w).Encode(map[string]interface{}{"results": page, "next_cursor": next})
+}
+
+// pageOf returns up to n items starting at the offset encoded in cursor,
+// and the cursor of the next page ("" on the last page).
+func pageOf[T any](items []T, cursor string, n int) ([]T, string) {
+	start := 0
+	_, _ = fmt.Sscan(cursor, &start)
+	if start > len(items) {
+		start = len(items)
+	}
+	end := len(items)
+	next := ""
+	if n > 0 && start+n < len(items) {
+		end = start + n
+		next = fmt.Sprint(end)
+	}
+	page := items[start:end]
+	if page == nil {
+		page = []T{}
+	}
+	return page, next
+}
+
+func (f *fakeAccount) apply(tk *models.Task, body map[string]interface{}) {
+	if v, ok := body["description"].(string); ok {
+		tk.Description = v
+	}
+	if v, ok := body["priority"].(float64); ok {
+		tk.Priority = int(v)
+	}
+	if v, ok := body["labels"].([]interface{}); ok {
+		tk.Labels = nil
+		for _, l := range v {
+			tk.Labels = append(tk.Labels, l.(string))
+		}
+	}
+	if v, ok := body["due_date"].(string); ok {
+		tk.Due = &models.DueDate{Date: v}
+	}
+	if v, ok := body["due_string"].(string); ok {
+		tk.Due = &models.DueDate{Date: "2026-11-06", String: v, Recurring: true}
+		if v == "no date" {
+			tk.Due = nil
+		}
+	}
+	if v, ok := body["duration"].(float64); ok {
+		tk.Duration = &models.Duration{Amount: int(v), Unit: body["duration_unit"].(string)}
+	}
+}
+
+func (f *fakeAccount) task(content string) *models.Task {
+	for i := range f.tasks {
+		if f.tasks[i].Content == content {
+			return &f.tasks[i]
+		}
+	}
+	return nil
+}
@@ -0,0 +1,714 @@
+package todoist
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// Filter is a parsed Todoist filter query that can be evaluated locally
+// against tasks, e.g. on cached data or on completed tasks that the
+// filter endpoint does not cover.
+//
+// Supported syntax: & (and), | (or), ! (not), parentheses, and commas
+// (separate queries, evaluated as a union). Terms: #project, ##project
+// (with subprojects), @label, /section, p1-p4, no priority, today,
+// tomorrow, yesterday, overdue, no date, recurring, no time, no labels,
+// subtask, "N days", "next N days", "-N days", due before:/due after:/due:,
+// created before:/created after:/created:, assigned, assigned to:,
+// assigned by:, search:, all, and bare dates. Names may contain * as a
+// wildcard, and special characters can be escaped with a backslash.
+type Filter struct {
+	query string
+	root  filterNode
+}
+
+// FilterEnv supplies the data a filter needs beyond the task itself.
+type FilterEnv struct {
+	// Now is the evaluation time. Zero means time.Now().
+	Now time.Time
+	// UserID is the current user, used by "assigned to: me".
+	UserID string
+	// Projects and Sections resolve #project and /section names.
+	Projects []models.Project
+	Sections []models.Section
+	// Users maps user IDs to names or emails for "assigned to: <name>".
+	Users map[string]string
+}
+
+// FilterError reports a syntax error in a filter query.
+type FilterError struct {
+	Pos int // zero-based byte offset into the query
+	Msg string
+}
+
+func (e *FilterError) Error() string {
+	return fmt.Sprintf("filter syntax error at position %d: %s", e.Pos, e.Msg)
+}
+
+// ParseFilter parses a Todoist filter query.
+func ParseFilter(query string) (*Filter, error) {
+	toks, err := lexFilter(query)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{toks: toks}
+	root, err := p.parseQuery()
+	if err != nil {
+		return nil, err
+	}
+	return &Filter{query: query, root: root}, nil
+}
+
+// String returns the original query.
+func (f *Filter) String() string { return f.query }
+
+// Match reports whether a task satisfies the filter.
+func (f *Filter) Match(t *models.Task, env FilterEnv) bool {
+	return f.root.eval(t, newFilterState(env))
+}
+
+// Apply returns the tasks that satisfy the filter, preserving order.
+func (f *Filter) Apply(tasks []models.Task, env FilterEnv) []models.Task {
+	st := newFilterState(env)
+	var out []models.Task
+	for i := range tasks {
+		if f.root.eval(&tasks[i], st) {
+			out = append(out, tasks[i])
+		}
+	}
+	return out
+}
+
+// --- evaluation state ---
+
+type filterState struct {
+	env      FilterEnv
+	now      time.Time
+	today    time.Time
+	projects map[string]models.Project
+	sections map[string]models.Section
+}
+
+func newFilterState(env FilterEnv) *filterState {
+	now := env.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	st := &filterState{
+		env:      env,
+		now:      now,
+		today:    startOfDay(now),
+		projects: map[string]models.Project{},
+		sections: map[string]models.Section{},
+	}
+	for _, p := range env.Projects {
+		st.projects[p.ID] = p
+	}
+	for _, s := range env.Sections {
+		st.sections[s.ID] = s
+	}
+	return st
+}
+
+func startOfDay(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, t.Location())
+}
+
+// taskDue returns the due moment of a task in loc, whether the due date
+// carries a time of day, and whether the task has a parseable due date.
+func taskDue(t *models.Task, loc *time.Location) (time.Time, bool, bool) {
+	if t.Due == nil {
+		return time.Time{}, false, false
+	}
+	for _, s := range []string{t.Due.Datetime, t.Due.Date} {
+		if s == "" {
+			continue
+		}
+		if ts, err := time.Parse(time.RFC3339, s); err == nil {
+			return ts.In(loc), true, true
+		}
+		if ts, err := time.ParseInLocation("2006-01-02T15:04:05", s, loc); err == nil {
+			return ts, true, true
+		}
+		if ts, err := time.ParseInLocation("2006-01-02", s, loc); err == nil {
+			return ts, false, true
+		}
+	}
+	return time.Time{}, false, false
+}
+
+func (st *filterState) dueDay(t *models.Task) (time.Time, bool) {
+	due, _, ok := taskDue(t, st.now.Location())
+	if !ok {
+		return time.Time{}, false
+	}
+	return startOfDay(due), true
+}
+
+// --- AST ---
+
+type filterNode interface {
+	eval(t *models.Task, st *filterState) bool
+}
+
+type andNode struct{ left, right filterNode }
+
+func (n andNode) eval(t *models.Task, st *filterState) bool {
+	return n.left.eval(t, st) && n.right.eval(t, st)
+}
+
+type orNode struct{ left, right filterNode }
+
+func (n orNode) eval(t *models.Task, st *filterState) bool {
+	return n.left.eval(t, st) || n.right.eval(t, st)
+}
+
+type notNode struct{ inner filterNode }
+
+func (n notNode) eval(t *models.Task, st *filterState) bool {
+	return !n.inner.eval(t, st)
+}
+
+// predNode wraps a simple per-task predicate.
+type predNode func(t *models.Task, st *filterState) bool
+
+func (n predNode) eval(t *models.Task, st *filterState) bool { return n(t, st) }
+
+type projectNode struct {
+	pattern     string
+	subprojects bool
+}
+
+func (n projectNode) eval(t *models.Task, st *filterState) bool {
+	seen := map[string]bool{}
+	for id := t.ProjectID; id != "" && !seen[id]; {
+		seen[id] = true
+		p, ok := st.projects[id]
+		if !ok {
+			return false
+		}
+		if wildcardMatch(n.pattern, p.Name) {
+			return true
+		}
+		if !n.subprojects {
+			return false
+		}
+		id = p.ParentID
+	}
+	return false
+}
+
+// --- lexer ---
+
+type filterTokKind int
+
+const (
+	tokTerm filterTokKind = iota
+	tokAnd
+	tokOr
+	tokNot
+	tokLParen
+	tokRParen
+	tokComma
+	tokEOF
+)
+
+type filterTok struct {
+	kind filterTokKind
+	text string
+	pos  int
+}
+
+const filterOperators = "&|(),"
+
+func lexFilter(query string) ([]filterTok, error) {
+	var toks []filterTok
+	i := 0
+	for i < len(query) {
+		ch := query[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '&':
+			toks = append(toks, filterTok{kind: tokAnd, pos: i})
+			i++
+		case ch == '|':
+			toks = append(toks, filterTok{kind: tokOr, pos: i})
+			i++
+		case ch == '!':
+			toks = append(toks, filterTok{kind: tokNot, pos: i})
+			i++
+		case ch == '(':
+			toks = append(toks, filterTok{kind: tokLParen, pos: i})
+			i++
+		case ch == ')':
+			toks = append(toks, filterTok{kind: tokRParen, pos: i})
+			i++
+		case ch == ',':
+			toks = append(toks, filterTok{kind: tokComma, pos: i})
+			i++
+		default:
+			start := i
+			var sb strings.Builder
+			for i < len(query) && !strings.ContainsRune(filterOperators, rune(query[i])) {
+				if query[i] == '\\' {
+					if i+1 >= len(query) {
+						return nil, &FilterError{Pos: i, Msg: "dangling escape character"}
+					}
+					i++
+				}
+				sb.WriteByte(query[i])
+				i++
+			}
+			toks = append(toks, filterTok{kind: tokTerm, text: strings.TrimSpace(sb.String()), pos: start})
+		}
+	}
+	toks = append(toks, filterTok{kind: tokEOF, pos: len(query)})
+	return toks, nil
+}
+
+// --- parser ---
+
+type filterParser struct {
+	toks []filterTok
+	pos  int
+}
+
+func (p *filterParser) peek() filterTok { return p.toks[p.pos] }
+
+func (p *filterParser) next() filterTok {
+	tok := p.toks[p.pos]
+	if tok.kind != tokEOF {
+		p.pos++
+	}
+	return tok
+}
+
+// parseQuery handles the top level, where commas separate queries.
+func (p *filterParser) parseQuery() (filterNode, error) {
+	if p.peek().kind == tokEOF {
+		return nil, &FilterError{Pos: 0, Msg: "empty filter"}
+	}
+	node, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokComma {
+		p.next()
+		right, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		node = orNode{node, right}
+	}
+	if tok := p.peek(); tok.kind != tokEOF {
+		return nil, &FilterError{Pos: tok.pos, Msg: "unexpected " + describeTok(tok)}
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	node, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokOr {
+		p.next()
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		node = orNode{node, right}
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	node, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.peek().kind == tokAnd {
+		p.next()
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		node = andNode{node, right}
+	}
+	return node, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	tok := p.next()
+	switch tok.kind {
+	case tokNot:
+		inner, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return notNode{inner}, nil
+	case tokLParen:
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.kind != tokRParen {
+			return nil, &FilterError{Pos: closing.pos, Msg: fmt.Sprintf("expected ')' to close '(' at position %d, got %s", tok.pos, describeTok(closing))}
+		}
+		return node, nil
+	case tokTerm:
+		return parseFilterTerm(tok.text, tok.pos)
+	default:
+		return nil, &FilterError{Pos: tok.pos, Msg: "expected a filter term, got " + describeTok(tok)}
+	}
+}
+
+func describeTok(tok filterTok) string {
+	switch tok.kind {
+	case tokTerm:
+		return fmt.Sprintf("term %q", tok.text)
+	case tokAnd:
+		return "'&'"
+	case tokOr:
+		return "'|'"
+	case tokNot:
+		return "'!'"
+	case tokLParen:
+		return "'('"
+	case tokRParen:
+		return "')'"
+	case tokComma:
+		return "','"
+	default:
+		return "end of filter"
+	}
+}
+
+// --- terms ---
+
+func parseFilterTerm(text string, pos int) (filterNode, error) {
+	lower := strings.ToLower(text)
+	termErr := func(msg string) error { return &FilterError{Pos: pos, Msg: msg} }
+
+	switch {
+	case strings.HasPrefix(lower, "##"):
+		name := strings.TrimSpace(text[2:])
+		if name == "" {
+			return nil, termErr("missing project name after '##'")
+		}
+		return projectNode{pattern: name, subprojects: true}, nil
+	case strings.HasPrefix(lower, "#"):
+		name := strings.TrimSpace(text[1:])
+		if name == "" {
+			return nil, termErr("missing project name after '#'")
+		}
+		return projectNode{pattern: name}, nil
+	case strings.HasPrefix(lower, "@"):
+		name := strings.TrimSpace(text[1:])
+		if name == "" {
+			return nil, termErr("missing label name after '@'")
+		}
+		return predNode(func(t *models.Task, _ *filterState) bool {
+			for _, l := range t.Labels {
+				if wildcardMatch(name, l) {
+					return true
+				}
+			}
+			return false
+		}), nil
+	case strings.HasPrefix(lower, "/"):
+		name := strings.TrimSpace(text[1:])
+		if name == "" {
+			return nil, termErr("missing section name after '/'")
+		}
+		return predNode(func(t *models.Task, st *filterState) bool {
+			if t.SectionID == "" {
+				return false
+			}
+			if name == "*" {
+				return true
+			}
+			sec, ok := st.sections[t.SectionID]
+			return ok && wildcardMatch(name, sec.Name)
+		}), nil
+	}
+
+	if key, arg, ok := strings.Cut(lower, ":"); ok {
+		key = strings.Join(strings.Fields(key), " ")
+		rawArg := strings.TrimSpace(text[strings.Index(text, ":")+1:])
+		arg = strings.TrimSpace(arg)
+		return parseKeyedTerm(key, arg, rawArg, termErr)
+	}
+
+	switch lower {
+	case "p1", "p2", "p3", "p4":
+		// The API stores priority inverted: p1 (urgent) is priority 4.
+		want := 5 - int(lower[1]-'0')
+		return predNode(func(t *models.Task, _ *filterState) bool { return taskPriority(t) == want }), nil
+	case "no priority":
+		return predNode(func(t *models.Task, _ *filterState) bool { return taskPriority(t) == 1 }), nil
+	case "all", "view all":
+		return predNode(func(*models.Task, *filterState) bool { return true }), nil
+	case "overdue", "od":
+		return predNode(func(t *models.Task, st *filterState) bool {
+			due, hasTime, ok := taskDue(t, st.now.Location())
+			if !ok {
+				return false
+			}
+			if hasTime {
+				return due.Before(st.now)
+			}
+			return due.Before(st.today)
+		}), nil
+	case "no date", "no due date":
+		return predNode(func(t *models.Task, st *filterState) bool {
+			_, ok := st.dueDay(t)
+			return !ok
+		}), nil
+	case "recurring":
+		return predNode(func(t *models.Task, _ *filterState) bool { return t.Due != nil && t.Due.Recurring }), nil
+	case "no time":
+		return predNode(func(t *models.Task, st *filterState) bool {
+			_, hasTime, ok := taskDue(t, st.now.Location())
+			return ok && !hasTime
+		}), nil
+	case "no labels":
+		return predNode(func(t *models.Task, _ *filterState) bool { return len(t.Labels) == 0 }), nil
+	case "subtask":
+		return predNode(func(t *models.Task, _ *filterState) bool { return t.ParentID != "" }), nil
+	case "assigned":
+		return predNode(func(t *models.Task, _ *filterState) bool { return t.AssigneeID != "" }), nil
+	}
+
+	if n, ok := parseDayRange(lower); ok {
+		return predNode(func(t *models.Task, st *filterState) bool {
+			day, ok := st.dueDay(t)
+			if !ok {
+				return false
+			}
+			if n >= 0 {
+				return !day.Before(st.today) && day.Before(st.today.AddDate(0, 0, n))
+			}
+			return day.Before(st.today) && !day.Before(st.today.AddDate(0, 0, n))
+		}), nil
+	}
+
+	if isFilterDate(lower) {
+		return dueComparison(lower, 0), nil
+	}
+
+	return nil, termErr(fmt.Sprintf("unknown filter term %q", text))
+}
+
+func parseKeyedTerm(key, arg, rawArg string, termErr func(string) error) (filterNode, error) {
+	switch key {
+	case "search", "due", "date", "due before", "date before", "due after", "date after",
+		"created", "created before", "created after", "assigned to", "assigned by":
+	default:
+		return nil, termErr(fmt.Sprintf("unknown filter keyword %q", key+":"))
+	}
+	if arg == "" {
+		return nil, termErr(fmt.Sprintf("missing value after %q", key+":"))
+	}
+
+	switch key {
+	case "search":
+		return predNode(func(t *models.Task, _ *filterState) bool {
+			return wildcardContains(arg, t.Content)
+		}), nil
+	case "due", "date":
+		if !isFilterDate(arg) {
+			return nil, termErr(fmt.Sprintf("invalid date %q", rawArg))
+		}
+		return dueComparison(arg, 0), nil
+	case "due before", "date before":
+		if !isFilterDate(arg) {
+			return nil, termErr(fmt.Sprintf("invalid date %q", rawArg))
+		}
+		return dueComparison(arg, -1), nil
+	case "due after", "date after":
+		if !isFilterDate(arg) {
+			return nil, termErr(fmt.Sprintf("invalid date %q", rawArg))
+		}
+		return dueComparison(arg, 1), nil
+	case "created", "created before", "created after":
+		if !isFilterDate(arg) {
+			return nil, termErr(fmt.Sprintf("invalid date %q", rawArg))
+		}
+		cmp := 0
+		if key == "created before" {
+			cmp = -1
+		} else if key == "created after" {
+			cmp = 1
+		}
+		return predNode(func(t *models.Task, st *filterState) bool {
+			if t.CreatedAt.IsZero() {
+				return false
+			}
+			ref, _ := parseFilterDate(arg, st.now)
+			return compareDay(startOfDay(t.CreatedAt.In(st.now.Location())), ref, cmp)
+		}), nil
+	case "assigned to":
+		return predNode(func(t *models.Task, st *filterState) bool {
+			return matchUser(arg, t.AssigneeID, st)
+		}), nil
+	default: // "assigned by"
+		return predNode(func(t *models.Task, st *filterState) bool {
+			return matchUser(arg, t.AssignedByUID, st)
+		}), nil
+	}
+}
+
+// dueComparison matches tasks whose due day is before (cmp < 0), on
+// (cmp == 0) or after (cmp > 0) the given date expression.
+func dueComparison(expr string, cmp int) filterNode {
+	return predNode(func(t *models.Task, st *filterState) bool {
+		day, ok := st.dueDay(t)
+		if !ok {
+			return false
+		}
+		ref, _ := parseFilterDate(expr, st.now)
+		return compareDay(day, ref, cmp)
+	})
+}
+
+func compareDay(day, ref time.Time, cmp int) bool {
+	switch {
+	case cmp < 0:
+		return day.Before(ref)
+	case cmp > 0:
+		return day.After(ref)
+	default:
+		return day.Equal(ref)
+	}
+}
+
+func matchUser(arg, uid string, st *filterState) bool {
+	if uid == "" {
+		return false
+	}
+	switch arg {
+	case "me":
+		return uid == st.env.UserID
+	case "others":
+		return uid != st.env.UserID
+	}
+	if uid == arg {
+		return true
+	}
+	name, ok := st.env.Users[uid]
+	return ok && wildcardContains(arg, name)
+}
+
+// taskPriority treats the zero value as normal priority.
+func taskPriority(t *models.Task) int {
+	if t.Priority == 0 {
+		return 1
+	}
+	return t.Priority
+}
+
+// parseDayRange recognizes "N days", "next N days" and "-N days".
+func parseDayRange(s string) (int, bool) {
+	fields := strings.Fields(s)
+	if len(fields) == 3 && fields[0] == "next" {
+		fields = fields[1:]
+	}
+	if len(fields) != 2 || (fields[1] != "days" && fields[1] != "day") {
+		return 0, false
+	}
+	n, err := strconv.Atoi(fields[0])
+	if err != nil || n == 0 {
+		return 0, false
+	}
+	return n, true
+}
+
+func isFilterDate(s string) bool {
+	_, ok := parseFilterDate(s, time.Now())
+	return ok
+}
+
+var filterDateLayouts = []string{
+	"2006-01-02",
+	"Jan 2",
+	"January 2",
+	"2 Jan",
+	"2 January",
+	"Jan 2 2006",
+	"January 2 2006",
+	"2 Jan 2006",
+	"2 January 2006",
+}
+
+// parseFilterDate resolves a date expression relative to now. Dates
+// without a year refer to the next occurrence on or after today.
+func parseFilterDate(s string, now time.Time) (time.Time, bool) {
+	today := startOfDay(now)
+	s = strings.Join(strings.Fields(strings.ToLower(s)), " ")
+	switch s {
+	case "today":
+		return today, true
+	case "tomorrow":
+		return today.AddDate(0, 0, 1), true
+	case "yesterday":
+		return today.AddDate(0, 0, -1), true
+	}
+	for wd := time.Sunday; wd <= time.Saturday; wd++ {
+		name := strings.ToLower(wd.String())
+		if s == name || s == name[:3] || s == "next "+name {
+			diff := (int(wd) - int(today.Weekday()) + 7) % 7
+			if diff == 0 && strings.HasPrefix(s, "next ") {
+				diff = 7
+			}
+			return today.AddDate(0, 0, diff), true
+		}
+	}
+	for _, layout := range filterDateLayouts {
+		ts, err := time.ParseInLocation(layout, s, now.Location())
+		if err != nil {
+			continue
+		}
+		if ts.Year() == 0 {
+			ts = ts.AddDate(today.Year(), 0, 0)
+			if ts.Before(today) {
+				ts = ts.AddDate(1, 0, 0)
+			}
+		}
+		return ts, true
+	}
+	return time.Time{}, false
+}
+
+// wildcardMatch reports whether s matches pattern case-insensitively,
+// where * in pattern matches any run of characters.
+func wildcardMatch(pattern, s string) bool {
+	pattern, s = strings.ToLower(pattern), strings.ToLower(s)
+	parts := strings.Split(pattern, "*")
+	if len(parts) == 1 {
+		return pattern == s
+	}
+	if !strings.HasPrefix(s, parts[0]) {
+		return false
+	}
+	s = s[len(parts[0]):]
+	last := parts[len(parts)-1]
+	for _, part := range parts[1 : len(parts)-1] {
+		idx := strings.Index(s, part)
+		if idx < 0 {
+			return false
+		}
+		s = s[idx+len(part):]
+	}
+	return strings.HasSuffix(s, last)
+}
+
+// wildcardContains reports whether s contains pattern case-insensitively.
+func wildcardContains(pattern, s string) bool {
+	return wildcardMatch("*"+pattern+"*", s)
+}
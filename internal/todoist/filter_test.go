@@ -0,0 +1,262 @@
+package todoist
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func filterFixture() ([]models.Task, FilterEnv) {
+	env := FilterEnv{
+		Now:    time.Date(2026, 10, 18, 10, 0, 0, 0, time.UTC), // a Sunday
+		UserID: "u1",
+		Projects: []models.Project{
+			{ID: "pi", Name: "Inbox", IsInboxProject: true},
+			{ID: "pw", Name: "Work"},
+			{ID: "pca", Name: "Client A", ParentID: "pw"},
+			{ID: "ph", Name: "Home"},
+		},
+		Sections: []models.Section{
+			{ID: "s1", ProjectID: "pw", Name: "Meetings"},
+			{ID: "s2", ProjectID: "pw", Name: "Backlog"},
+		},
+		Users: map[string]string{
+			"u1": "Alice Smith",
+			"u2": "Bob Jones <bob@example.com>",
+		},
+	}
+	tasks := []models.Task{
+		{ID: "1", Content: "Write report", ProjectID: "pw", SectionID: "s1", Priority: 4,
+			Labels: []string{"work", "urgent"}, Due: &models.DueDate{Date: "2026-10-18"}, AssigneeID: "u1",
+			CreatedAt: time.Date(2026, 10, 10, 9, 0, 0, 0, time.UTC)},
+		{ID: "2", Content: "Call client", ProjectID: "pca", Priority: 3,
+			Labels: []string{"phone"}, Due: &models.DueDate{Date: "2026-10-17"}},
+		{ID: "3", Content: "Buy milk", ProjectID: "ph", Priority: 1},
+		{ID: "4", Content: "Plan trip", ProjectID: "ph", Priority: 2,
+			Labels: []string{"home-errands"}, Due: &models.DueDate{Date: "2026-10-20T15:00:00"}},
+		{ID: "5", Content: "Standup", ProjectID: "pw", SectionID: "s2", Priority: 1, ParentID: "1",
+			Due: &models.DueDate{Date: "2026-10-19", Recurring: true}, AssigneeID: "u2", AssignedByUID: "u1"},
+		{ID: "6", Content: "Old idea", ProjectID: "pi", Due: &models.DueDate{Date: "2026-09-01"},
+			CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		{ID: "7", Content: "Meeting prep", ProjectID: "pw",
+			Due: &models.DueDate{Date: "2026-10-18T08:00:00Z", Datetime: "2026-10-18T08:00:00Z"}},
+	}
+	return tasks, env
+}
+
+func TestFilter_match(t *testing.T) {
+	tasks, env := filterFixture()
+	all := []string{"1", "2", "3", "4", "5", "6", "7"}
+
+	tests := []struct {
+		query string
+		want  []string
+	}{
+		// Dates.
+		{"today", []string{"1", "7"}},
+		{"tomorrow", []string{"5"}},
+		{"yesterday", []string{"2"}},
+		{"overdue", []string{"2", "6", "7"}},
+		{"od", []string{"2", "6", "7"}},
+		{"no date", []string{"3"}},
+		{"no due date", []string{"3"}},
+		{"!no date", []string{"1", "2", "4", "5", "6", "7"}},
+		{"recurring", []string{"5"}},
+		{"no time", []string{"1", "2", "5", "6"}},
+		{"3 days", []string{"1", "4", "5", "7"}},
+		{"next 2 days", []string{"1", "5", "7"}},
+		{"-7 days", []string{"2"}},
+		{"due before: today", []string{"2", "6"}},
+		{"due after: tomorrow", []string{"4"}},
+		{"due: Oct 19", []string{"5"}},
+		{"date: 19 Oct", []string{"5"}},
+		{"Oct 20", []string{"4"}},
+		{"2026-09-01", []string{"6"}},
+		{"due before: 2026-10-01", []string{"6"}},
+		{"sunday", []string{"1", "7"}},
+		{"monday", []string{"5"}},
+		{"next sunday", nil},
+		{"created before: 2026-06-01", []string{"6"}},
+		{"created after: 2026-06-01", []string{"1"}},
+		{"created: 2026-10-10", []string{"1"}},
+
+		// Priorities.
+		{"p1", []string{"1"}},
+		{"p2", []string{"2"}},
+		{"p3", []string{"4"}},
+		{"p4", []string{"3", "5", "6", "7"}},
+		{"no priority", []string{"3", "5", "6", "7"}},
+		{"P1", []string{"1"}},
+
+		// Projects, labels and sections.
+		{"#Work", []string{"1", "5", "7"}},
+		{"#work", []string{"1", "5", "7"}},
+		{"##Work", []string{"1", "2", "5", "7"}},
+		{"#Client A", []string{"2"}},
+		{"#Client*", []string{"2"}},
+		{"##Client A", []string{"2"}},
+		{"#Nope", nil},
+		{"#Inbox", []string{"6"}},
+		{"@work", []string{"1"}},
+		{"@URGENT", []string{"1"}},
+		{"@home*", []string{"4"}},
+		{"@*e*", []string{"1", "2", "4"}},
+		{"no labels", []string{"3", "5", "6", "7"}},
+		{"/Meetings", []string{"1"}},
+		{"/back*", []string{"5"}},
+		{"/*", []string{"1", "5"}},
+		{"!/*", []string{"2", "3", "4", "6", "7"}},
+
+		// People, hierarchy and text.
+		{"subtask", []string{"5"}},
+		{"!subtask", []string{"1", "2", "3", "4", "6", "7"}},
+		{"assigned", []string{"1", "5"}},
+		{"assigned to: me", []string{"1"}},
+		{"assigned to: others", []string{"5"}},
+		{"assigned to: bob", []string{"5"}},
+		{"assigned to: bob@example.com", []string{"5"}},
+		{"assigned to: u2", []string{"5"}},
+		{"assigned by: me", []string{"5"}},
+		{"search: report", []string{"1"}},
+		{"search: M*k", []string{"3"}},
+		{"search: nothing matches", nil},
+		{"all", all},
+		{"view all", all},
+
+		// Operators.
+		{"today & p1", []string{"1"}},
+		{"today | overdue", []string{"1", "2", "6", "7"}},
+		{"#Work & !subtask", []string{"1", "7"}},
+		{"(p1 | p2) & ##Work", []string{"1", "2"}},
+		{"!(today | overdue) & !no date", []string{"4", "5"}},
+		{"p1, p2", []string{"1", "2"}},
+		{"p1 & (today | tomorrow) | #Home", []string{"1", "3", "4"}},
+		{"p1 & (today | tomorrow | #Home)", []string{"1"}},
+		{"!!p1", []string{"1"}},
+		{"((p1))", []string{"1"}},
+		{"  #Home  &  no date  ", []string{"3"}},
+		{"@work | @phone & overdue", []string{"1", "2"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			f, err := ParseFilter(tt.query)
+			if err != nil {
+				t.Fatalf("ParseFilter(%q) error: %v", tt.query, err)
+			}
+			var got []string
+			for _, task := range f.Apply(tasks, env) {
+				got = append(got, task.ID)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Apply(%q) = %v, want %v", tt.query, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFilter_syntaxErrors(t *testing.T) {
+	tests := []struct {
+		query string
+		pos   int
+	}{
+		{"", 0},
+		{"   ", 0},
+		{"p1 &", 4},
+		{"& p1", 0},
+		{"p1 | | p2", 5},
+		{"(p1 | p2", 8},
+		{"p1)", 2},
+		{"()", 1},
+		{"p1 ,, p2", 4},
+		{"!", 1},
+		{"p5", 0},
+		{"today & bogus", 8},
+		{"p1 p2", 0},
+		{"#", 0},
+		{"p1 & ##", 5},
+		{"@", 0},
+		{"/", 0},
+		{"due before: someday", 0},
+		{"today | due: 2026-13-45", 8},
+		{"p1 & frob: x", 5},
+		{"search:", 0},
+		{"assigned to:", 0},
+		{`p1 \`, 3},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.query, func(t *testing.T) {
+			_, err := ParseFilter(tt.query)
+			if err == nil {
+				t.Fatalf("ParseFilter(%q) succeeded, want error", tt.query)
+			}
+			var fe *FilterError
+			if !errors.As(err, &fe) {
+				t.Fatalf("error type = %T, want *FilterError", err)
+			}
+			if fe.Pos != tt.pos {
+				t.Errorf("ParseFilter(%q) pos = %d, want %d (%v)", tt.query, fe.Pos, tt.pos, err)
+			}
+		})
+	}
+}
+
+func TestFilter_escapes(t *testing.T) {
+	f, err := ParseFilter(`search: R\&D \(draft\)`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	task := &models.Task{Content: "Review R&D (draft) budget"}
+	if !f.Match(task, FilterEnv{}) {
+		t.Error("expected escaped operators to match literally")
+	}
+}
+
+func TestFilter_completedTasks(t *testing.T) {
+	// Local evaluation works on any task, including completed ones that
+	// the API filter endpoint never returns.
+	f, err := ParseFilter("#Work & @done*")
+	if err != nil {
+		t.Fatal(err)
+	}
+	env := FilterEnv{Projects: []models.Project{{ID: "pw", Name: "Work"}}}
+	task := &models.Task{ProjectID: "pw", Labels: []string{"done-this-week"}, IsCompleted: true}
+	if !f.Match(task, env) {
+		t.Error("expected completed task to match")
+	}
+}
+
+func TestFilter_String(t *testing.T) {
+	f, err := ParseFilter("today | overdue")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if f.String() != "today | overdue" {
+		t.Errorf("String() = %q", f.String())
+	}
+}
+
+func TestWildcardMatch(t *testing.T) {
+	tests := []struct {
+		pattern, s string
+		want       bool
+	}{
+		{"work", "Work", true},
+		{"work", "Workshop", false},
+		{"work*", "Workshop", true},
+		{"*shop", "Workshop", true},
+		{"w*k*p", "Workshop", true},
+		{"a*a", "a", false},
+		{"*", "", true},
+		{"*x*", "abc", false},
+	}
+	for _, tt := range tests {
+		if got := wildcardMatch(tt.pattern, tt.s); got != tt.want {
+			t.Errorf("wildcardMatch(%q, %q) = %v, want %v", tt.pattern, tt.s, got, tt.want)
+		}
+	}
+}
@@ -0,0 +1,280 @@
+package todoist
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// GTDConfig names the labels and project that mark GTD lists.
+type GTDConfig struct {
+	// Contexts are the context labels, e.g. home, office, phone. Empty
+	// means every label other than the list labels below.
+	Contexts       []string
+	NextLabel      string
+	WaitingLabel   string
+	SomedayLabel   string
+	SomedayProject string // project name; its sub-projects count too
+}
+
+// IsWaiting reports whether a task is on the waiting-for list.
+func (cfg GTDConfig) IsWaiting(t models.Task) bool {
+	return cfg.WaitingLabel != "" && HasLabel(t.Labels, cfg.WaitingLabel)
+}
+
+// IsSomeday reports whether a task is on the someday/maybe list, by label
+// or by being in the Someday project. projects maps IDs to projects.
+func (cfg GTDConfig) IsSomeday(t models.Task, projects map[string]models.Project) bool {
+	if cfg.SomedayLabel != "" && HasLabel(t.Labels, cfg.SomedayLabel) {
+		return true
+	}
+	return cfg.InSomedayProject(t.ProjectID, projects)
+}
+
+// InSomedayProject reports whether a project is the Someday project or
+// one of its sub-projects. The Someday project is matched by name as
+// FindProjectByName does.
+func (cfg GTDConfig) InSomedayProject(projectID string, projects map[string]models.Project) bool {
+	if cfg.SomedayProject == "" {
+		return false
+	}
+	var active []models.Project
+	for _, p := range projects {
+		if !p.IsArchived && !p.IsDeleted {
+			active = append(active, p)
+		}
+	}
+	sort.Slice(active, func(i, j int) bool { return active[i].ID < active[j].ID })
+	somedayID := matchProjectName(active, cfg.SomedayProject)
+	if somedayID == "" {
+		return false
+	}
+	seen := map[string]bool{}
+	for id := projectID; id != "" && !seen[id]; id = projects[id].ParentID {
+		seen[id] = true
+		if id == somedayID {
+			return true
+		}
+	}
+	return false
+}
+
+// TaskContexts returns the task's context labels in the task's order,
+// spelled as configured.
+func (cfg GTDConfig) TaskContexts(t models.Task) []string {
+	var out []string
+	for _, l := range t.Labels {
+		switch {
+		case len(cfg.Contexts) > 0:
+			for _, ctx := range cfg.Contexts {
+				if strings.EqualFold(ctx, l) {
+					out = append(out, ctx)
+					break
+				}
+			}
+		case !HasLabel([]string{cfg.NextLabel, cfg.WaitingLabel, cfg.SomedayLabel}, l):
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// NextActions is the next-actions list grouped by context.
+type NextActions struct {
+	// Contexts lists the groups in display order: configured contexts,
+	// then others alphabetically, then "" for tasks without a context.
+	Contexts  []string
+	ByContext map[string][]models.Task
+	Total     int
+	// Stalled lists active projects without a next action.
+	Stalled []StalledProject
+}
+
+// StalledProject is a project without a next action.
+type StalledProject struct {
+	Project models.Project
+	Open    int // open tasks
+	Waiting int // of which waiting for someone else
+}
+
+// FindNextActions selects the actionable tasks: open, not waiting, not
+// someday, and not scheduled after today. A task with open subtasks is not
+// itself actionable; only its first actionable open subtask is, as
+// subtasks are taken to be steps in order. A task with several contexts is listed
+// under each. Projects are reported as stalled when they have open tasks
+// or no sub-projects yet nothing actionable, leaving out the inbox and the
+// Someday project.
+func FindNextActions(projects []models.Project, tasks []models.Task, cfg GTDConfig, now time.Time) *NextActions {
+	if now.IsZero() {
+		now = time.Now()
+	}
+	tomorrow := startOfDay(now).AddDate(0, 0, 1)
+	byID := map[string]models.Project{}
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+
+	actionable := func(t models.Task) bool {
+		if cfg.IsWaiting(t) || cfg.IsSomeday(t, byID) {
+			return false
+		}
+		due, _, ok := taskDue(&t, now.Location())
+		return !ok || due.Before(tomorrow)
+	}
+
+	var open []models.Task
+	for _, t := range tasks {
+		if !t.IsCompleted {
+			open = append(open, t)
+		}
+	}
+	na := &NextActions{ByContext: map[string][]models.Task{}}
+	hasNext := map[string]bool{}
+	canon := map[string]string{}
+	// visit lists the next action under n and reports whether it found one.
+	var visit func(n *TaskNode) bool
+	visit = func(n *TaskNode) bool {
+		if len(n.Children) > 0 {
+			for _, child := range n.Children {
+				if visit(child) {
+					return true
+				}
+			}
+			return false
+		}
+		if !actionable(n.Task) {
+			return false
+		}
+		na.Total++
+		hasNext[n.Task.ProjectID] = true
+		contexts := cfg.TaskContexts(n.Task)
+		if len(contexts) == 0 {
+			contexts = []string{""}
+		}
+		for _, ctx := range contexts {
+			// Labels differing only in case share a group.
+			key, ok := canon[strings.ToLower(ctx)]
+			if !ok {
+				key = ctx
+				canon[strings.ToLower(ctx)] = ctx
+			}
+			na.ByContext[key] = append(na.ByContext[key], n.Task)
+		}
+		return true
+	}
+	for _, root := range BuildTaskTree(open).Roots {
+		visit(root)
+	}
+
+	seen := map[string]bool{}
+	for _, ctx := range cfg.Contexts {
+		if _, ok := na.ByContext[ctx]; ok && !seen[ctx] {
+			na.Contexts = append(na.Contexts, ctx)
+			seen[ctx] = true
+		}
+	}
+	var rest []string
+	for key := range na.ByContext {
+		if !seen[key] && key != "" {
+			rest = append(rest, key)
+		}
+	}
+	sort.Slice(rest, func(i, j int) bool { return strings.ToLower(rest[i]) < strings.ToLower(rest[j]) })
+	na.Contexts = append(na.Contexts, rest...)
+	if _, ok := na.ByContext[""]; ok {
+		na.Contexts = append(na.Contexts, "")
+	}
+
+	counts := map[string]*StalledProject{}
+	hasChildren := map[string]bool{}
+	for _, p := range projects {
+		hasChildren[p.ParentID] = true
+	}
+	for _, t := range open {
+		sp, ok := counts[t.ProjectID]
+		if !ok {
+			sp = &StalledProject{}
+			counts[t.ProjectID] = sp
+		}
+		sp.Open++
+		if cfg.IsWaiting(t) {
+			sp.Waiting++
+		}
+	}
+	for _, p := range projects {
+		if p.IsArchived || p.IsDeleted || p.IsInboxProject || hasNext[p.ID] || cfg.InSomedayProject(p.ID, byID) {
+			continue
+		}
+		sp := counts[p.ID]
+		if sp == nil {
+			if hasChildren[p.ID] {
+				continue // a folder for sub-projects
+			}
+			sp = &StalledProject{}
+		}
+		sp.Project = p
+		na.Stalled = append(na.Stalled, *sp)
+	}
+	return na
+}
+
+// WaitingItem is a task on the waiting-for list.
+type WaitingItem struct {
+	Task     models.Task
+	Assigned bool // assigned to someone else rather than labelled
+	// Since is the last activity on the task: its latest comment, else
+	// its last update, else its creation, as named by SinceSource.
+	Since       time.Time
+	SinceSource string // "comment", "updated" or "created"
+}
+
+// IsWaitingFor reports whether a task is on the waiting-for list: labelled
+// WaitingLabel, or assigned to someone other than me. The second result
+// is true when only the assignment puts it there.
+func (cfg GTDConfig) IsWaitingFor(t models.Task, me string) (waiting, assigned bool) {
+	if cfg.IsWaiting(t) {
+		return true, false
+	}
+	if me != "" && t.AssigneeID != "" && t.AssigneeID != me {
+		return true, true
+	}
+	return false, false
+}
+
+// GetWaitingFor returns the open waiting-for tasks, longest waiting first.
+// Comments are read for tasks that have any, so a follow-up comment
+// resets the wait.
+func (c *Client) GetWaitingFor(projectID string, cfg GTDConfig, me string) ([]WaitingItem, error) {
+	tasks, err := c.GetTasks(projectID, "")
+	if err != nil {
+		return nil, err
+	}
+	var items []WaitingItem
+	for _, t := range tasks {
+		waiting, assigned := cfg.IsWaitingFor(t, me)
+		if !waiting || t.IsCompleted {
+			continue
+		}
+		it := WaitingItem{Task: t, Assigned: assigned, Since: t.CreatedAt, SinceSource: "created"}
+		if at, err := time.Parse(time.RFC3339, t.UpdatedAt); err == nil && at.After(it.Since) {
+			it.Since, it.SinceSource = at, "updated"
+		}
+		if t.CommentCount > 0 {
+			comments, err := c.GetComments(t.ID, "")
+			if err != nil {
+				return nil, fmt.Errorf("failed to read comments of %s: %w", t.Content, err)
+			}
+			for _, cm := range comments {
+				if cm.PostedAt.After(it.Since) {
+					it.Since, it.SinceSource = cm.PostedAt, "comment"
+				}
+			}
+		}
+		items = append(items, it)
+	}
+	sort.SliceStable(items, func(i, j int) bool { return items[i].Since.Before(items[j].Since) })
+	return items, nil
+}
@@ -0,0 +1,111 @@
+package todoist
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestFindNextActions(t *testing.T) {
+	now := time.Date(2026, 10, 19, 9, 0, 0, 0, time.UTC)
+	projects := []models.Project{
+		{ID: "inbox", Name: "Inbox", IsInboxProject: true},
+		{ID: "home", Name: "Home"},
+		{ID: "move", Name: "Move house"},
+		{ID: "blocked", Name: "Visa"},
+		{ID: "empty", Name: "Garden"},
+		{ID: "areas", Name: "Areas"},
+		{ID: "health", Name: "Health", ParentID: "areas"},
+		{ID: "someday", Name: "some-day"},
+		{ID: "ideas", Name: "Ideas", ParentID: "someday"},
+		{ID: "old", Name: "Old", IsArchived: true},
+	}
+	tasks := []models.Task{
+		{ID: "1", Content: "Fix tap", ProjectID: "home", Labels: []string{"home", "errands"}},
+		{ID: "2", Content: "Call landlord", ProjectID: "home", Labels: []string{"Phone", "next"}},
+		{ID: "3", Content: "Pack boxes", ProjectID: "move"},
+		{ID: "4", Content: "Buy tape", ProjectID: "move", ParentID: "3", Order: 2, Labels: []string{"errands"}},
+		{ID: "5", Content: "Sort books", ProjectID: "move", ParentID: "3", Order: 1, Labels: []string{"waiting"}},
+		{ID: "6", Content: "Book van", ProjectID: "move", Due: &models.DueDate{Date: "2026-11-01"}},
+		{ID: "7", Content: "Passport back", ProjectID: "blocked", Labels: []string{"waiting"}},
+		{ID: "8", Content: "Learn piano", ProjectID: "ideas"},
+		{ID: "9", Content: "Sail", ProjectID: "home", Labels: []string{"someday"}},
+		{ID: "10", Content: "Checkup", ProjectID: "health", Labels: []string{"phone"}},
+		{ID: "11", Content: "Process receipt", ProjectID: "inbox"},
+	}
+	cfg := GTDConfig{Contexts: []string{"phone", "home"}, NextLabel: "next", WaitingLabel: "waiting", SomedayLabel: "someday", SomedayProject: "Someday"}
+	na := FindNextActions(projects, tasks, cfg, now)
+
+	if want := "phone,home,"; strings.Join(na.Contexts, ",") != want {
+		t.Errorf("contexts = %q, want %q", na.Contexts, want)
+	}
+	ids := func(ts []models.Task) string {
+		var out []string
+		for _, t := range ts {
+			out = append(out, t.ID)
+		}
+		return strings.Join(out, ",")
+	}
+	for ctx, want := range map[string]string{"phone": "2,10", "home": "1", "": "4,11"} {
+		if got := ids(na.ByContext[ctx]); got != want {
+			t.Errorf("context %q = %s, want %s", ctx, got, want)
+		}
+	}
+	if na.Total != 5 {
+		t.Errorf("total = %d, want 5", na.Total)
+	}
+
+	var stalled []string
+	for _, sp := range na.Stalled {
+		stalled = append(stalled, sp.Project.ID)
+	}
+	if want := "blocked,empty"; strings.Join(stalled, ",") != want {
+		t.Errorf("stalled = %v, want %s", stalled, want)
+	}
+	if na.Stalled[0].Open != 1 || na.Stalled[0].Waiting != 1 {
+		t.Errorf("blocked = %+v", na.Stalled[0])
+	}
+
+	// Without configured contexts every non-list label is a context.
+	cfg.Contexts = nil
+	na = FindNextActions(projects, tasks, cfg, now)
+	if want := "errands,home,Phone,"; strings.Join(na.Contexts, ",") != want {
+		t.Errorf("contexts = %q, want %q", na.Contexts, want)
+	}
+	if got := ids(na.ByContext["errands"]); got != "1,4" {
+		t.Errorf("errands = %s, want 1,4", got)
+	}
+	if got := ids(na.ByContext["Phone"]); got != "2,10" {
+		t.Errorf("Phone = %s, want 2,10", got)
+	}
+}
+
+func TestGetWaitingFor(t *testing.T) {
+	day := func(d int) time.Time { return time.Date(2026, 10, d, 12, 0, 0, 0, time.UTC) }
+	f := &fakeAccount{
+		tasks: []models.Task{
+			{ID: "1", Content: "Quote from builder", Labels: []string{"Waiting"}, CreatedAt: day(1), UpdatedAt: "2026-10-03T12:00:00.000000Z", CommentCount: 1},
+			{ID: "2", Content: "Review by Kim", AssigneeID: "kim", CreatedAt: day(5)},
+			{ID: "3", Content: "My own task", AssigneeID: "me", CreatedAt: day(2)},
+			{ID: "4", Content: "Refund", Labels: []string{"waiting"}, CreatedAt: day(2), UpdatedAt: "2026-10-04T08:00:00Z"},
+		},
+		comments: []models.Comment{{ID: "c1", TaskID: "1", Content: "Chased by email", PostedAt: day(10)}},
+	}
+	c, srv := testServer(t, f.handler(t))
+	defer srv.Close()
+
+	items, err := c.GetWaitingFor("", GTDConfig{WaitingLabel: "waiting"}, "me")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, it := range items {
+		got = append(got, fmt.Sprintf("%s:%s:%s:%v", it.Task.ID, it.SinceSource, it.Since.Format("01-02"), it.Assigned))
+	}
+	if want := "4:updated:10-04:false,2:created:10-05:true,1:comment:10-10:false"; strings.Join(got, ",") != want {
+		t.Errorf("waiting = %v, want %s", got, want)
+	}
+}
@@ -0,0 +1,746 @@
+package todoist
+
+import (
+	"fmt"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// ICSOptions controls how WriteICS renders tasks.
+type ICSOptions struct {
+	// Events renders tasks as VEVENTs instead of VTODOs. Tasks without a
+	// due date are skipped, since events need a start.
+	Events bool
+	// Name is the calendar's display name (X-WR-CALNAME).
+	Name string
+	// Now is used for DTSTAMP; zero means time.Now.
+	Now time.Time
+}
+
+// ICSResult counts what WriteICS exported.
+type ICSResult struct {
+	Exported int
+	Undated  int // tasks skipped because events need a date
+	// NoRRule counts recurring tasks whose pattern has no RRULE
+	// equivalent; they are exported as single occurrences.
+	NoRRule int
+}
+
+// icsDue is a task's due date resolved for iCalendar.
+type icsDue struct {
+	allDay bool
+	start  time.Time // wall time in loc for timed dues
+	loc    *time.Location
+	tzid   string // set when start should carry a TZID
+	utc    bool
+}
+
+// WriteICS writes tasks as an RFC 5545 calendar. Due datetimes keep their
+// timezone (with a generated VTIMEZONE), durations become DURATION or an
+// end time, and recurring due strings become RRULEs where expressible.
+func WriteICS(w io.Writer, tasks []models.Task, opts ICSOptions) (ICSResult, error) {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	var result ICSResult
+	var body []string
+	tzYears := map[string]int{}
+	tzLocs := map[string]*time.Location{}
+
+	for _, t := range tasks {
+		due, hasDue := resolveICSDue(t.Due)
+		if opts.Events && !hasDue {
+			result.Undated++
+			continue
+		}
+		if hasDue && due.tzid != "" {
+			if y, ok := tzYears[due.tzid]; !ok || due.start.Year() < y {
+				tzYears[due.tzid] = due.start.Year()
+			}
+			tzLocs[due.tzid] = due.loc
+		}
+
+		kind := "VTODO"
+		if opts.Events {
+			kind = "VEVENT"
+		}
+		lines := []string{
+			"BEGIN:" + kind,
+			"UID:" + t.ID + "@todoist.com",
+			"DTSTAMP:" + now.UTC().Format("20060102T150405Z"),
+			"SUMMARY:" + icsEscape(t.Content),
+		}
+		if t.Description != "" {
+			lines = append(lines, "DESCRIPTION:"+icsEscape(t.Description))
+		}
+		if t.URL != "" {
+			lines = append(lines, "URL:"+t.URL)
+		}
+		if p := icsPriority(t.Priority); p > 0 {
+			lines = append(lines, "PRIORITY:"+strconv.Itoa(p))
+		}
+		if len(t.Labels) > 0 {
+			escaped := make([]string, len(t.Labels))
+			for i, l := range t.Labels {
+				escaped[i] = icsEscape(l)
+			}
+			lines = append(lines, "CATEGORIES:"+strings.Join(escaped, ","))
+		}
+
+		if hasDue {
+			rule, hasRule := "", false
+			if t.Due.Recurring {
+				if rule, hasRule = RRuleFromDueString(t.Due.String); !hasRule {
+					result.NoRRule++
+				}
+			}
+
+			length := icsDuration(t.Duration)
+			switch {
+			case opts.Events && length != "":
+				lines = append(lines, due.property("DTSTART"), "DURATION:"+length)
+			case opts.Events && due.allDay:
+				lines = append(lines, due.property("DTSTART"),
+					"DTEND;VALUE=DATE:"+due.start.AddDate(0, 0, 1).Format("20060102"))
+			case opts.Events:
+				lines = append(lines, due.property("DTSTART"))
+			case length != "" && !due.allDay:
+				// A task's due time is when work starts, so a task with a
+				// duration is due at the end of it.
+				end := due
+				end.start = due.start.Add(durationOf(t.Duration))
+				lines = append(lines, due.property("DTSTART"), end.property("DUE"))
+			case hasRule:
+				// Recurrences expand from DTSTART, so a repeating to-do
+				// needs one even though it only has a due date.
+				lines = append(lines, due.property("DTSTART"), due.property("DUE"))
+			default:
+				lines = append(lines, due.property("DUE"))
+			}
+
+			if hasRule {
+				lines = append(lines, "RRULE:"+rule)
+			}
+		}
+
+		if !opts.Events {
+			if t.IsCompleted {
+				lines = append(lines, "STATUS:COMPLETED")
+				if at, err := time.Parse(time.RFC3339, t.CompletedAt); err == nil {
+					lines = append(lines, "COMPLETED:"+at.UTC().Format("20060102T150405Z"))
+				}
+			} else {
+				lines = append(lines, "STATUS:NEEDS-ACTION")
+			}
+		}
+		lines = append(lines, "END:"+kind)
+		body = append(body, lines...)
+		result.Exported++
+	}
+
+	out := []string{"BEGIN:VCALENDAR", "VERSION:2.0", "PRODID:-//mcp-todoist//Todoist export//EN", "CALSCALE:GREGORIAN"}
+	if opts.Name != "" {
+		out = append(out, "X-WR-CALNAME:"+icsEscape(opts.Name))
+	}
+	tzids := make([]string, 0, len(tzYears))
+	for id := range tzYears {
+		tzids = append(tzids, id)
+	}
+	sort.Strings(tzids)
+	for _, id := range tzids {
+		out = append(out, vtimezone(id, tzLocs[id], tzYears[id])...)
+	}
+	out = append(out, body...)
+	out = append(out, "END:VCALENDAR")
+
+	for _, l := range out {
+		if _, err := io.WriteString(w, icsFold(l)); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// resolveICSDue interprets a due date. Datetimes ending in Z are fixed
+// to the task's timezone when it is known; other datetimes are floating.
+func resolveICSDue(d *models.DueDate) (icsDue, bool) {
+	if d == nil || d.Date == "" && d.Datetime == "" {
+		return icsDue{}, false
+	}
+	dt := d.Datetime
+	if dt == "" && len(d.Date) > 10 {
+		dt = d.Date
+	}
+	if dt == "" {
+		day, err := time.Parse("2006-01-02", d.Date)
+		if err != nil {
+			return icsDue{}, false
+		}
+		return icsDue{allDay: true, start: day}, true
+	}
+
+	if strings.HasSuffix(dt, "Z") {
+		at, err := time.Parse(time.RFC3339, dt)
+		if err != nil {
+			return icsDue{}, false
+		}
+		if d.Timezone != "" {
+			if loc, err := time.LoadLocation(d.Timezone); err == nil && loc != time.UTC {
+				return icsDue{start: at.In(loc), loc: loc, tzid: d.Timezone}, true
+			}
+		}
+		return icsDue{start: at.UTC(), utc: true}, true
+	}
+	at, err := time.Parse("2006-01-02T15:04:05", dt)
+	if err != nil {
+		return icsDue{}, false
+	}
+	return icsDue{start: at}, true
+}
+
+func (d icsDue) property(name string) string {
+	switch {
+	case d.allDay:
+		return name + ";VALUE=DATE:" + d.start.Format("20060102")
+	case d.tzid != "":
+		return name + ";TZID=" + d.tzid + ":" + d.start.Format("20060102T150405")
+	case d.utc:
+		return name + ":" + d.start.Format("20060102T150405Z")
+	default:
+		return name + ":" + d.start.Format("20060102T150405")
+	}
+}
+
+// icsPriority maps Todoist priorities onto RFC 5545's 1 (high) to 9 (low);
+// p4 tasks have no priority.
+func icsPriority(p int) int {
+	switch p {
+	case 4:
+		return 1
+	case 3:
+		return 5
+	case 2:
+		return 9
+	}
+	return 0
+}
+
+func durationOf(d *models.Duration) time.Duration {
+	if d.Unit == "day" {
+		return time.Duration(d.Amount) * 24 * time.Hour
+	}
+	return time.Duration(d.Amount) * time.Minute
+}
+
+// icsDuration formats a task duration as an RFC 5545 DURATION value.
+func icsDuration(d *models.Duration) string {
+	if d == nil || d.Amount <= 0 {
+		return ""
+	}
+	if d.Unit == "day" {
+		return fmt.Sprintf("P%dD", d.Amount)
+	}
+	h, m := d.Amount/60, d.Amount%60
+	switch {
+	case h == 0:
+		return fmt.Sprintf("PT%dM", m)
+	case m == 0:
+		return fmt.Sprintf("PT%dH", h)
+	}
+	return fmt.Sprintf("PT%dH%dM", h, m)
+}
+
+var (
+	rruleIntervalRe = regexp.MustCompile(`^(?:(other)|(\d+))\s+(.+)$`)
+	rruleTimeRe     = regexp.MustCompile(`\s+at\s+\d{1,2}(?::\d{2})?\s*(?:am|pm)?$`)
+	rruleOrdinalRe  = regexp.MustCompile(`^(\d{1,2})(?:st|nd|rd|th)?$`)
+	rruleDayNames   = map[string]string{
+		"monday": "MO", "mon": "MO", "tuesday": "TU", "tue": "TU", "tues": "TU",
+		"wednesday": "WE", "wed": "WE", "thursday": "TH", "thu": "TH", "thurs": "TH",
+		"friday": "FR", "fri": "FR", "saturday": "SA", "sat": "SA", "sunday": "SU", "sun": "SU",
+	}
+)
+
+// RRuleFromDueString converts an English Todoist recurrence such as
+// "every 2 weeks", "every mon, wed and fri at 9am" or "every 15th" into
+// an RRULE value. It reports false for patterns iCalendar cannot express,
+// such as "every!" (recurring from completion) or "starting"/"until"
+// bounds.
+func RRuleFromDueString(s string) (string, bool) {
+	s = strings.ToLower(strings.TrimSpace(s))
+	s = rruleTimeRe.ReplaceAllString(s, "")
+	switch s {
+	case "daily", "every day":
+		return "FREQ=DAILY", true
+	case "weekly", "every week":
+		return "FREQ=WEEKLY", true
+	case "monthly", "every month":
+		return "FREQ=MONTHLY", true
+	case "yearly", "annually", "every year":
+		return "FREQ=YEARLY", true
+	}
+	if !strings.HasPrefix(s, "every ") || strings.Contains(s, "starting") || strings.Contains(s, "until") || strings.Contains(s, " for ") {
+		return "", false
+	}
+	rest := strings.TrimSpace(strings.TrimPrefix(s, "every "))
+
+	interval := 1
+	if m := rruleIntervalRe.FindStringSubmatch(rest); m != nil {
+		if m[1] != "" {
+			interval = 2
+		} else {
+			interval, _ = strconv.Atoi(m[2])
+		}
+		rest = m[3]
+	}
+	withInterval := func(rule string) (string, bool) {
+		if interval > 1 {
+			rule += ";INTERVAL=" + strconv.Itoa(interval)
+		}
+		return rule, true
+	}
+
+	switch strings.TrimSuffix(rest, "s") {
+	case "day":
+		return withInterval("FREQ=DAILY")
+	case "week":
+		return withInterval("FREQ=WEEKLY")
+	case "month":
+		return withInterval("FREQ=MONTHLY")
+	case "year":
+		return withInterval("FREQ=YEARLY")
+	case "weekday", "workday":
+		return withInterval("FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR")
+	}
+	if m := rruleOrdinalRe.FindStringSubmatch(rest); m != nil && interval == 1 {
+		if day, _ := strconv.Atoi(m[1]); day >= 1 && day <= 31 {
+			return "FREQ=MONTHLY;BYMONTHDAY=" + m[1], true
+		}
+	}
+
+	var days []string
+	for _, f := range strings.FieldsFunc(strings.ReplaceAll(rest, " and ", ","), func(r rune) bool { return r == ',' || r == ' ' }) {
+		code, ok := rruleDayNames[f]
+		if !ok {
+			return "", false
+		}
+		days = append(days, code)
+	}
+	if len(days) == 0 {
+		return "", false
+	}
+	return withInterval("FREQ=WEEKLY;BYDAY=" + strings.Join(days, ","))
+}
+
+// vtimezone describes loc's UTC offsets from the given year on, using the
+// year's transitions as yearly rules.
+func vtimezone(tzid string, loc *time.Location, year int) []string {
+	lines := []string{"BEGIN:VTIMEZONE", "TZID:" + tzid}
+
+	start := time.Date(year, 1, 1, 0, 0, 0, 0, time.UTC)
+	_, prev := start.In(loc).Zone()
+	var transitions []time.Time
+	for t := start.Add(time.Hour); t.Year() == year; t = t.Add(time.Hour) {
+		if _, off := t.In(loc).Zone(); off != prev {
+			transitions = append(transitions, t)
+			prev = off
+		}
+	}
+
+	if len(transitions) == 0 {
+		name, off := start.In(loc).Zone()
+		lines = append(lines, "BEGIN:STANDARD", "DTSTART:19700101T000000",
+			"TZOFFSETFROM:"+icsOffset(off), "TZOFFSETTO:"+icsOffset(off), "TZNAME:"+name, "END:STANDARD")
+		return append(lines, "END:VTIMEZONE")
+	}
+
+	for _, t := range transitions {
+		_, from := t.Add(-time.Hour).In(loc).Zone()
+		after := t.In(loc)
+		name, to := after.Zone()
+		kind := "STANDARD"
+		if after.IsDST() {
+			kind = "DAYLIGHT"
+		}
+		// DTSTART is the local wall time just before the change.
+		onset := t.UTC().Add(time.Duration(from) * time.Second)
+		week := (onset.Day()-1)/7 + 1
+		if onset.Day()+7 > daysIn(onset.Month(), onset.Year()) {
+			week = -1
+		}
+		weekday := strings.ToUpper(onset.Weekday().String()[:2])
+		lines = append(lines,
+			"BEGIN:"+kind,
+			"DTSTART:"+onset.Format("20060102T150405"),
+			fmt.Sprintf("RRULE:FREQ=YEARLY;BYMONTH=%d;BYDAY=%d%s", onset.Month(), week, weekday),
+			"TZOFFSETFROM:"+icsOffset(from),
+			"TZOFFSETTO:"+icsOffset(to),
+			"TZNAME:"+name,
+			"END:"+kind)
+	}
+	return append(lines, "END:VTIMEZONE")
+}
+
+func daysIn(m time.Month, year int) int {
+	return time.Date(year, m+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func icsOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign, seconds = "-", -seconds
+	}
+	return fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds%3600/60)
+}
+
+var icsEscaper = strings.NewReplacer(`\`, `\\`, ";", `\;`, ",", `\,`, "\r\n", `\n`, "\n", `\n`)
+
+func icsEscape(s string) string { return icsEscaper.Replace(s) }
+
+// icsFold terminates a content line with CRLF, folding it so that no line
+// exceeds 75 octets without splitting a UTF-8 sequence.
+func icsFold(line string) string {
+	var sb strings.Builder
+	limit := 75
+	for len(line) > limit {
+		cut := limit
+		for cut > 0 && line[cut]&0xC0 == 0x80 {
+			cut--
+		}
+		sb.WriteString(line[:cut] + "\r\n ")
+		line = line[cut:]
+		limit = 74 // continuation lines start with a space
+	}
+	sb.WriteString(line + "\r\n")
+	return sb.String()
+}
+
+// ParseICS reads the VTODOs of an iCalendar file as task drafts. Due
+// dates keep their time and timezone, a DTSTART before the DUE becomes
+// the due time plus a duration (the reverse of WriteICS), and RRULEs with
+// a Todoist equivalent become recurring due strings. Completed and
+// cancelled to-dos are skipped, as are other components; skipped counts
+// them.
+func ParseICS(r io.Reader) (drafts []TaskDraft, skipped int, err error) {
+	data, err := io.ReadAll(r)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	text := strings.ReplaceAll(strings.ReplaceAll(string(data), "\r\n", "\n"), "\r", "\n")
+	text = strings.NewReplacer("\n ", "", "\n\t", "").Replace(text)
+	if !strings.HasPrefix(strings.TrimSpace(strings.TrimPrefix(text, "\ufeff")), "BEGIN:VCALENDAR") {
+		return nil, 0, fmt.Errorf("not an iCalendar file (missing BEGIN:VCALENDAR)")
+	}
+
+	var props map[string]icsProp
+	var component string
+	depth, n := 0, 0
+	for _, line := range strings.Split(text, "\n") {
+		if line == "" {
+			continue
+		}
+		p := parseICSLine(line)
+		switch p.name {
+		case "BEGIN":
+			depth++
+			if depth == 2 {
+				component = strings.ToUpper(p.value)
+				props = map[string]icsProp{}
+			}
+			continue
+		case "END":
+			if depth == 2 {
+				if component != "VTODO" {
+					if component != "VTIMEZONE" {
+						skipped++
+					}
+				} else if status := strings.ToUpper(props["STATUS"].value); status == "COMPLETED" || status == "CANCELLED" {
+					skipped++
+				} else {
+					n++
+					d, err := draftFromVTODO(props)
+					if err != nil {
+						return nil, 0, fmt.Errorf("VTODO %d: %w", n, err)
+					}
+					d.Source = fmt.Sprintf("VTODO %d", n)
+					drafts = append(drafts, d)
+				}
+			}
+			depth--
+			continue
+		}
+		if depth == 2 {
+			if _, dup := props[p.name]; !dup {
+				props[p.name] = p
+			}
+		}
+	}
+	return drafts, skipped, nil
+}
+
+// icsProp is one content line: NAME;PARAM=x:value.
+type icsProp struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+func parseICSLine(line string) icsProp {
+	p := icsProp{params: map[string]string{}}
+	inQuote, colon := false, len(line)
+	for i, r := range line {
+		if r == '"' {
+			inQuote = !inQuote
+		}
+		if r == ':' && !inQuote {
+			colon = i
+			break
+		}
+	}
+	head := strings.Split(line[:colon], ";")
+	p.name = strings.ToUpper(head[0])
+	for _, param := range head[1:] {
+		if k, v, ok := strings.Cut(param, "="); ok {
+			p.params[strings.ToUpper(k)] = strings.Trim(v, `"`)
+		}
+	}
+	if colon < len(line) {
+		p.value = line[colon+1:]
+	}
+	return p
+}
+
+var icsUnescaper = strings.NewReplacer(`\\`, `\`, `\;`, ";", `\,`, ",", `\n`, "\n", `\N`, "\n")
+
+func draftFromVTODO(props map[string]icsProp) (TaskDraft, error) {
+	d := TaskDraft{
+		Content:     strings.TrimSpace(icsUnescaper.Replace(props["SUMMARY"].value)),
+		Description: icsUnescaper.Replace(props["DESCRIPTION"].value),
+	}
+	if d.Content == "" {
+		d.Content = "(untitled)"
+	}
+	if p, ok := props["PRIORITY"]; ok {
+		switch v, _ := strconv.Atoi(p.value); {
+		case v >= 1 && v <= 4:
+			d.Priority = 4
+		case v == 5:
+			d.Priority = 3
+		case v >= 6 && v <= 9:
+			d.Priority = 2
+		}
+	}
+	if p, ok := props["CATEGORIES"]; ok {
+		for _, l := range splitICSList(p.value) {
+			if l = strings.TrimSpace(l); l != "" {
+				d.Labels = append(d.Labels, l)
+			}
+		}
+	}
+
+	due, hasDue := props["DUE"]
+	start, hasStart := props["DTSTART"]
+	if !hasDue && !hasStart {
+		return d, nil
+	}
+	at, allDay, floating, err := parseICSTime(due)
+	if !hasDue {
+		at, allDay, floating, err = parseICSTime(start)
+	}
+	if err != nil {
+		return d, err
+	}
+	if hasDue && hasStart && !allDay {
+		if begin, _, _, err := parseICSTime(start); err == nil && begin.Before(at) {
+			d.Duration = &models.Duration{Amount: int(at.Sub(begin).Minutes()), Unit: "minute"}
+			at = begin
+		}
+	}
+
+	switch {
+	case allDay:
+		d.DueDate = at.Format("2006-01-02")
+	case floating:
+		d.DueDatetime = at.Format("2006-01-02T15:04:05")
+	default:
+		d.DueDatetime = at.UTC().Format(time.RFC3339)
+	}
+	if rule, ok := props["RRULE"]; ok {
+		if s, ok := DueStringFromRRule(rule.value); ok {
+			if !allDay {
+				// The time of day as the calendar gave it: in its TZID, in
+				// UTC for a "Z" time, or floating.
+				s += " at " + at.Format("15:04")
+			}
+			d.DueString, d.DueDate, d.DueDatetime = s, "", ""
+		}
+	}
+	return d, nil
+}
+
+// splitICSList splits a comma-separated value, honouring escaped commas.
+func splitICSList(v string) []string {
+	var out []string
+	var cur strings.Builder
+	for i := 0; i < len(v); i++ {
+		switch {
+		case v[i] == '\\' && i+1 < len(v):
+			i++
+			cur.WriteByte(v[i])
+		case v[i] == ',':
+			out = append(out, cur.String())
+			cur.Reset()
+		default:
+			cur.WriteByte(v[i])
+		}
+	}
+	return append(out, cur.String())
+}
+
+// parseICSTime parses a DATE or DATE-TIME value. Times with a TZID that
+// cannot be loaded are treated as floating.
+func parseICSTime(p icsProp) (at time.Time, allDay, floating bool, err error) {
+	v := p.value
+	if p.params["VALUE"] == "DATE" || len(v) == 8 {
+		at, err = time.Parse("20060102", v)
+		if err != nil {
+			return at, false, false, fmt.Errorf("invalid date %q", v)
+		}
+		return at, true, false, nil
+	}
+	if strings.HasSuffix(v, "Z") {
+		at, err = time.Parse("20060102T150405Z", v)
+	} else if loc, lerr := time.LoadLocation(p.params["TZID"]); p.params["TZID"] != "" && lerr == nil {
+		at, err = time.ParseInLocation("20060102T150405", v, loc)
+	} else {
+		at, err = time.Parse("20060102T150405", v)
+		floating = true
+	}
+	if err != nil {
+		return at, false, false, fmt.Errorf("invalid date-time %q", v)
+	}
+	return at, false, floating, nil
+}
+
+// DueStringFromRRule converts an RRULE into an English Todoist recurrence,
+// the reverse of RRuleFromDueString. It reports false for rules Todoist
+// cannot express, such as those with COUNT, UNTIL or BYSETPOS.
+func DueStringFromRRule(rule string) (string, bool) {
+	parts := map[string]string{}
+	for _, kv := range strings.Split(strings.ToUpper(strings.TrimPrefix(rule, "RRULE:")), ";") {
+		if k, v, ok := strings.Cut(kv, "="); ok {
+			parts[k] = v
+		}
+	}
+	interval := 1
+	if v, ok := parts["INTERVAL"]; ok {
+		n, err := strconv.Atoi(v)
+		if err != nil || n < 1 {
+			return "", false
+		}
+		interval = n
+	}
+	for k := range parts {
+		switch k {
+		case "FREQ", "INTERVAL", "BYDAY", "BYMONTHDAY", "WKST":
+		default:
+			return "", false
+		}
+	}
+
+	every := func(unit string) string {
+		switch interval {
+		case 1:
+			return "every " + unit
+		case 2:
+			return "every other " + unit
+		}
+		return fmt.Sprintf("every %d %ss", interval, unit)
+	}
+
+	switch parts["FREQ"] {
+	case "DAILY":
+		if parts["BYDAY"] != "" || parts["BYMONTHDAY"] != "" {
+			return "", false
+		}
+		return every("day"), true
+	case "WEEKLY":
+		if parts["BYMONTHDAY"] != "" {
+			return "", false
+		}
+		days := strings.Split(parts["BYDAY"], ",")
+		if parts["BYDAY"] == "" {
+			return every("week"), true
+		}
+		if interval == 1 && strings.Join(sortedWeekdays(days), ",") == "MO,TU,WE,TH,FR" {
+			return "every weekday", true
+		}
+		names := make([]string, len(days))
+		for i, code := range days {
+			name, ok := rruleDayCodes[code]
+			if !ok {
+				return "", false
+			}
+			names[i] = name
+		}
+		switch {
+		case interval == 1:
+			return "every " + strings.Join(names, ", "), true
+		case interval == 2 && len(names) == 1:
+			return "every other " + names[0], true
+		}
+		return "", false
+	case "MONTHLY":
+		if parts["BYDAY"] != "" {
+			return "", false
+		}
+		if v := parts["BYMONTHDAY"]; v != "" {
+			day, err := strconv.Atoi(v)
+			if err != nil || day < 1 || day > 31 || interval != 1 {
+				return "", false
+			}
+			return "every " + ordinal(day), true
+		}
+		return every("month"), true
+	case "YEARLY":
+		if parts["BYDAY"] != "" || parts["BYMONTHDAY"] != "" {
+			return "", false
+		}
+		return every("year"), true
+	}
+	return "", false
+}
+
+var rruleDayCodes = map[string]string{
+	"MO": "monday", "TU": "tuesday", "WE": "wednesday", "TH": "thursday",
+	"FR": "friday", "SA": "saturday", "SU": "sunday",
+}
+
+func sortedWeekdays(codes []string) []string {
+	order := map[string]int{"MO": 0, "TU": 1, "WE": 2, "TH": 3, "FR": 4, "SA": 5, "SU": 6}
+	out := append([]string(nil), codes...)
+	sort.Slice(out, func(i, j int) bool { return order[out[i]] < order[out[j]] })
+	return out
+}
+
+func ordinal(n int) string {
+	suffix := "th"
+	if n%100 < 11 || n%100 > 13 {
+		switch n % 10 {
+		case 1:
+			suffix = "st"
+		case 2:
+			suffix = "nd"
+		case 3:
+			suffix = "rd"
+		}
+	}
+	return strconv.Itoa(n) + suffix
+}
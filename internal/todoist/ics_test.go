@@ -0,0 +1,180 @@
+package todoist
+
+import (
+	"bytes"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestRRuleFromDueString(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"every day", "FREQ=DAILY", true},
+		{"Every day at 9am", "FREQ=DAILY", true},
+		{"every 3 days", "FREQ=DAILY;INTERVAL=3", true},
+		{"every other week", "FREQ=WEEKLY;INTERVAL=2", true},
+		{"every weekday", "FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR", true},
+		{"every mon, wed and fri at 08:30", "FREQ=WEEKLY;BYDAY=MO,WE,FR", true},
+		{"every other tuesday", "FREQ=WEEKLY;BYDAY=TU;INTERVAL=2", true},
+		{"every 15th", "FREQ=MONTHLY;BYMONTHDAY=15", true},
+		{"yearly", "FREQ=YEARLY", true},
+		{"every! 3 days", "", false},
+		{"every day starting dec 1", "", false},
+		{"every last day", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := RRuleFromDueString(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("RRuleFromDueString(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+	}
+}
+
+func TestWriteICS_todos(t *testing.T) {
+	now := time.Date(2026, 10, 18, 12, 0, 0, 0, time.UTC)
+	tasks := []models.Task{
+		{ID: "1", Content: "Standup; daily, short", Priority: 4, Labels: []string{"work"},
+			Due:      &models.DueDate{Date: "2026-10-19", Datetime: "2026-10-19T07:30:00Z", Timezone: "Europe/Berlin", String: "every weekday at 9:30", Recurring: true},
+			Duration: &models.Duration{Amount: 15, Unit: "minute"}},
+		{ID: "2", Content: "Pay rent", Due: &models.DueDate{Date: "2026-11-01", String: "every! month", Recurring: true}},
+		{ID: "3", Content: "Someday idea", Description: strings.Repeat("long ", 20)},
+		{ID: "4", Content: "Water plants", Due: &models.DueDate{Date: "2026-10-20", String: "every 3 days", Recurring: true}},
+	}
+	var buf bytes.Buffer
+	result, err := WriteICS(&buf, tasks, ICSOptions{Now: now})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Exported != 4 || result.NoRRule != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"BEGIN:VCALENDAR\r\nVERSION:2.0\r\n",
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\n",
+		"BEGIN:DAYLIGHT\r\nDTSTART:20260329T020000\r\nRRULE:FREQ=YEARLY;BYMONTH=3;BYDAY=-1SU\r\nTZOFFSETFROM:+0100\r\nTZOFFSETTO:+0200\r\nTZNAME:CEST\r\n",
+		"BEGIN:STANDARD\r\nDTSTART:20261025T030000\r\nRRULE:FREQ=YEARLY;BYMONTH=10;BYDAY=-1SU\r\nTZOFFSETFROM:+0200\r\nTZOFFSETTO:+0100\r\n",
+		"UID:1@todoist.com\r\nDTSTAMP:20261018T120000Z\r\nSUMMARY:Standup\\; daily\\, short\r\n",
+		"PRIORITY:1\r\nCATEGORIES:work\r\n",
+		"DTSTART;TZID=Europe/Berlin:20261019T093000\r\nDUE;TZID=Europe/Berlin:20261019T094500\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\nSTATUS:NEEDS-ACTION\r\n",
+		"SUMMARY:Pay rent\r\nDUE;VALUE=DATE:20261101\r\nSTATUS:NEEDS-ACTION\r\n",
+		"SUMMARY:Water plants\r\nDTSTART;VALUE=DATE:20261020\r\nDUE;VALUE=DATE:20261020\r\nRRULE:FREQ=DAILY;INTERVAL=3\r\n",
+		"END:VCALENDAR\r\n",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	for _, line := range strings.Split(out, "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line not folded: %q", line)
+		}
+	}
+	if !strings.Contains(out, "DESCRIPTION:long long") || !strings.Contains(out, "lon\r\n g long") {
+		t.Errorf("description not folded:\n%s", out)
+	}
+}
+
+func TestWriteICS_events(t *testing.T) {
+	tasks := []models.Task{
+		{ID: "1", Content: "Offsite", Due: &models.DueDate{Date: "2026-11-05"}},
+		{ID: "2", Content: "Review", Due: &models.DueDate{Date: "2026-11-06", Datetime: "2026-11-06T14:00:00"},
+			Duration: &models.Duration{Amount: 90, Unit: "minute"}},
+		{ID: "3", Content: "Sync", Due: &models.DueDate{Date: "2026-11-06", Datetime: "2026-11-06T09:00:00Z"}},
+		{ID: "4", Content: "No date"},
+	}
+	var buf bytes.Buffer
+	result, err := WriteICS(&buf, tasks, ICSOptions{Events: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.Exported != 3 || result.Undated != 1 {
+		t.Errorf("result = %+v", result)
+	}
+	out := buf.String()
+	for _, want := range []string{
+		"SUMMARY:Offsite\r\nDTSTART;VALUE=DATE:20261105\r\nDTEND;VALUE=DATE:20261106\r\nEND:VEVENT",
+		"SUMMARY:Review\r\nDTSTART:20261106T140000\r\nDURATION:PT1H30M\r\nEND:VEVENT",
+		"SUMMARY:Sync\r\nDTSTART:20261106T090000Z\r\nEND:VEVENT",
+	} {
+		if !strings.Contains(out, want) {
+			t.Errorf("output missing %q:\n%s", want, out)
+		}
+	}
+	if strings.Contains(out, "VTIMEZONE") || strings.Contains(out, "STATUS:") {
+		t.Errorf("unexpected VTIMEZONE or STATUS:\n%s", out)
+	}
+}
+
+func TestDueStringFromRRule(t *testing.T) {
+	tests := []struct {
+		in, want string
+		ok       bool
+	}{
+		{"FREQ=DAILY", "every day", true},
+		{"RRULE:FREQ=DAILY;INTERVAL=3", "every 3 days", true},
+		{"FREQ=WEEKLY;INTERVAL=2", "every other week", true},
+		{"FREQ=WEEKLY;BYDAY=FR,MO,TU,WE,TH", "every weekday", true},
+		{"FREQ=WEEKLY;BYDAY=MO,WE", "every monday, wednesday", true},
+		{"FREQ=WEEKLY;INTERVAL=2;BYDAY=TU", "every other tuesday", true},
+		{"FREQ=MONTHLY;BYMONTHDAY=22", "every 22nd", true},
+		{"FREQ=YEARLY", "every year", true},
+		{"FREQ=DAILY;COUNT=5", "", false},
+		{"FREQ=MONTHLY;BYDAY=1MO", "", false},
+		{"FREQ=HOURLY", "", false},
+	}
+	for _, tt := range tests {
+		got, ok := DueStringFromRRule(tt.in)
+		if got != tt.want || ok != tt.ok {
+			t.Errorf("DueStringFromRRule(%q) = %q, %v; want %q, %v", tt.in, got, ok, tt.want, tt.ok)
+		}
+		if ok {
+			if back, _ := RRuleFromDueString(got); back == "" {
+				t.Errorf("%q does not convert back to an RRULE", got)
+			}
+		}
+	}
+}
+
+func TestParseICS(t *testing.T) {
+	in := "BEGIN:VCALENDAR\r\nVERSION:2.0\r\n" +
+		"BEGIN:VTIMEZONE\r\nTZID:Europe/Berlin\r\nEND:VTIMEZONE\r\n" +
+		"BEGIN:VTODO\r\nUID:a\r\nSUMMARY:Review PR\\, then merge\r\nDESCRIPTION:line one\\nline two that is long enough to\r\n  be folded\r\n" +
+		"PRIORITY:1\r\nCATEGORIES:work,code\\,review\r\n" +
+		"DTSTART;TZID=Europe/Berlin:20261019T093000\r\nDUE;TZID=Europe/Berlin:20261019T100000\r\n" +
+		"BEGIN:VALARM\r\nACTION:DISPLAY\r\nSUMMARY:ignored\r\nEND:VALARM\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Standup\r\nDUE:20261020T091500\r\nRRULE:FREQ=WEEKLY;BYDAY=MO,TU,WE,TH,FR\r\nPRIORITY:5\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Taxes\r\nDUE;VALUE=DATE:20270415\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Sync with NY\r\nDTSTART;TZID=America/New_York:20261019T090000\r\nRRULE:FREQ=WEEKLY;BYDAY=MO\r\nEND:VTODO\r\n" +
+		"BEGIN:VTODO\r\nSUMMARY:Old\r\nSTATUS:COMPLETED\r\nEND:VTODO\r\n" +
+		"BEGIN:VEVENT\r\nSUMMARY:Party\r\nEND:VEVENT\r\n" +
+		"END:VCALENDAR\r\n"
+	drafts, skipped, err := ParseICS(strings.NewReader(in))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if skipped != 2 {
+		t.Errorf("skipped = %d, want 2", skipped)
+	}
+	want := []TaskDraft{
+		{Source: "VTODO 1", Content: "Review PR, then merge", Description: "line one\nline two that is long enough to be folded",
+			DueDatetime: "2026-10-19T07:30:00Z", Priority: 4, Labels: []string{"work", "code,review"},
+			Duration: &models.Duration{Amount: 30, Unit: "minute"}},
+		{Source: "VTODO 2", Content: "Standup", DueString: "every weekday at 09:15", Priority: 3},
+		{Source: "VTODO 3", Content: "Taxes", DueDate: "2027-04-15"},
+		{Source: "VTODO 4", Content: "Sync with NY", DueString: "every monday at 09:00"},
+	}
+	if !reflect.DeepEqual(drafts, want) {
+		t.Errorf("drafts:\n got  %+v\n want %+v", drafts, want)
+	}
+
+	if _, _, err := ParseICS(strings.NewReader("Title,Due\n")); err == nil {
+		t.Error("expected error for non-iCalendar input")
+	}
+}
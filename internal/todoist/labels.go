@@ -3,22 +3,15 @@ package todoist
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
+	"strings"
 
 	"github.com/nsega/mcp-todoist/internal/models"
 )
 
 // GetLabels returns all personal labels.
 func (c *Client) GetLabels() ([]models.Label, error) {
-	data, err := c.do("GET", "/labels", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var page PaginatedResponse[models.Label]
-	if err := json.Unmarshal(data, &page); err != nil {
-		return nil, fmt.Errorf("failed to parse labels: %w", err)
-	}
-	return page.Results, nil
+	return getAll[models.Label](c, "/labels", nil, "labels")
 }
 
 // CreateLabel creates a new personal label.
@@ -54,3 +47,69 @@ func (c *Client) DeleteLabel(id string) error {
 	_, err := c.do("DELETE", "/labels/"+id, nil)
 	return err
 }
+
+// GetSharedLabels returns the names of labels used on tasks in shared
+// projects. With omitPersonal, names that are also personal labels are
+// left out.
+func (c *Client) GetSharedLabels(omitPersonal bool) ([]string, error) {
+	params := url.Values{}
+	if omitPersonal {
+		params.Set("omit_personal", "true")
+	}
+	return getAll[string](c, "/labels/shared", params, "shared labels")
+}
+
+// RenameSharedLabel renames a shared label on all tasks that use it.
+func (c *Client) RenameSharedLabel(name, newName string) error {
+	_, err := c.do("POST", "/labels/shared/rename", map[string]interface{}{"name": name, "new_name": newName})
+	return err
+}
+
+// RemoveSharedLabel removes a shared label from all tasks that use it.
+func (c *Client) RemoveSharedLabel(name string) error {
+	_, err := c.do("POST", "/labels/shared/remove", map[string]interface{}{"name": name})
+	return err
+}
+
+// ReplaceLabelOnTasks swaps label oldName for newName on every active task
+// that carries it, matching names case-insensitively. An empty newName
+// removes the label. It returns the number of tasks updated.
+func (c *Client) ReplaceLabelOnTasks(oldName, newName string) (int, error) {
+	tasks, err := c.GetTasks("", "")
+	if err != nil {
+		return 0, err
+	}
+
+	updated := 0
+	for _, t := range tasks {
+		labels, changed := replaceLabel(t.Labels, oldName, newName)
+		if !changed {
+			continue
+		}
+		if _, err := c.UpdateTask(t.ID, map[string]interface{}{"labels": labels}); err != nil {
+			return updated, fmt.Errorf("failed to update labels on task %s: %w", t.ID, err)
+		}
+		updated++
+	}
+	return updated, nil
+}
+
+// replaceLabel returns labels with oldName replaced by newName (or removed
+// if newName is empty), without introducing duplicates.
+func replaceLabel(labels []string, oldName, newName string) ([]string, bool) {
+	out := make([]string, 0, len(labels))
+	changed := false
+	seen := map[string]bool{}
+	for _, l := range labels {
+		if strings.EqualFold(l, oldName) {
+			changed = true
+			l = newName
+		}
+		if l == "" || seen[strings.ToLower(l)] {
+			continue
+		}
+		seen[strings.ToLower(l)] = true
+		out = append(out, l)
+	}
+	return out, changed
+}
@@ -1,6 +1,8 @@
 package todoist
 
 import (
+	"encoding/json"
+	"fmt"
 	"net/http"
 	"testing"
 )
@@ -54,3 +56,77 @@ func TestDeleteLabel(t *testing.T) {
 		t.Fatal(err)
 	}
 }
+
+func TestGetSharedLabels(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/labels/shared" || r.URL.Query().Get("omit_personal") != "true" {
+			t.Errorf("url = %s", r.URL)
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"results":["team","review"],"next_cursor":"c2"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":["ops"],"next_cursor":""}`))
+	})
+	defer srv.Close()
+
+	labels, err := c.GetSharedLabels(true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(labels) != 3 || labels[0] != "team" || labels[2] != "ops" {
+		t.Errorf("unexpected labels: %v", labels)
+	}
+}
+
+func TestRenameSharedLabel(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/labels/shared/rename" {
+			t.Errorf("method=%s path=%s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		if body["name"] != "old" || body["new_name"] != "new" {
+			t.Errorf("body = %v", body)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer srv.Close()
+
+	if err := c.RenameSharedLabel("old", "new"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestReplaceLabelOnTasks(t *testing.T) {
+	updates := map[string][]interface{}{}
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(`{"results":[
+				{"id":"1","content":"a","labels":["Errand","home"]},
+				{"id":"2","content":"b","labels":["errand","shop"]},
+				{"id":"3","content":"c","labels":["work"]}
+			],"next_cursor":""}`))
+			return
+		}
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		updates[r.URL.Path] = body["labels"].([]interface{})
+		_, _ = w.Write([]byte(`{"id":"x","content":"x"}`))
+	})
+	defer srv.Close()
+
+	n, err := c.ReplaceLabelOnTasks("errand", "shop")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if n != 2 || len(updates) != 2 {
+		t.Fatalf("updated %d tasks: %v", n, updates)
+	}
+	if got := fmt.Sprint(updates["/tasks/1"]); got != "[shop home]" {
+		t.Errorf("task 1 labels = %s", got)
+	}
+	if got := fmt.Sprint(updates["/tasks/2"]); got != "[shop]" {
+		t.Errorf("task 2 labels = %s", got)
+	}
+}
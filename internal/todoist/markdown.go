@@ -0,0 +1,356 @@
+package todoist
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// syncBatchSize is the most commands the Sync API accepts per request.
+const syncBatchSize = 100
+
+// MarkdownTask is a list item parsed from a markdown checklist.
+type MarkdownTask struct {
+	Line        int
+	Content     string
+	Description string
+	Section     string
+	Project     string // project name from #project, empty for the default
+	Labels      []string
+	Priority    int    // API priority (4 = p1), 0 if unset
+	Due         string // date phrase, passed to Todoist as a due string
+	Checked     bool
+	Parent      int // index of the parent item in MarkdownDoc.Tasks, -1 at top level
+}
+
+// MarkdownDoc is the result of ParseMarkdown.
+type MarkdownDoc struct {
+	Tasks   []MarkdownTask
+	Skipped int // non-blank lines that were neither headings nor list items
+}
+
+// MarkdownImport maps source lines to the tasks ImportMarkdown created.
+type MarkdownImport struct {
+	Lines           []MarkdownImportedLine
+	SectionsCreated int
+	SectionsReused  int
+}
+
+// MarkdownImportedLine records the task created for one list item.
+type MarkdownImportedLine struct {
+	Line    int
+	Content string
+	TaskID  string
+}
+
+var (
+	mdHeadingRe = regexp.MustCompile(`^(#{1,6})\s+(.*)$`)
+	mdItemRe    = regexp.MustCompile(`^([-*+]|\d+[.)])\s+(?:\[([ xX])\]\s*)?(.*)$`)
+	mdISODateRe = regexp.MustCompile(`^\d{4}-\d{2}-\d{2}$`)
+)
+
+// mdDateRe matches the date phrases recognised in list items. The leading
+// "due", "by" or "on" is dropped from the phrase sent to Todoist.
+var mdDateRe = func() *regexp.Regexp {
+	weekday := `(?:monday|tuesday|wednesday|thursday|friday|saturday|sunday)`
+	shortDay := `(?:mon|tues?|wed|thu(?:rs?)?|fri|sat|sun)`
+	month := `(?:jan(?:uary)?|feb(?:ruary)?|mar(?:ch)?|apr(?:il)?|may|june?|july?|aug(?:ust)?|sept?(?:ember)?|oct(?:ober)?|nov(?:ember)?|dec(?:ember)?)`
+	unit := `(?:days?|weeks?|months?|years?|weekdays?|workdays?)`
+	clock := `(?:\s+(?:at\s+)?\d{1,2}(?::\d{2})?\s*(?:am|pm)|\s+at\s+\d{1,2}(?::\d{2})?)?`
+	phrase := strings.Join([]string{
+		`every\s+(?:other\s+)?(?:\d+\s+)?(?:` + unit + `|` + weekday + `|` + shortDay + `)`,
+		`\d{4}-\d{2}-\d{2}`,
+		`today|tonight|tomorrow`,
+		`(?:next|this)\s+(?:week|weekend|month|year|` + weekday + `|` + shortDay + `)`,
+		`in\s+\d+\s+(?:days?|weeks?|months?)`,
+		month + `\s+\d{1,2}(?:st|nd|rd|th)?`,
+		`\d{1,2}(?:st|nd|rd|th)?\s+` + month,
+		weekday,
+	}, "|")
+	return regexp.MustCompile(`(?i)(?:^|\s)(?:(?:due:?|by|on)\s+)?((?:` + phrase + `)` + clock + `)(?:$|[\s,.;])`)
+}()
+
+// ParseMarkdown parses markdown checklists into tasks. Nested list items
+// become subtasks and headings become sections. Within an item, @label,
+// #project, p1-p4 and a date phrase are extracted from the content; a
+// #project in a heading applies to the items below it. Only #words that
+// name one of projects are taken as projects, so "Fix issue #123" keeps
+// its text. Indented lines under an item are added to its description.
+func ParseMarkdown(text string, projects []models.Project) *MarkdownDoc {
+	isProject := func(ref string) bool { return matchProjectName(projects, ref) != "" }
+	doc := &MarkdownDoc{}
+	type open struct{ indent, index int }
+	var stack []open
+	var section, headingProject string
+
+	for i, raw := range strings.Split(strings.ReplaceAll(text, "\r\n", "\n"), "\n") {
+		line := strings.ReplaceAll(raw, "\t", "    ")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" {
+			continue
+		}
+		indent := len(line) - len(strings.TrimLeft(line, " "))
+
+		if m := mdHeadingRe.FindStringSubmatch(trimmed); m != nil && indent == 0 {
+			section, headingProject = parseMarkdownHeading(m[2], isProject)
+			stack = nil
+			continue
+		}
+
+		m := mdItemRe.FindStringSubmatch(trimmed)
+		if m == nil {
+			if len(stack) > 0 && indent > stack[len(stack)-1].indent {
+				last := &doc.Tasks[stack[len(stack)-1].index]
+				if last.Description != "" {
+					last.Description += "\n"
+				}
+				last.Description += trimmed
+				continue
+			}
+			doc.Skipped++
+			continue
+		}
+
+		for len(stack) > 0 && stack[len(stack)-1].indent >= indent {
+			stack = stack[:len(stack)-1]
+		}
+		t := parseMarkdownContent(m[3], isProject)
+		if t.Content == "" {
+			doc.Skipped++
+			continue
+		}
+		t.Line = i + 1
+		t.Checked = m[2] == "x" || m[2] == "X"
+		t.Parent = -1
+		if len(stack) > 0 {
+			parent := doc.Tasks[stack[len(stack)-1].index]
+			t.Parent = stack[len(stack)-1].index
+			t.Project, t.Section = parent.Project, parent.Section
+		} else {
+			t.Section = section
+			if t.Project == "" {
+				t.Project = headingProject
+			}
+		}
+		doc.Tasks = append(doc.Tasks, t)
+		stack = append(stack, open{indent, len(doc.Tasks) - 1})
+	}
+	return doc
+}
+
+// parseMarkdownHeading splits a heading into a section name and an
+// optional #project. Date phrases are left in the name.
+func parseMarkdownHeading(s string, isProject func(string) bool) (name, project string) {
+	var words []string
+	for _, w := range strings.Fields(s) {
+		if len(w) > 1 && w[0] == '#' && isProject(w[1:]) {
+			project = w[1:]
+			continue
+		}
+		words = append(words, w)
+	}
+	return strings.Join(words, " "), project
+}
+
+// parseMarkdownContent extracts the inline attributes of a list item.
+func parseMarkdownContent(s string, isProject func(string) bool) MarkdownTask {
+	var t MarkdownTask
+	if m := mdDateRe.FindStringSubmatchIndex(s); m != nil {
+		t.Due = s[m[2]:m[3]]
+		s = s[:m[0]] + " " + s[m[1]:]
+	}
+
+	var words []string
+	for _, w := range strings.Fields(s) {
+		bare := strings.TrimRight(w, ",;.")
+		switch {
+		case len(bare) > 1 && bare[0] == '@':
+			t.Labels = append(t.Labels, bare[1:])
+		case len(bare) > 1 && bare[0] == '#' && isProject(bare[1:]):
+			t.Project = bare[1:]
+		case len(bare) == 2 && (bare[0] == 'p' || bare[0] == 'P') && bare[1] >= '1' && bare[1] <= '4':
+			t.Priority = 5 - int(bare[1]-'0')
+		default:
+			words = append(words, w)
+		}
+	}
+	t.Content = strings.Join(words, " ")
+	return t
+}
+
+// ImportMarkdown creates a parsed document's sections and tasks in one
+// Sync API request (split only past the command limit). Items without a
+// #project go to projectID, or the inbox if it is empty. Project names
+// are resolved against projects, the list the document was parsed with,
+// before anything is written; existing sections are reused by name.
+// Checked items are created and then completed.
+func (c *Client) ImportMarkdown(doc *MarkdownDoc, projectID string, projects []models.Project) (*MarkdownImport, error) {
+	if projectID == "" {
+		for _, p := range projects {
+			if p.IsInboxProject {
+				projectID = p.ID
+			}
+		}
+		if projectID == "" {
+			return nil, fmt.Errorf("no inbox project found; specify a project")
+		}
+	}
+
+	projectIDs := map[string]string{"": projectID}
+	var unknown []string
+	for _, t := range doc.Tasks {
+		if _, ok := projectIDs[t.Project]; ok {
+			continue
+		}
+		id := matchProjectName(projects, t.Project)
+		if id == "" {
+			unknown = append(unknown, "#"+t.Project)
+		}
+		projectIDs[t.Project] = id
+	}
+	if len(unknown) > 0 {
+		return nil, fmt.Errorf("unknown project(s): %s", strings.Join(unknown, ", "))
+	}
+
+	result := &MarkdownImport{}
+	var cmds []syncCommand
+	sectionIDs := map[string]map[string]string{} // project ID → lower-cased name → ID or temp ID
+	reused := map[string]bool{}                  // existing section ID → already counted
+	sectionID := func(projectID, name string) (string, error) {
+		if sectionIDs[projectID] == nil {
+			existing, err := c.GetSections(projectID)
+			if err != nil {
+				return "", err
+			}
+			sectionIDs[projectID] = map[string]string{}
+			for _, s := range existing {
+				sectionIDs[projectID][strings.ToLower(s.Name)] = s.ID
+				reused[s.ID] = false
+			}
+		}
+		key := strings.ToLower(name)
+		if id, ok := sectionIDs[projectID][key]; ok {
+			if counted, existing := reused[id]; existing && !counted {
+				reused[id] = true
+				result.SectionsReused++
+			}
+			return id, nil
+		}
+		cmd := newSyncCommand("section_add", map[string]interface{}{"name": name, "project_id": projectID}, true)
+		cmds = append(cmds, cmd)
+		sectionIDs[projectID][key] = cmd.TempID
+		result.SectionsCreated++
+		return cmd.TempID, nil
+	}
+
+	tempIDs := make([]string, len(doc.Tasks))
+	var closes []syncCommand
+	for i, t := range doc.Tasks {
+		args := map[string]interface{}{"content": t.Content}
+		if t.Parent >= 0 {
+			args["parent_id"] = tempIDs[t.Parent]
+		} else {
+			args["project_id"] = projectIDs[t.Project]
+			if t.Section != "" {
+				id, err := sectionID(projectIDs[t.Project], t.Section)
+				if err != nil {
+					return nil, err
+				}
+				args["section_id"] = id
+			}
+		}
+		if t.Description != "" {
+			args["description"] = t.Description
+		}
+		if len(t.Labels) > 0 {
+			args["labels"] = t.Labels
+		}
+		if t.Priority > 1 {
+			args["priority"] = t.Priority
+		}
+		if t.Due != "" {
+			if mdISODateRe.MatchString(t.Due) {
+				args["due"] = map[string]interface{}{"date": t.Due}
+			} else {
+				args["due"] = map[string]interface{}{"string": t.Due}
+			}
+		}
+		cmd := newSyncCommand("item_add", args, true)
+		cmds = append(cmds, cmd)
+		tempIDs[i] = cmd.TempID
+		if t.Checked {
+			closes = append(closes, newSyncCommand("item_close", map[string]interface{}{"id": cmd.TempID}, false))
+		}
+	}
+	// Close subtasks before their parents so no item is completed twice.
+	for i := len(closes) - 1; i >= 0; i-- {
+		cmds = append(cmds, closes[i])
+	}
+
+	ids := map[string]string{}
+	for start := 0; start < len(cmds); start += syncBatchSize {
+		batch := cmds[start:min(start+syncBatchSize, len(cmds))]
+		// Temp IDs are only valid within one request, so references to
+		// objects created by an earlier batch use their real IDs.
+		for _, cmd := range batch {
+			for _, k := range []string{"id", "parent_id", "section_id"} {
+				if v, ok := cmd.Args[k].(string); ok && ids[v] != "" {
+					cmd.Args[k] = ids[v]
+				}
+			}
+		}
+		mapping, err := c.syncWrite(batch...)
+		for k, v := range mapping {
+			ids[k] = v
+		}
+		if err != nil {
+			// The other commands in the failed request were still
+			// applied, so report every task that was created.
+			result.Lines = importedMarkdownLines(doc, tempIDs, ids)
+			return result, fmt.Errorf("request %d of %d was partially applied: %w",
+				start/syncBatchSize+1, (len(cmds)+syncBatchSize-1)/syncBatchSize, err)
+		}
+	}
+	result.Lines = importedMarkdownLines(doc, tempIDs, ids)
+	return result, nil
+}
+
+// importedMarkdownLines lists, in document order, the items whose temp
+// IDs have been mapped to real task IDs.
+func importedMarkdownLines(doc *MarkdownDoc, tempIDs []string, ids map[string]string) []MarkdownImportedLine {
+	var lines []MarkdownImportedLine
+	for i, t := range doc.Tasks {
+		if id := ids[tempIDs[i]]; id != "" {
+			lines = append(lines, MarkdownImportedLine{Line: t.Line, Content: t.Content, TaskID: id})
+		}
+	}
+	return lines
+}
+
+// matchProjectName finds a project by name, ignoring case and treating
+// "-" and "_" in the reference as spaces, so "#Home_Office" matches
+// "Home Office". It returns "" if there is no match.
+func matchProjectName(projects []models.Project, ref string) string {
+	normalize := func(s string) string {
+		s = strings.NewReplacer("-", " ", "_", " ").Replace(strings.ToLower(s))
+		return strings.Join(strings.Fields(s), " ")
+	}
+	want := normalize(ref)
+	squashed := strings.ReplaceAll(want, " ", "")
+	var fuzzy []string
+	for _, p := range projects {
+		name := normalize(p.Name)
+		if name == want {
+			return p.ID
+		}
+		if strings.ReplaceAll(name, " ", "") == squashed {
+			fuzzy = append(fuzzy, p.ID)
+		}
+	}
+	if len(fuzzy) == 1 {
+		return fuzzy[0]
+	}
+	return ""
+}
@@ -0,0 +1,177 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+const meetingNotes = `# Standup #Work
+
+- [ ] Send recap to team @email p1 tomorrow
+    Include the action items
+    - [ ] Collect notes from Ana
+    - [x] Book follow-up room
+- [ ] Renew domain #Home_Office due next fri at 9am
+
+Random paragraph that is not a task.
+
+## Later
+* [ ] Draft Q4 plan @planning 2026-11-02
+1. Review budget p3
+`
+
+var meetingProjects = []models.Project{{ID: "w", Name: "Work"}, {ID: "h", Name: "Home Office"}}
+
+func TestParseMarkdown(t *testing.T) {
+	doc := ParseMarkdown(meetingNotes, meetingProjects)
+	if doc.Skipped != 1 {
+		t.Errorf("skipped = %d, want 1", doc.Skipped)
+	}
+	want := []MarkdownTask{
+		{Line: 3, Content: "Send recap to team", Description: "Include the action items", Section: "Standup", Project: "Work",
+			Labels: []string{"email"}, Priority: 4, Due: "tomorrow", Parent: -1},
+		{Line: 5, Content: "Collect notes from Ana", Section: "Standup", Project: "Work", Parent: 0},
+		{Line: 6, Content: "Book follow-up room", Section: "Standup", Project: "Work", Checked: true, Parent: 0},
+		{Line: 7, Content: "Renew domain", Section: "Standup", Project: "Home_Office", Due: "next fri at 9am", Parent: -1},
+		{Line: 12, Content: "Draft Q4 plan", Section: "Later", Labels: []string{"planning"}, Due: "2026-11-02", Parent: -1},
+		{Line: 13, Content: "Review budget", Section: "Later", Priority: 2, Parent: -1},
+	}
+	if len(doc.Tasks) != len(want) {
+		t.Fatalf("got %d tasks: %+v", len(doc.Tasks), doc.Tasks)
+	}
+	for i := range want {
+		if !reflect.DeepEqual(doc.Tasks[i], want[i]) {
+			t.Errorf("task %d:\n got  %+v\n want %+v", i, doc.Tasks[i], want[i])
+		}
+	}
+}
+
+func TestParseMarkdown_datePhrases(t *testing.T) {
+	tests := []struct{ in, content, due string }{
+		{"Water plants every monday", "Water plants", "every monday"},
+		{"Pay rent every 2 weeks", "Pay rent", "every 2 weeks"},
+		{"Call dentist in 3 days", "Call dentist", "in 3 days"},
+		{"Ship release by Oct 20th.", "Ship release", "Oct 20th"},
+		{"Prepare slides on Friday at 3pm", "Prepare slides", "Friday at 3pm"},
+		{"Email sat@example.com about the sun", "Email sat@example.com about the sun", ""},
+	}
+	for _, tt := range tests {
+		got := parseMarkdownContent(tt.in, func(string) bool { return false })
+		if got.Content != tt.content || got.Due != tt.due {
+			t.Errorf("%q: content=%q due=%q, want %q / %q", tt.in, got.Content, got.Due, tt.content, tt.due)
+		}
+	}
+}
+
+func TestImportMarkdown(t *testing.T) {
+	var cmds []syncCommand
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sections":
+			if r.URL.Query().Get("project_id") == "w" {
+				_, _ = w.Write([]byte(`{"results":[{"id":"s1","name":"standup","project_id":"w"}],"next_cursor":null}`))
+				return
+			}
+			_, _ = w.Write([]byte(`{"results":[],"next_cursor":null}`))
+		case r.URL.Path == "/sync":
+			_ = r.ParseForm()
+			if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds); err != nil {
+				t.Fatal(err)
+			}
+			status, mapping := map[string]string{}, map[string]string{}
+			for i, cmd := range cmds {
+				status[cmd.UUID] = "ok"
+				if cmd.TempID != "" {
+					mapping[cmd.TempID] = fmt.Sprintf("r%d", i)
+				}
+			}
+			_ = json.NewEncoder(w).Encode(map[string]interface{}{"sync_status": status, "temp_id_mapping": mapping})
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL)
+		}
+	})
+	defer srv.Close()
+
+	projects := append([]models.Project{{ID: "in", Name: "Inbox", IsInboxProject: true}}, meetingProjects...)
+	result, err := c.ImportMarkdown(ParseMarkdown(meetingNotes, projects), "", projects)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SectionsCreated != 2 || result.SectionsReused != 1 {
+		t.Errorf("sections created=%d reused=%d", result.SectionsCreated, result.SectionsReused)
+	}
+
+	var types []string
+	for _, cmd := range cmds {
+		types = append(types, cmd.Type)
+	}
+	wantTypes := []string{"item_add", "item_add", "item_add", "section_add", "item_add", "section_add", "item_add", "item_add", "item_close"}
+	if !reflect.DeepEqual(types, wantTypes) {
+		t.Fatalf("commands = %v", types)
+	}
+	recap, child, renew := cmds[0].Args, cmds[1].Args, cmds[4].Args
+	if recap["project_id"] != "w" || recap["section_id"] != "s1" || recap["priority"] != float64(4) {
+		t.Errorf("recap args = %v", recap)
+	}
+	if recap["due"].(map[string]interface{})["string"] != "tomorrow" {
+		t.Errorf("recap due = %v", recap["due"])
+	}
+	if child["parent_id"] != cmds[0].TempID || child["project_id"] != nil {
+		t.Errorf("child args = %v", child)
+	}
+	if renew["project_id"] != "h" || renew["section_id"] != cmds[3].TempID {
+		t.Errorf("renew args = %v", renew)
+	}
+	if cmds[6].Args["due"].(map[string]interface{})["date"] != "2026-11-02" || cmds[6].Args["project_id"] != "in" {
+		t.Errorf("draft args = %v", cmds[6].Args)
+	}
+	if cmds[8].Args["id"] != cmds[2].TempID {
+		t.Errorf("close args = %v", cmds[8].Args)
+	}
+
+	var lines []string
+	for _, l := range result.Lines {
+		lines = append(lines, fmt.Sprintf("%d:%s", l.Line, l.TaskID))
+	}
+	if want := []string{"3:r0", "5:r1", "6:r2", "7:r4", "12:r6", "13:r7"}; !reflect.DeepEqual(lines, want) {
+		t.Errorf("lines = %v, want %v", lines, want)
+	}
+}
+
+func TestParseMarkdown_unknownProjectIsContent(t *testing.T) {
+	doc := ParseMarkdown("# Triage #Bugs\n- [ ] Fix issue #123 #work", meetingProjects)
+	if len(doc.Tasks) != 1 {
+		t.Fatalf("tasks = %+v", doc.Tasks)
+	}
+	if got := doc.Tasks[0]; got.Content != "Fix issue #123" || got.Project != "work" || got.Section != "Triage #Bugs" {
+		t.Errorf("task = %+v", got)
+	}
+}
+
+func TestImportMarkdown_partialBatch(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"sync_status":     map[string]interface{}{cmds[0].UUID: map[string]interface{}{"error": "Invalid date", "error_code": 22}, cmds[1].UUID: "ok"},
+			"temp_id_mapping": map[string]string{cmds[1].TempID: "t2"},
+		})
+	})
+	defer srv.Close()
+
+	projects := []models.Project{{ID: "in", Name: "Inbox", IsInboxProject: true}}
+	result, err := c.ImportMarkdown(ParseMarkdown("- [ ] One\n- [ ] Two", projects), "", projects)
+	if err == nil || !strings.Contains(err.Error(), "partially applied") {
+		t.Fatalf("err = %v", err)
+	}
+	if len(result.Lines) != 1 || result.Lines[0].Line != 2 || result.Lines[0].TaskID != "t2" {
+		t.Errorf("lines = %+v", result.Lines)
+	}
+}
@@ -0,0 +1,163 @@
+package todoist
+
+import (
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// PlanOptions configures BuildDailyPlan.
+type PlanOptions struct {
+	// Now is the planning time. Zero means time.Now().
+	Now time.Time
+	// CapacityMinutes is how much work fits in the day.
+	CapacityMinutes int
+	// NextLabel selects undated "next action" tasks; empty disables it.
+	NextLabel string
+	// DefaultMinutes estimates tasks without a duration.
+	DefaultMinutes int
+}
+
+// PlanItem is a task selected for the day.
+type PlanItem struct {
+	Task      models.Task
+	Reasons   []string // overdue, today, p1, next
+	Minutes   int
+	Estimated bool      // Minutes is DefaultMinutes, not the task's duration
+	At        time.Time // due time for tasks fixed to a time today
+}
+
+// Fixed reports whether the task is due at a specific time today.
+func (it PlanItem) Fixed() bool { return !it.At.IsZero() }
+
+// DailyPlan is an ordered proposal for the day: tasks fixed to a time in
+// time order, then flexible tasks by priority and urgency.
+type DailyPlan struct {
+	Date          time.Time
+	Fixed         []PlanItem
+	Flexible      []PlanItem
+	TotalMinutes  int
+	Capacity      int
+	Estimated     int // tasks without a duration
+	Overcommitted bool
+	// FitsCapacity is how many flexible tasks fit after the fixed ones.
+	FitsCapacity int
+}
+
+// BuildDailyPlan selects overdue tasks, tasks due today, p1 tasks and
+// tasks labelled NextLabel (the latter two unless due later), estimates
+// their total duration and orders them into a proposed schedule.
+func BuildDailyPlan(tasks []models.Task, opts PlanOptions) *DailyPlan {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	today := startOfDay(now)
+	plan := &DailyPlan{Date: today, Capacity: opts.CapacityMinutes}
+
+	for _, t := range tasks {
+		if t.IsCompleted {
+			continue
+		}
+		due, timed, hasDue := taskDue(&t, now.Location())
+		var reasons []string
+		switch {
+		case hasDue && startOfDay(due).Before(today):
+			reasons = append(reasons, "overdue")
+		case hasDue && startOfDay(due).Equal(today):
+			reasons = append(reasons, "today")
+		}
+		later := hasDue && startOfDay(due).After(today)
+		if t.Priority == 4 && !later {
+			reasons = append(reasons, "p1")
+		}
+		if opts.NextLabel != "" && !later && HasLabel(t.Labels, opts.NextLabel) {
+			reasons = append(reasons, "next")
+		}
+		if len(reasons) == 0 {
+			continue
+		}
+
+		it := PlanItem{Task: t, Reasons: reasons}
+		it.Minutes, it.Estimated = TaskMinutes(t, opts.DefaultMinutes, opts.CapacityMinutes)
+		if it.Estimated {
+			plan.Estimated++
+		}
+		if timed && reasons[0] == "today" {
+			it.At = due
+			plan.Fixed = append(plan.Fixed, it)
+		} else {
+			plan.Flexible = append(plan.Flexible, it)
+		}
+		plan.TotalMinutes += it.Minutes
+	}
+
+	sort.SliceStable(plan.Fixed, func(i, j int) bool { return plan.Fixed[i].At.Before(plan.Fixed[j].At) })
+	sort.SliceStable(plan.Flexible, func(i, j int) bool {
+		a, b := plan.Flexible[i], plan.Flexible[j]
+		if a.Task.Priority != b.Task.Priority {
+			return a.Task.Priority > b.Task.Priority
+		}
+		if ra, rb := planRank(a), planRank(b); ra != rb {
+			return ra < rb
+		}
+		da, _, _ := taskDue(&a.Task, now.Location())
+		db, _, _ := taskDue(&b.Task, now.Location())
+		if !da.Equal(db) {
+			return da.Before(db)
+		}
+		return a.Minutes < b.Minutes
+	})
+
+	used := 0
+	for _, it := range plan.Fixed {
+		used += it.Minutes
+	}
+	for _, it := range plan.Flexible {
+		if used+it.Minutes > plan.Capacity {
+			break
+		}
+		used += it.Minutes
+		plan.FitsCapacity++
+	}
+	plan.Overcommitted = plan.TotalMinutes > plan.Capacity
+	return plan
+}
+
+// planRank orders flexible tasks of equal priority: overdue, due today,
+// then undated.
+func planRank(it PlanItem) int {
+	switch it.Reasons[0] {
+	case "overdue":
+		return 0
+	case "today":
+		return 1
+	}
+	return 2
+}
+
+// TaskMinutes returns a task's duration in minutes, counting a day as
+// dayMinutes, or defaultMinutes (and true) if it has none.
+func TaskMinutes(t models.Task, defaultMinutes, dayMinutes int) (int, bool) {
+	if t.Duration == nil || t.Duration.Amount <= 0 {
+		return defaultMinutes, true
+	}
+	if t.Duration.Unit == "day" {
+		return t.Duration.Amount * dayMinutes, false
+	}
+	return t.Duration.Amount, false
+}
+
+// HasLabel reports whether labels contains want, ignoring case and a
+// leading "@".
+func HasLabel(labels []string, want string) bool {
+	want = strings.TrimPrefix(want, "@")
+	for _, l := range labels {
+		if strings.EqualFold(l, want) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,49 @@
+package todoist
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestBuildDailyPlan(t *testing.T) {
+	now := time.Date(2026, 10, 19, 8, 0, 0, 0, time.UTC)
+	mins := func(n int) *models.Duration { return &models.Duration{Amount: n, Unit: "minute"} }
+	tasks := []models.Task{
+		{ID: "late", Content: "Expense report", Priority: 1, Due: &models.DueDate{Date: "2026-10-15"}, Duration: mins(60)},
+		{ID: "call", Content: "Client call", Priority: 1, Due: &models.DueDate{Date: "2026-10-19", Datetime: "2026-10-19T14:00:00"}, Duration: mins(45)},
+		{ID: "standup", Content: "Standup", Priority: 1, Due: &models.DueDate{Date: "2026-10-19", Datetime: "2026-10-19T09:00:00Z"}, Duration: mins(15)},
+		{ID: "today", Content: "Review PR", Priority: 3, Due: &models.DueDate{Date: "2026-10-19"}, Duration: mins(90)},
+		{ID: "p1", Content: "Fix outage", Priority: 4},
+		{ID: "p1later", Content: "Launch", Priority: 4, Due: &models.DueDate{Date: "2026-10-25"}},
+		{ID: "next", Content: "Email Sam", Priority: 1, Labels: []string{"Next"}, Duration: &models.Duration{Amount: 1, Unit: "day"}},
+		{ID: "other", Content: "Someday", Priority: 1},
+	}
+	plan := BuildDailyPlan(tasks, PlanOptions{Now: now, CapacityMinutes: 240, NextLabel: "next", DefaultMinutes: 30})
+
+	var fixed, flexible []string
+	for _, it := range plan.Fixed {
+		fixed = append(fixed, it.Task.ID+"@"+it.At.Format("15:04"))
+	}
+	for _, it := range plan.Flexible {
+		flexible = append(flexible, it.Task.ID)
+	}
+	if want := "standup@09:00,call@14:00"; strings.Join(fixed, ",") != want {
+		t.Errorf("fixed = %v, want %s", fixed, want)
+	}
+	if want := "p1,today,late,next"; strings.Join(flexible, ",") != want {
+		t.Errorf("flexible = %v, want %s", flexible, want)
+	}
+	if plan.TotalMinutes != 15+45+30+90+60+240 || plan.Estimated != 1 || !plan.Overcommitted {
+		t.Errorf("total=%d estimated=%d overcommitted=%v", plan.TotalMinutes, plan.Estimated, plan.Overcommitted)
+	}
+	// 60 fixed + 30 + 90 + 60 = 240 fits; the day-long task does not.
+	if plan.FitsCapacity != 3 {
+		t.Errorf("fits = %d, want 3", plan.FitsCapacity)
+	}
+	if r := plan.Flexible[0].Reasons; len(r) != 1 || r[0] != "p1" {
+		t.Errorf("reasons = %v", r)
+	}
+}
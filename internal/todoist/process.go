@@ -0,0 +1,189 @@
+package todoist
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// InboxActions are the GTD decisions ProcessInboxItem can apply.
+var InboxActions = []string{"do", "delegate", "defer", "file", "trash", "project"}
+
+// InboxDecision is what to do with an inbox item.
+type InboxDecision struct {
+	Action string // one of InboxActions
+
+	// Filing: where the task goes and labels to add. Used by file, and
+	// optionally by delegate and defer.
+	ProjectID string
+	SectionID string
+	Labels    []string
+
+	AssigneeID string // delegate: the user to assign the task to
+	DueString  string // defer: required; file and delegate: optional
+	Comment    string // added to the task (or the new project)
+
+	// Converting to a project: the project's name (default the task's
+	// content) and an optional first next action.
+	NewProjectName string
+	NextAction     string
+}
+
+// Validate checks that the decision has what its action needs.
+func (d InboxDecision) Validate() error {
+	switch d.Action {
+	case "do", "trash":
+	case "delegate":
+		if d.AssigneeID == "" {
+			return fmt.Errorf("delegate needs an assignee")
+		}
+	case "defer":
+		if d.DueString == "" {
+			return fmt.Errorf("defer needs a due_string")
+		}
+	case "file":
+		if d.ProjectID == "" && d.SectionID == "" {
+			return fmt.Errorf("file needs a project or section")
+		}
+	case "project":
+	default:
+		return fmt.Errorf("unknown action %q (use %s)", d.Action, strings.Join(InboxActions, ", "))
+	}
+	return nil
+}
+
+// InboxResult describes a processed inbox item.
+type InboxResult struct {
+	Commands  int    // Sync API commands sent
+	ProjectID string // the project created by the project action
+	NextID    string // its first next action, if any
+	Moved     int    // subtasks moved into the new project
+	// Applied describes the steps that took effect, in order. When
+	// ProcessInboxItem fails partway it lists what was done anyway.
+	Applied []string
+}
+
+// ProcessInboxItem applies a decision to a task through the Sync API.
+// Delegating adds cfg.WaitingLabel. Converting to a project creates it,
+// moves the task's subtasks into it as top-level tasks and then the task
+// itself as its first action, keeping its description, labels, due date
+// and comments, and adds the next action if given. The Sync API applies
+// commands independently, so on error the returned result lists the
+// steps that still took effect.
+func (c *Client) ProcessInboxItem(task models.Task, d InboxDecision, cfg GTDConfig) (*InboxResult, error) {
+	if err := d.Validate(); err != nil {
+		return nil, err
+	}
+	var cmds []syncCommand
+	var steps []string
+	add := func(step string, cmd syncCommand) {
+		cmds = append(cmds, cmd)
+		steps = append(steps, step)
+	}
+	var projectTemp, nextTemp string
+	subtaskMoves := map[int]bool{}
+	if d.Comment != "" && d.Action != "trash" && d.Action != "project" {
+		add("added the comment", newSyncCommand("note_add", map[string]interface{}{"item_id": task.ID, "content": d.Comment}, true))
+	}
+
+	switch d.Action {
+	case "do":
+		add("completed the task", newSyncCommand("item_close", map[string]interface{}{"id": task.ID}, false))
+
+	case "trash":
+		add("deleted the task", newSyncCommand("item_delete", map[string]interface{}{"id": task.ID}, false))
+
+	case "delegate", "defer", "file":
+		switch {
+		case d.SectionID != "":
+			add("moved the task to section "+d.SectionID, newSyncCommand("item_move", map[string]interface{}{"id": task.ID, "section_id": d.SectionID}, false))
+		case d.ProjectID != "" && d.ProjectID != task.ProjectID:
+			add("moved the task to project "+d.ProjectID, newSyncCommand("item_move", map[string]interface{}{"id": task.ID, "project_id": d.ProjectID}, false))
+		}
+		update := map[string]interface{}{"id": task.ID}
+		labels := d.Labels
+		if d.Action == "delegate" {
+			update["responsible_uid"] = d.AssigneeID
+			if cfg.WaitingLabel != "" {
+				labels = append(append([]string{}, labels...), cfg.WaitingLabel)
+			}
+		}
+		if merged := mergeLabels(task.Labels, labels); len(merged) != len(task.Labels) {
+			update["labels"] = merged
+		}
+		if d.DueString != "" {
+			update["due"] = map[string]interface{}{"string": d.DueString}
+		}
+		if len(update) > 1 {
+			add("updated the task", newSyncCommand("item_update", update, false))
+		}
+
+	case "project":
+		tasks, err := c.GetTasks(task.ProjectID, "")
+		if err != nil {
+			return nil, err
+		}
+		name := d.NewProjectName
+		if name == "" {
+			name = task.Content
+		}
+		project := newSyncCommand("project_add", map[string]interface{}{"name": name}, true)
+		add("created project "+name, project)
+		for _, t := range tasks {
+			if t.ParentID == task.ID {
+				subtaskMoves[len(cmds)] = true
+				add("moved subtask "+t.Content, newSyncCommand("item_move", map[string]interface{}{"id": t.ID, "project_id": project.TempID}, false))
+			}
+		}
+		// Moved after its subtasks, which would otherwise go along as its
+		// children.
+		add("moved the task into the project", newSyncCommand("item_move", map[string]interface{}{"id": task.ID, "project_id": project.TempID}, false))
+		var next syncCommand
+		if d.NextAction != "" {
+			next = newSyncCommand("item_add", map[string]interface{}{"content": d.NextAction, "project_id": project.TempID}, true)
+			add("added the next action", next)
+		}
+		if d.Comment != "" {
+			add("added the comment to the project", newSyncCommand("note_add", map[string]interface{}{"project_id": project.TempID, "content": d.Comment}, true))
+		}
+		projectTemp, nextTemp = project.TempID, next.TempID
+	}
+
+	result := &InboxResult{}
+	if len(cmds) == 0 {
+		return result, nil
+	}
+	applied, mapping, err := c.syncWriteEach(cmds...)
+	result.Commands = len(cmds)
+	result.ProjectID = mapping[projectTemp]
+	result.NextID = mapping[nextTemp]
+	for i, ok := range applied {
+		if !ok {
+			continue
+		}
+		result.Applied = append(result.Applied, steps[i])
+		if subtaskMoves[i] {
+			result.Moved++
+		}
+	}
+	if err != nil {
+		if len(result.Applied) == 0 {
+			return nil, err
+		}
+		return result, err
+	}
+	return result, nil
+}
+
+// mergeLabels appends the labels not already present, ignoring case.
+func mergeLabels(labels, add []string) []string {
+	out := append([]string{}, labels...)
+	for _, l := range add {
+		l = strings.TrimPrefix(l, "@")
+		if l != "" && !HasLabel(out, l) {
+			out = append(out, l)
+		}
+	}
+	return out
+}
@@ -0,0 +1,122 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// syncRecorder answers Sync API writes with "ok", mapping temp IDs to
+// "new-<n>", and serves tasks for reads.
+func syncRecorder(t *testing.T, tasks string, got *[]syncCommand) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == http.MethodGet {
+			_, _ = w.Write([]byte(tasks))
+			return
+		}
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds); err != nil {
+			t.Fatal(err)
+		}
+		*got = append(*got, cmds...)
+		var status, temps []string
+		for i, cmd := range cmds {
+			status = append(status, fmt.Sprintf("%q:\"ok\"", cmd.UUID))
+			if cmd.TempID != "" {
+				temps = append(temps, fmt.Sprintf("%q:\"new-%d\"", cmd.TempID, i))
+			}
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%s},"temp_id_mapping":{%s}}`, strings.Join(status, ","), strings.Join(temps, ","))
+	}
+}
+
+func TestProcessInboxItem_delegate(t *testing.T) {
+	var got []syncCommand
+	c, srv := testServer(t, syncRecorder(t, "", &got))
+	defer srv.Close()
+
+	task := models.Task{ID: "9", Content: "Book venue", ProjectID: "inbox", Labels: []string{"Events"}}
+	d := InboxDecision{Action: "delegate", ProjectID: "team", AssigneeID: "u2", Labels: []string{"@events", "q4"}, Comment: "Over to you"}
+	if _, err := c.ProcessInboxItem(task, d, GTDConfig{WaitingLabel: "waiting"}); err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, cmd := range got {
+		types = append(types, cmd.Type)
+	}
+	if want := "note_add,item_move,item_update"; strings.Join(types, ",") != want {
+		t.Fatalf("commands = %v, want %s", types, want)
+	}
+	if got[1].Args["project_id"] != "team" {
+		t.Errorf("move = %v", got[1].Args)
+	}
+	update := got[2].Args
+	if update["responsible_uid"] != "u2" || fmt.Sprint(update["labels"]) != "[Events q4 waiting]" {
+		t.Errorf("update = %v", update)
+	}
+}
+
+func TestProcessInboxItem_project(t *testing.T) {
+	var got []syncCommand
+	tasks := `{"results":[{"id":"9","content":"Plan offsite"},{"id":"10","content":"Pick dates","parent_id":"9"},{"id":"11","content":"Other"}],"next_cursor":""}`
+	c, srv := testServer(t, syncRecorder(t, tasks, &got))
+	defer srv.Close()
+
+	task := models.Task{ID: "9", Content: "Plan offsite", ProjectID: "inbox"}
+	result, err := c.ProcessInboxItem(task, InboxDecision{Action: "project", NextAction: "Email the team"}, GTDConfig{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var types []string
+	for _, cmd := range got {
+		types = append(types, cmd.Type)
+	}
+	if want := "project_add,item_move,item_move,item_add"; strings.Join(types, ",") != want {
+		t.Fatalf("commands = %v, want %s", types, want)
+	}
+	project := got[0].TempID
+	if got[0].Args["name"] != "Plan offsite" || got[1].Args["id"] != "10" || got[1].Args["project_id"] != project ||
+		got[2].Args["id"] != "9" || got[2].Args["project_id"] != project || got[3].Args["project_id"] != project {
+		t.Errorf("commands = %+v", got)
+	}
+	if result.ProjectID != "new-0" || result.NextID != "new-3" || result.Moved != 1 || result.Commands != 4 {
+		t.Errorf("result = %+v", result)
+	}
+
+	if _, err := c.ProcessInboxItem(models.Task{ID: "9"}, InboxDecision{Action: "defer"}, GTDConfig{}); err == nil {
+		t.Error("expected error for defer without a due date")
+	}
+}
+
+func TestProcessInboxItem_partial(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		var status []string
+		for _, cmd := range cmds {
+			if cmd.Type == "item_update" {
+				status = append(status, fmt.Sprintf("%q:{\"error\":\"Not a collaborator\",\"error_code\":41}", cmd.UUID))
+				continue
+			}
+			status = append(status, fmt.Sprintf("%q:\"ok\"", cmd.UUID))
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%s}}`, strings.Join(status, ","))
+	})
+	defer srv.Close()
+
+	task := models.Task{ID: "9", Content: "Book venue", ProjectID: "inbox"}
+	d := InboxDecision{Action: "delegate", ProjectID: "team", AssigneeID: "u2", Comment: "Over to you"}
+	result, err := c.ProcessInboxItem(task, d, GTDConfig{WaitingLabel: "waiting"})
+	if err == nil || !strings.Contains(err.Error(), "Not a collaborator") {
+		t.Fatalf("err = %v", err)
+	}
+	if result == nil || strings.Join(result.Applied, ",") != "added the comment,moved the task to project team" {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -9,16 +9,7 @@ import (
 
 // GetProjects returns all projects.
 func (c *Client) GetProjects() ([]models.Project, error) {
-	data, err := c.do("GET", "/projects", nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var page PaginatedResponse[models.Project]
-	if err := json.Unmarshal(data, &page); err != nil {
-		return nil, fmt.Errorf("failed to parse projects: %w", err)
-	}
-	return page.Results, nil
+	return getAll[models.Project](c, "/projects", nil, "projects")
 }
 
 // GetProject returns a single project by ID.
@@ -80,3 +71,26 @@ func (c *Client) UnarchiveProject(id string) error {
 	_, err := c.do("POST", "/projects/"+id+"/unarchive", nil)
 	return err
 }
+
+// FindProjectByName returns the active project whose name matches,
+// ignoring case, spaces, dashes and underscores. Returns nil if none or
+// several match.
+func (c *Client) FindProjectByName(name string) (*models.Project, error) {
+	projects, err := c.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	var active []models.Project
+	for _, p := range projects {
+		if !p.IsArchived && !p.IsDeleted {
+			active = append(active, p)
+		}
+	}
+	id := matchProjectName(active, name)
+	for i := range active {
+		if active[i].ID == id {
+			return &active[i], nil
+		}
+	}
+	return nil, nil
+}
@@ -0,0 +1,70 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// GetReminders returns active reminders, optionally only those for one task.
+func (c *Client) GetReminders(taskID string) ([]models.Reminder, error) {
+	resp, err := c.syncRead("reminders")
+	if err != nil {
+		return nil, err
+	}
+
+	var all []models.Reminder
+	if raw, ok := resp["reminders"]; ok {
+		if err := json.Unmarshal(raw, &all); err != nil {
+			return nil, fmt.Errorf("failed to parse reminders: %w", err)
+		}
+	}
+
+	var reminders []models.Reminder
+	for _, r := range all {
+		if r.IsDeleted || (taskID != "" && r.TaskID != taskID) {
+			continue
+		}
+		reminders = append(reminders, r)
+	}
+	return reminders, nil
+}
+
+// CreateReminder creates a reminder. The body uses Sync API field names,
+// e.g. item_id, type, minute_offset, due.
+func (c *Client) CreateReminder(body map[string]interface{}) (*models.Reminder, error) {
+	cmd := newSyncCommand("reminder_add", body, true)
+	mapping, err := c.syncWrite(cmd)
+	if err != nil {
+		return nil, err
+	}
+
+	// The Sync API only returns the new ID, so echo the request back.
+	data, err := json.Marshal(body)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal reminder: %w", err)
+	}
+	var reminder models.Reminder
+	if err := json.Unmarshal(data, &reminder); err != nil {
+		return nil, fmt.Errorf("failed to parse reminder: %w", err)
+	}
+	reminder.ID = mapping[cmd.TempID]
+	return &reminder, nil
+}
+
+// UpdateReminder updates an existing reminder.
+func (c *Client) UpdateReminder(id string, body map[string]interface{}) error {
+	args := map[string]interface{}{"id": id}
+	for k, v := range body {
+		args[k] = v
+	}
+	_, err := c.syncWrite(newSyncCommand("reminder_update", args, false))
+	return err
+}
+
+// DeleteReminder deletes a reminder.
+func (c *Client) DeleteReminder(id string) error {
+	_, err := c.syncWrite(newSyncCommand("reminder_delete", map[string]interface{}{"id": id}, false))
+	return err
+}
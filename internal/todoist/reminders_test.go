@@ -0,0 +1,83 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestGetReminders(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/sync" {
+			t.Errorf("method=%s path=%s", r.Method, r.URL.Path)
+		}
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.PostForm.Get("resource_types"); got != `["reminders"]` {
+			t.Errorf("resource_types = %q", got)
+		}
+		_, _ = w.Write([]byte(`{"reminders":[
+			{"id":"r1","item_id":"42","type":"relative","minute_offset":30},
+			{"id":"r2","item_id":"7","type":"absolute","due":{"date":"2026-10-20T09:00:00"}},
+			{"id":"r3","item_id":"42","type":"relative","minute_offset":10,"is_deleted":true}
+		]}`))
+	})
+	defer srv.Close()
+
+	reminders, err := c.GetReminders("42")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(reminders) != 1 || reminders[0].ID != "r1" || reminders[0].MinuteOffset != 30 {
+		t.Errorf("unexpected reminders: %+v", reminders)
+	}
+}
+
+func TestCreateReminder(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if err := r.ParseForm(); err != nil {
+			t.Fatal(err)
+		}
+		var cmds []syncCommand
+		if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds); err != nil {
+			t.Fatal(err)
+		}
+		if len(cmds) != 1 || cmds[0].Type != "reminder_add" || cmds[0].TempID == "" {
+			t.Fatalf("unexpected commands: %+v", cmds)
+		}
+		if cmds[0].Args["item_id"] != "42" {
+			t.Errorf("item_id = %v", cmds[0].Args["item_id"])
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"},"temp_id_mapping":{%q:"r9"}}`, cmds[0].UUID, cmds[0].TempID)
+	})
+	defer srv.Close()
+
+	r, err := c.CreateReminder(map[string]interface{}{"item_id": "42", "type": "relative", "minute_offset": 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if r.ID != "r9" || r.TaskID != "42" || r.MinuteOffset != 30 {
+		t.Errorf("unexpected reminder: %+v", r)
+	}
+}
+
+func TestDeleteReminder_commandError(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:{"error_code":22,"error":"Reminder not found"}}}`, cmds[0].UUID)
+	})
+	defer srv.Close()
+
+	err := c.DeleteReminder("missing")
+	if err == nil || !strings.Contains(err.Error(), "Reminder not found") {
+		t.Errorf("err = %v", err)
+	}
+}
@@ -0,0 +1,198 @@
+package todoist
+
+import (
+	"sort"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// RescheduleOptions configures PlanReschedule.
+type RescheduleOptions struct {
+	// Now is the current time. Zero means time.Now().
+	Now time.Time
+	// Days is how many days, starting today, tasks are spread over.
+	Days int
+	// PerDay caps the tasks due on a day, counting tasks already due
+	// then. Zero means no cap.
+	PerDay int
+	// SkipWeekends leaves Saturdays and Sundays out of the spread.
+	SkipWeekends bool
+}
+
+// RescheduleMove is a due date change. Applying it with From and To
+// swapped undoes it.
+type RescheduleMove struct {
+	TaskID    string         `json:"task_id"`
+	Content   string         `json:"content"`
+	Recurring bool           `json:"recurring"`
+	From      models.DueDate `json:"from"`
+	To        models.DueDate `json:"to"`
+}
+
+// Undo returns the move that reverses m.
+func (m RescheduleMove) Undo() RescheduleMove {
+	m.From, m.To = m.To, m.From
+	return m
+}
+
+// ReschedulePlan is the outcome of PlanReschedule.
+type ReschedulePlan struct {
+	Days     []time.Time
+	Load     map[string]int // tasks due per day (YYYY-MM-DD) after the moves
+	Moves    []RescheduleMove
+	Unplaced []models.Task // overdue tasks for which every day was full
+}
+
+// PlanReschedule spreads overdue tasks over the coming days. Tasks are
+// taken by priority, then oldest first, and each goes to the least
+// loaded day under the cap, the earliest on ties. Timed tasks keep their
+// time of day and recurring tasks keep their recurrence string, so only
+// the next occurrence moves.
+func PlanReschedule(tasks []models.Task, opts RescheduleOptions) *ReschedulePlan {
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+	loc := now.Location()
+	today := startOfDay(now)
+
+	plan := &ReschedulePlan{Load: map[string]int{}}
+	for d := today; len(plan.Days) < max(1, opts.Days); d = d.AddDate(0, 0, 1) {
+		if opts.SkipWeekends && (d.Weekday() == time.Saturday || d.Weekday() == time.Sunday) {
+			continue
+		}
+		plan.Days = append(plan.Days, d)
+	}
+
+	var overdue []models.Task
+	for _, t := range tasks {
+		if t.IsCompleted {
+			continue
+		}
+		due, _, ok := taskDue(&t, loc)
+		if !ok {
+			continue
+		}
+		if day := startOfDay(due); day.Before(today) {
+			overdue = append(overdue, t)
+		} else {
+			plan.Load[day.Format("2006-01-02")]++
+		}
+	}
+	sort.SliceStable(overdue, func(i, j int) bool {
+		if overdue[i].Priority != overdue[j].Priority {
+			return overdue[i].Priority > overdue[j].Priority
+		}
+		di, _, _ := taskDue(&overdue[i], loc)
+		dj, _, _ := taskDue(&overdue[j], loc)
+		return di.Before(dj)
+	})
+
+	for _, t := range overdue {
+		var best time.Time
+		for _, d := range plan.Days {
+			n := plan.Load[d.Format("2006-01-02")]
+			if opts.PerDay > 0 && n >= opts.PerDay {
+				continue
+			}
+			if best.IsZero() || n < plan.Load[best.Format("2006-01-02")] {
+				best = d
+			}
+		}
+		if best.IsZero() {
+			plan.Unplaced = append(plan.Unplaced, t)
+			continue
+		}
+		plan.Load[best.Format("2006-01-02")]++
+		plan.Moves = append(plan.Moves, RescheduleMove{
+			TaskID:    t.ID,
+			Content:   t.Content,
+			Recurring: t.Due.Recurring,
+			From:      *t.Due,
+			To:        movedDue(*t.Due, best, loc),
+		})
+	}
+	return plan
+}
+
+// movedDue returns due moved to day, keeping the time of day, time zone
+// and recurrence.
+func movedDue(due models.DueDate, day time.Time, loc *time.Location) models.DueDate {
+	moved := models.DueDate{
+		Date:      day.Format("2006-01-02"),
+		String:    due.String,
+		Recurring: due.Recurring,
+		Timezone:  due.Timezone,
+		Lang:      due.Lang,
+	}
+	if !due.Recurring {
+		// The old phrase ("yesterday", "Friday") no longer describes the
+		// date.
+		moved.String = ""
+	}
+	if due.Datetime == "" {
+		return moved
+	}
+	if at, err := time.Parse(time.RFC3339, due.Datetime); err == nil {
+		if due.Timezone != "" {
+			if tz, err := time.LoadLocation(due.Timezone); err == nil {
+				loc = tz
+			}
+		}
+		at = at.In(loc)
+		moved.Datetime = time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), at.Second(), 0, loc).UTC().Format(time.RFC3339)
+	} else if at, err := time.Parse("2006-01-02T15:04:05", due.Datetime); err == nil {
+		moved.Datetime = time.Date(day.Year(), day.Month(), day.Day(), at.Hour(), at.Minute(), at.Second(), 0, time.UTC).Format("2006-01-02T15:04:05")
+	}
+	return moved
+}
+
+// syncDue converts a due date to the Sync API's due object.
+func syncDue(due models.DueDate) map[string]interface{} {
+	obj := map[string]interface{}{"date": due.Date}
+	if due.Datetime != "" {
+		obj["date"] = due.Datetime
+	}
+	if due.Timezone != "" {
+		obj["timezone"] = due.Timezone
+	}
+	if due.String != "" {
+		obj["string"] = due.String
+	}
+	if due.Lang != "" {
+		obj["lang"] = due.Lang
+	}
+	obj["is_recurring"] = due.Recurring
+	return obj
+}
+
+// ApplyReschedule sets each move's To due date in Sync API batches and
+// returns the moves that were applied. Sending the date with the
+// recurrence string moves a recurring task's next occurrence without
+// changing its pattern. On error, the applied moves are those of earlier
+// batches and those the failing batch still carried out, so they can be
+// undone.
+func (c *Client) ApplyReschedule(moves []RescheduleMove) ([]RescheduleMove, error) {
+	var done []RescheduleMove
+	for start := 0; start < len(moves); start += syncBatchSize {
+		chunk := moves[start:min(start+syncBatchSize, len(moves))]
+		var batch []syncCommand
+		for _, m := range chunk {
+			batch = append(batch, newSyncCommand("item_update", map[string]interface{}{
+				"id":  m.TaskID,
+				"due": syncDue(m.To),
+			}, false))
+		}
+		applied, _, err := c.syncWriteEach(batch...)
+		for i, ok := range applied {
+			if ok {
+				done = append(done, chunk[i])
+			}
+		}
+		if err != nil {
+			return done, err
+		}
+	}
+	return done, nil
+}
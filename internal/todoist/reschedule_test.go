@@ -0,0 +1,127 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestPlanReschedule(t *testing.T) {
+	now := time.Date(2026, 10, 21, 8, 0, 0, 0, time.UTC) // a Wednesday
+	due := func(date string) *models.DueDate { return &models.DueDate{Date: date} }
+	tasks := []models.Task{
+		{ID: "a", Content: "Stretch", Priority: 4, Due: &models.DueDate{Date: "2026-10-19", String: "every day", Recurring: true}},
+		{ID: "b", Content: "Call dentist", Priority: 1, Due: &models.DueDate{Date: "2026-10-01", Datetime: "2026-10-01T14:30:00Z", String: "oct 1 at 14:30"}},
+		{ID: "c", Content: "File receipts", Priority: 1, Due: due("2026-10-15")},
+		{ID: "d", Content: "Clean inbox", Priority: 1, Due: due("2026-10-18")},
+		{ID: "e", Content: "Back up photos", Priority: 1, Due: due("2026-10-20")},
+		{ID: "f", Content: "Renew library card", Priority: 1, Due: due("2026-10-20")},
+		{ID: "x", Content: "Existing", Due: due("2026-10-21")},
+		{ID: "y", Content: "Existing", Due: due("2026-10-22")},
+		{ID: "z", Content: "Existing", Due: due("2026-10-22")},
+		{ID: "u", Content: "Undated"},
+	}
+	plan := PlanReschedule(tasks, RescheduleOptions{Now: now, Days: 4, PerDay: 2, SkipWeekends: true})
+
+	var days []string
+	for _, d := range plan.Days {
+		days = append(days, d.Format("Mon 2"))
+	}
+	if want := "Wed 21,Thu 22,Fri 23,Mon 26"; strings.Join(days, ",") != want {
+		t.Errorf("days = %v, want %s", days, want)
+	}
+	var moves []string
+	for _, m := range plan.Moves {
+		moves = append(moves, m.TaskID+"->"+m.To.Date)
+	}
+	if want := "a->2026-10-23,b->2026-10-26,c->2026-10-21,d->2026-10-23,e->2026-10-26"; strings.Join(moves, ",") != want {
+		t.Errorf("moves = %v, want %s", moves, want)
+	}
+	if len(plan.Unplaced) != 1 || plan.Unplaced[0].ID != "f" {
+		t.Errorf("unplaced = %+v", plan.Unplaced)
+	}
+
+	a, b := plan.Moves[0], plan.Moves[1]
+	if !a.Recurring || a.To.String != "every day" || !a.To.Recurring {
+		t.Errorf("recurring move lost its pattern: %+v", a.To)
+	}
+	if b.To.Datetime != "2026-10-26T14:30:00Z" || b.To.String != "" {
+		t.Errorf("timed move = %+v", b.To)
+	}
+	if u := b.Undo(); u.To != b.From || u.From != b.To {
+		t.Errorf("undo = %+v", u)
+	}
+}
+
+func TestApplyReschedule(t *testing.T) {
+	var got []syncCommand
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &got); err != nil {
+			t.Fatal(err)
+		}
+		var status []string
+		for _, cmd := range got {
+			status = append(status, fmt.Sprintf("%q:\"ok\"", cmd.UUID))
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%s},"temp_id_mapping":{}}`, strings.Join(status, ","))
+	})
+	defer srv.Close()
+
+	applied, err := c.ApplyReschedule([]RescheduleMove{
+		{TaskID: "1", To: models.DueDate{Date: "2026-10-23", String: "every day", Recurring: true}},
+		{TaskID: "2", To: models.DueDate{Date: "2026-10-26", Datetime: "2026-10-26T14:30:00Z", Timezone: "Europe/Berlin"}},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(applied) != 2 {
+		t.Errorf("applied = %+v", applied)
+	}
+	if len(got) != 2 || got[0].Type != "item_update" || got[0].Args["id"] != "1" {
+		t.Fatalf("commands = %+v", got)
+	}
+	if d := got[0].Args["due"].(map[string]interface{}); d["date"] != "2026-10-23" || d["string"] != "every day" || d["is_recurring"] != true {
+		t.Errorf("recurring due = %v", d)
+	}
+	if d := got[1].Args["due"].(map[string]interface{}); d["date"] != "2026-10-26T14:30:00Z" || d["timezone"] != "Europe/Berlin" || d["string"] != nil {
+		t.Errorf("timed due = %v", d)
+	}
+}
+
+func TestApplyReschedule_partial(t *testing.T) {
+	var requests int
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		var status []string
+		for _, cmd := range cmds {
+			if cmd.Args["id"] == "2" {
+				status = append(status, fmt.Sprintf("%q:{\"error\":\"Item not found\",\"error_code\":22}", cmd.UUID))
+				continue
+			}
+			status = append(status, fmt.Sprintf("%q:\"ok\"", cmd.UUID))
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%s}}`, strings.Join(status, ","))
+	})
+	defer srv.Close()
+
+	var moves []RescheduleMove
+	for i := 1; i <= syncBatchSize+1; i++ {
+		moves = append(moves, RescheduleMove{TaskID: fmt.Sprint(i), To: models.DueDate{Date: "2026-10-23"}})
+	}
+	applied, err := c.ApplyReschedule(moves)
+	if err == nil || !strings.Contains(err.Error(), "Item not found") {
+		t.Fatalf("err = %v", err)
+	}
+	if requests != 1 || len(applied) != syncBatchSize-1 || applied[0].TaskID != "1" || applied[1].TaskID != "3" {
+		t.Errorf("requests = %d, applied %d moves starting %+v", requests, len(applied), applied[:min(2, len(applied))])
+	}
+}
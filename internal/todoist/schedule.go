@@ -0,0 +1,146 @@
+package todoist
+
+import (
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// scheduleStep is the granularity of start times placed after Now.
+const scheduleStep = 15 * time.Minute
+
+// ScheduleOptions configures ScheduleDay.
+type ScheduleOptions struct {
+	// Start and End bound the working hours; both must fall on the day
+	// being scheduled.
+	Start, End time.Time
+	// Now keeps blocks out of the past when scheduling today. Zero means
+	// time.Now().
+	Now time.Time
+	// NextLabel and DefaultMinutes select and estimate tasks as in
+	// BuildDailyPlan.
+	NextLabel      string
+	DefaultMinutes int
+}
+
+// ScheduleBlock is a task placed at a start time.
+type ScheduleBlock struct {
+	Item       PlanItem
+	Start, End time.Time
+}
+
+// Schedule is a time-blocked day: the timed tasks already on the
+// calendar, the blocks assigned to untimed tasks, and the tasks that did
+// not fit in the working hours.
+type Schedule struct {
+	Start, End  time.Time
+	Busy        []ScheduleBlock
+	Blocks      []ScheduleBlock
+	Unscheduled []PlanItem
+	FreeMinutes int // left in the window after all blocks
+}
+
+// ScheduleDay assigns start times to the day's untimed tasks. Tasks are
+// selected and ordered as in BuildDailyPlan (by priority, then urgency,
+// then shorter first); each is placed in the earliest gap of the working
+// hours that it fits, so shorter tasks can fill gaps between timed tasks
+// that a longer, more important task skipped.
+func ScheduleDay(tasks []models.Task, opts ScheduleOptions) (*Schedule, error) {
+	if !opts.End.After(opts.Start) {
+		return nil, fmt.Errorf("working hours end (%s) must be after start (%s)", opts.End.Format("15:04"), opts.Start.Format("15:04"))
+	}
+	if !startOfDay(opts.Start).Equal(startOfDay(opts.End)) {
+		return nil, fmt.Errorf("working hours must start and end on the same day")
+	}
+	now := opts.Now
+	if now.IsZero() {
+		now = time.Now()
+	}
+
+	window := int(opts.End.Sub(opts.Start) / time.Minute)
+	plan := BuildDailyPlan(tasks, PlanOptions{
+		Now:             opts.Start,
+		CapacityMinutes: window,
+		NextLabel:       opts.NextLabel,
+		DefaultMinutes:  opts.DefaultMinutes,
+	})
+	s := &Schedule{Start: opts.Start, End: opts.End}
+	for _, it := range plan.Fixed {
+		s.Busy = append(s.Busy, ScheduleBlock{Item: it, Start: it.At, End: it.At.Add(time.Duration(it.Minutes) * time.Minute)})
+	}
+
+	from := opts.Start
+	if now.After(from) {
+		from = now.Truncate(scheduleStep)
+		if from.Before(now) {
+			from = from.Add(scheduleStep)
+		}
+	}
+	for _, it := range plan.Flexible {
+		d := time.Duration(it.Minutes) * time.Minute
+		at, ok := s.firstFit(from, d)
+		if !ok {
+			s.Unscheduled = append(s.Unscheduled, it)
+			continue
+		}
+		s.Blocks = append(s.Blocks, ScheduleBlock{Item: it, Start: at, End: at.Add(d)})
+	}
+	sort.SliceStable(s.Blocks, func(i, j int) bool { return s.Blocks[i].Start.Before(s.Blocks[j].Start) })
+
+	s.FreeMinutes = s.freeMinutes(from)
+	return s, nil
+}
+
+// firstFit returns the earliest start at or after from where a block of
+// length d fits before End without overlapping a busy or placed block.
+func (s *Schedule) firstFit(from time.Time, d time.Duration) (time.Time, bool) {
+	at := from
+	for _, b := range s.taken() {
+		if !b.End.After(at) {
+			continue
+		}
+		if !b.Start.Before(at.Add(d)) {
+			break
+		}
+		at = b.End
+	}
+	if at.Add(d).After(s.End) {
+		return time.Time{}, false
+	}
+	return at, true
+}
+
+// freeMinutes counts the minutes between from and End not covered by a
+// busy or placed block.
+func (s *Schedule) freeMinutes(from time.Time) int {
+	var free time.Duration
+	at := from
+	for _, b := range s.taken() {
+		if !at.Before(s.End) {
+			break
+		}
+		if b.Start.After(at) {
+			end := b.Start
+			if end.After(s.End) {
+				end = s.End
+			}
+			free += end.Sub(at)
+		}
+		if b.End.After(at) {
+			at = b.End
+		}
+	}
+	if at.Before(s.End) {
+		free += s.End.Sub(at)
+	}
+	return int(free / time.Minute)
+}
+
+// taken returns the busy and placed blocks in start order.
+func (s *Schedule) taken() []ScheduleBlock {
+	taken := append(append([]ScheduleBlock{}, s.Busy...), s.Blocks...)
+	sort.Slice(taken, func(i, j int) bool { return taken[i].Start.Before(taken[j].Start) })
+	return taken
+}
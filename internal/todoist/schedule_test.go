@@ -0,0 +1,58 @@
+package todoist
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestScheduleDay(t *testing.T) {
+	at := func(h, m int) time.Time { return time.Date(2026, 10, 19, h, m, 0, 0, time.UTC) }
+	mins := func(n int) *models.Duration { return &models.Duration{Amount: n, Unit: "minute"} }
+	tasks := []models.Task{
+		{ID: "standup", Content: "Standup", Priority: 1, Due: &models.DueDate{Date: "2026-10-19", Datetime: "2026-10-19T09:00:00Z"}, Duration: mins(15)},
+		{ID: "call", Content: "Client call", Priority: 1, Due: &models.DueDate{Date: "2026-10-19", Datetime: "2026-10-19T10:00:00Z"}, Duration: mins(60)},
+		{ID: "p1", Content: "Fix outage", Priority: 4},
+		{ID: "review", Content: "Review PR", Priority: 3, Due: &models.DueDate{Date: "2026-10-19"}, Duration: mins(90)},
+		{ID: "late", Content: "Expense report", Priority: 1, Due: &models.DueDate{Date: "2026-10-15"}, Duration: mins(60)},
+		{ID: "tiny", Content: "Reply to Ana", Priority: 1, Due: &models.DueDate{Date: "2026-10-19"}, Duration: mins(15)},
+	}
+	blocks := func(s *Schedule) string {
+		var out []string
+		for _, b := range s.Blocks {
+			out = append(out, b.Item.Task.ID+"@"+b.Start.Format("15:04")+"-"+b.End.Format("15:04"))
+		}
+		return strings.Join(out, ",")
+	}
+
+	s, err := ScheduleDay(tasks, ScheduleOptions{Start: at(9, 0), End: at(13, 0), Now: at(8, 0), DefaultMinutes: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The 90-minute review skips the 15-minute gap before the call, which
+	// the short task fills; the overdue report no longer fits.
+	if want := "p1@09:15-09:45,tiny@09:45-10:00,review@11:00-12:30"; blocks(s) != want {
+		t.Errorf("blocks = %s, want %s", blocks(s), want)
+	}
+	if len(s.Busy) != 2 || len(s.Unscheduled) != 1 || s.Unscheduled[0].Task.ID != "late" {
+		t.Errorf("busy = %d, unscheduled = %+v", len(s.Busy), s.Unscheduled)
+	}
+	if s.FreeMinutes != 30 {
+		t.Errorf("free = %d, want 30", s.FreeMinutes)
+	}
+
+	// Scheduling later in the day starts at the next quarter hour.
+	s, err = ScheduleDay(tasks, ScheduleOptions{Start: at(9, 0), End: at(13, 0), Now: at(11, 5), DefaultMinutes: 30})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "p1@11:15-11:45,late@11:45-12:45,tiny@12:45-13:00"; blocks(s) != want {
+		t.Errorf("blocks = %s, want %s", blocks(s), want)
+	}
+
+	if _, err := ScheduleDay(tasks, ScheduleOptions{Start: at(17, 0), End: at(9, 0)}); err == nil {
+		t.Error("expected error for end before start")
+	}
+}
@@ -3,27 +3,18 @@ package todoist
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/nsega/mcp-todoist/internal/models"
 )
 
 // GetSections returns sections, optionally filtered by project.
 func (c *Client) GetSections(projectID string) ([]models.Section, error) {
-	endpoint := "/sections"
+	params := url.Values{}
 	if projectID != "" {
-		endpoint += "?project_id=" + projectID
+		params.Set("project_id", projectID)
 	}
-
-	data, err := c.do("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var page PaginatedResponse[models.Section]
-	if err := json.Unmarshal(data, &page); err != nil {
-		return nil, fmt.Errorf("failed to parse sections: %w", err)
-	}
-	return page.Results, nil
+	return getAll[models.Section](c, "/sections", params, "sections")
 }
 
 // CreateSection creates a new section.
@@ -0,0 +1,174 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// ShareProject invites a user to a project by email. Role is optional and
+// only applies to workspace projects.
+func (c *Client) ShareProject(projectID, email, role string) error {
+	args := map[string]interface{}{"project_id": projectID, "email": email}
+	if role != "" {
+		args["role"] = role
+	}
+	_, err := c.syncWrite(newSyncCommand("share_project", args, false))
+	return err
+}
+
+// DeleteCollaborator removes a user from a shared project.
+func (c *Client) DeleteCollaborator(projectID, email string) error {
+	args := map[string]interface{}{"project_id": projectID, "email": email}
+	_, err := c.syncWrite(newSyncCommand("delete_collaborator", args, false))
+	return err
+}
+
+// LeaveProject removes the authenticated user from a shared project.
+func (c *Client) LeaveProject(projectID string) error {
+	user, err := c.GetUser()
+	if err != nil {
+		return err
+	}
+	return c.DeleteCollaborator(projectID, user.Email)
+}
+
+// ChangeCollaboratorRole sets a collaborator's role in a workspace
+// project, e.g. "ADMIN", "READ_WRITE" or "READ_ONLY".
+func (c *Client) ChangeCollaboratorRole(projectID, userID, role string) error {
+	args := map[string]interface{}{"project_id": projectID, "user_id": userID, "role": role}
+	_, err := c.syncWrite(newSyncCommand("change_collaborator_role", args, false))
+	return err
+}
+
+// liveNotification is the subset of a Sync API live notification needed
+// to describe project invitations.
+type liveNotification struct {
+	NotificationType string `json:"notification_type"`
+	InvitationID     string `json:"invitation_id"`
+	InvitationSecret string `json:"invitation_secret"`
+	ProjectID        string `json:"project_id"`
+	ProjectName      string `json:"project_name"`
+	State            string `json:"state"`
+	CreatedAt        string `json:"created_at"`
+	IsDeleted        bool   `json:"is_deleted"`
+	FromUser         struct {
+		Email    string `json:"email"`
+		FullName string `json:"full_name"`
+	} `json:"from_user"`
+}
+
+// collaboratorState is a Sync API collaborator state: a user's membership
+// of a shared project, "invited" until they accept.
+type collaboratorState struct {
+	ProjectID string `json:"project_id"`
+	UserID    string `json:"user_id"`
+	State     string `json:"state"`
+	IsDeleted bool   `json:"is_deleted"`
+}
+
+// syncCollaborator is a Sync API collaborator.
+type syncCollaborator struct {
+	ID       string `json:"id"`
+	Email    string `json:"email"`
+	FullName string `json:"full_name"`
+}
+
+// GetInvitations returns pending project invitations, optionally only
+// those for one project. Invitations the authenticated user has received
+// come from share_invitation_sent notifications. Invitations pending on
+// the user's shared projects come from collaborator states and are marked
+// Sent; they are revoked by removing the invitee's email from the project.
+func (c *Client) GetInvitations(projectID string) ([]models.Invitation, error) {
+	resp, err := c.syncRead("live_notifications", "projects", "collaborators", "collaborator_states")
+	if err != nil {
+		return nil, err
+	}
+
+	var notes []liveNotification
+	var projects []models.Project
+	var people []syncCollaborator
+	var states []collaboratorState
+	for key, v := range map[string]interface{}{
+		"live_notifications":  &notes,
+		"projects":            &projects,
+		"collaborators":       &people,
+		"collaborator_states": &states,
+	} {
+		if raw, ok := resp[key]; ok {
+			if err := json.Unmarshal(raw, v); err != nil {
+				return nil, fmt.Errorf("failed to parse %s: %w", strings.ReplaceAll(key, "_", " "), err)
+			}
+		}
+	}
+
+	var invitations []models.Invitation
+	for _, n := range notes {
+		if n.NotificationType != "share_invitation_sent" || n.IsDeleted || n.InvitationID == "" {
+			continue
+		}
+		if n.State != "" && n.State != "invited" {
+			continue
+		}
+		if projectID != "" && n.ProjectID != projectID {
+			continue
+		}
+		invitations = append(invitations, models.Invitation{
+			ID:          n.InvitationID,
+			Secret:      n.InvitationSecret,
+			ProjectID:   n.ProjectID,
+			ProjectName: n.ProjectName,
+			FromEmail:   n.FromUser.Email,
+			FromName:    n.FromUser.FullName,
+			State:       n.State,
+			CreatedAt:   n.CreatedAt,
+		})
+	}
+
+	names := map[string]string{}
+	for _, p := range projects {
+		names[p.ID] = p.Name
+	}
+	emails := map[string]string{}
+	for _, p := range people {
+		emails[p.ID] = p.Email
+	}
+	for _, st := range states {
+		if st.State != "invited" || st.IsDeleted || (projectID != "" && st.ProjectID != projectID) {
+			continue
+		}
+		invitations = append(invitations, models.Invitation{
+			ProjectID:   st.ProjectID,
+			ProjectName: names[st.ProjectID],
+			Sent:        true,
+			ToEmail:     emails[st.UserID],
+			State:       st.State,
+		})
+	}
+	return invitations, nil
+}
+
+// AcceptInvitation accepts a received project invitation. The secret
+// comes from the invitation notification.
+func (c *Client) AcceptInvitation(invitationID, secret string) error {
+	args := map[string]interface{}{"invitation_id": invitationID, "invitation_secret": secret}
+	_, err := c.syncWrite(newSyncCommand("accept_invitation", args, false))
+	return err
+}
+
+// RejectInvitation declines a received project invitation.
+func (c *Client) RejectInvitation(invitationID, secret string) error {
+	args := map[string]interface{}{"invitation_id": invitationID, "invitation_secret": secret}
+	_, err := c.syncWrite(newSyncCommand("reject_invitation", args, false))
+	return err
+}
+
+// DeleteInvitation revokes a pending invitation by ID. Only the user who
+// sent the invitation may revoke it. Invitations listed by GetInvitations
+// as Sent have no ID; revoke those with DeleteCollaborator.
+func (c *Client) DeleteInvitation(invitationID string) error {
+	_, err := c.syncWrite(newSyncCommand("delete_invitation", map[string]interface{}{"invitation_id": invitationID}, false))
+	return err
+}
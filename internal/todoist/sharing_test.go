@@ -0,0 +1,119 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+func TestShareProject(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds); err != nil {
+			t.Fatal(err)
+		}
+		if len(cmds) != 1 || cmds[0].Type != "share_project" || cmds[0].TempID != "" {
+			t.Fatalf("unexpected commands: %+v", cmds)
+		}
+		if cmds[0].Args["email"] != "ada@example.com" || cmds[0].Args["role"] != "READ_WRITE" {
+			t.Errorf("args = %v", cmds[0].Args)
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"}}`, cmds[0].UUID)
+	})
+	defer srv.Close()
+
+	if err := c.ShareProject("p1", "ada@example.com", "READ_WRITE"); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestLeaveProject(t *testing.T) {
+	var removed string
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/user":
+			_, _ = w.Write([]byte(`{"id":"u1","email":"me@example.com","full_name":"Me"}`))
+		case "/sync":
+			_ = r.ParseForm()
+			var cmds []syncCommand
+			_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+			if cmds[0].Type == "delete_collaborator" && cmds[0].Args["project_id"] == "p1" {
+				removed, _ = cmds[0].Args["email"].(string)
+			}
+			_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"}}`, cmds[0].UUID)
+		default:
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+	})
+	defer srv.Close()
+
+	if err := c.LeaveProject("p1"); err != nil {
+		t.Fatal(err)
+	}
+	if removed != "me@example.com" {
+		t.Errorf("removed = %q", removed)
+	}
+}
+
+func TestGetInvitations(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if got := r.PostForm.Get("resource_types"); got != `["live_notifications","projects","collaborators","collaborator_states"]` {
+			t.Errorf("resource_types = %q", got)
+		}
+		_, _ = w.Write([]byte(`{"live_notifications":[
+			{"id":"n1","notification_type":"share_invitation_sent","invitation_id":"i1","invitation_secret":"s1","project_id":"p1","project_name":"Trip","state":"invited","from_user":{"email":"ada@example.com","full_name":"Ada"}},
+			{"id":"n2","notification_type":"share_invitation_sent","invitation_id":"i2","project_id":"p1","state":"accepted"},
+			{"id":"n3","notification_type":"share_invitation_sent","invitation_id":"i3","project_id":"p2","state":"invited"},
+			{"id":"n4","notification_type":"item_assigned","project_id":"p1"}
+		],
+		"projects":[{"id":"p5","name":"Garden"}],
+		"collaborators":[{"id":"u2","email":"bo@example.com","full_name":"Bo"},{"id":"u3","email":"cy@example.com"}],
+		"collaborator_states":[
+			{"project_id":"p5","user_id":"u2","state":"invited"},
+			{"project_id":"p5","user_id":"u3","state":"active"}
+		]}`))
+	})
+	defer srv.Close()
+
+	invitations, err := c.GetInvitations("p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(invitations) != 1 || invitations[0].ID != "i1" || invitations[0].Secret != "s1" || invitations[0].FromName != "Ada" {
+		t.Errorf("unexpected invitations: %+v", invitations)
+	}
+
+	all, err := c.GetInvitations("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(all) != 3 {
+		t.Fatalf("got %d invitations, want 3", len(all))
+	}
+	if sent := all[2]; !sent.Sent || sent.ProjectName != "Garden" || sent.ToEmail != "bo@example.com" || sent.ID != "" {
+		t.Errorf("sent invitation = %+v", sent)
+	}
+}
+
+func TestAcceptInvitation(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []syncCommand
+		if err := json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds); err != nil {
+			t.Fatal(err)
+		}
+		if len(cmds) != 1 || cmds[0].Type != "accept_invitation" ||
+			cmds[0].Args["invitation_id"] != "i1" || cmds[0].Args["invitation_secret"] != "s1" {
+			t.Errorf("unexpected commands: %+v", cmds)
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"}}`, cmds[0].UUID)
+	})
+	defer srv.Close()
+
+	if err := c.AcceptInvitation("i1", "s1"); err != nil {
+		t.Fatal(err)
+	}
+}
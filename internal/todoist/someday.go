@@ -0,0 +1,121 @@
+package todoist
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// SomedayProjectID returns the ID of the Someday project, creating it if
+// create is set and it does not exist yet.
+func (c *Client) SomedayProjectID(cfg GTDConfig, create bool) (string, error) {
+	if cfg.SomedayProject == "" {
+		return "", fmt.Errorf("no Someday project configured")
+	}
+	p, err := c.FindProjectByName(cfg.SomedayProject)
+	if err != nil {
+		return "", err
+	}
+	if p != nil {
+		return p.ID, nil
+	}
+	if !create {
+		return "", nil
+	}
+	p, err = c.CreateProject(map[string]interface{}{"name": cfg.SomedayProject})
+	if err != nil {
+		return "", fmt.Errorf("failed to create project %s: %w", cfg.SomedayProject, err)
+	}
+	return p.ID, nil
+}
+
+// DeferToSomeday puts a task on the someday/maybe list, either by moving it
+// to the Someday project (created if missing) or by adding SomedayLabel.
+// Its due date is cleared, as someday items are not scheduled.
+func (c *Client) DeferToSomeday(task models.Task, cfg GTDConfig, useLabel bool) (*models.Task, error) {
+	body := map[string]interface{}{}
+	if useLabel {
+		if cfg.SomedayLabel == "" {
+			return nil, fmt.Errorf("no someday label configured")
+		}
+		if !HasLabel(task.Labels, cfg.SomedayLabel) {
+			body["labels"] = append(append([]string{}, task.Labels...), cfg.SomedayLabel)
+		}
+	} else {
+		pid, err := c.SomedayProjectID(cfg, true)
+		if err != nil {
+			return nil, err
+		}
+		if task.ProjectID != pid {
+			moved, err := c.MoveTask(task.ID, map[string]interface{}{"project_id": pid})
+			if err != nil {
+				return nil, err
+			}
+			task = *moved
+		}
+	}
+	if task.Due != nil {
+		body["due_string"] = "no date"
+	}
+	if len(body) == 0 {
+		return &task, nil
+	}
+	return c.UpdateTask(task.ID, body)
+}
+
+// GetSomeday returns the someday/maybe tasks, by label or in the Someday
+// project, oldest first.
+func (c *Client) GetSomeday(cfg GTDConfig) ([]models.Task, error) {
+	projects, err := c.GetProjects()
+	if err != nil {
+		return nil, err
+	}
+	byID := map[string]models.Project{}
+	for _, p := range projects {
+		byID[p.ID] = p
+	}
+	tasks, err := c.GetTasks("", "")
+	if err != nil {
+		return nil, err
+	}
+	var out []models.Task
+	for _, t := range tasks {
+		if !t.IsCompleted && cfg.IsSomeday(t, byID) {
+			out = append(out, t)
+		}
+	}
+	sort.SliceStable(out, func(i, j int) bool { return out[i].CreatedAt.Before(out[j].CreatedAt) })
+	return out, nil
+}
+
+// ActivateSomeday takes a task off the someday/maybe list: it moves it to
+// projectID (unless empty or already there), removes SomedayLabel and
+// sets the due string, if any.
+func (c *Client) ActivateSomeday(task models.Task, cfg GTDConfig, projectID, dueString string) (*models.Task, error) {
+	if projectID != "" && task.ProjectID != projectID {
+		moved, err := c.MoveTask(task.ID, map[string]interface{}{"project_id": projectID})
+		if err != nil {
+			return nil, err
+		}
+		task = *moved
+	}
+	body := map[string]interface{}{}
+	if cfg.SomedayLabel != "" && HasLabel(task.Labels, cfg.SomedayLabel) {
+		labels := []string{}
+		for _, l := range task.Labels {
+			if !strings.EqualFold(l, strings.TrimPrefix(cfg.SomedayLabel, "@")) {
+				labels = append(labels, l)
+			}
+		}
+		body["labels"] = labels
+	}
+	if dueString != "" {
+		body["due_string"] = dueString
+	}
+	if len(body) == 0 {
+		return &task, nil
+	}
+	return c.UpdateTask(task.ID, body)
+}
@@ -0,0 +1,56 @@
+package todoist
+
+import (
+	"testing"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestSomedayRoundTrip(t *testing.T) {
+	f := &fakeAccount{
+		projects: []models.Project{{ID: "inbox", Name: "Inbox", IsInboxProject: true}, {ID: "work", Name: "Work"}},
+		tasks: []models.Task{
+			{ID: "1", Content: "Learn Italian", ProjectID: "inbox", Due: &models.DueDate{Date: "2026-10-20"}, CreatedAt: time.Date(2026, 5, 1, 0, 0, 0, 0, time.UTC)},
+			{ID: "2", Content: "Write a book", ProjectID: "work", Labels: []string{"writing"}, CreatedAt: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+	c, srv := testServer(t, f.handler(t))
+	defer srv.Close()
+	cfg := GTDConfig{SomedayLabel: "someday", SomedayProject: "Someday/Maybe"}
+
+	// The project is created on first use and the due date dropped.
+	moved, err := c.DeferToSomeday(f.tasks[0], cfg, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(f.projects) != 3 || f.projects[2].Name != "Someday/Maybe" || moved.ProjectID != f.projects[2].ID || moved.Due != nil {
+		t.Fatalf("deferred = %+v, projects = %+v", moved, f.projects)
+	}
+	labelled, err := c.DeferToSomeday(f.tasks[1], cfg, true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if labelled.ProjectID != "work" || len(labelled.Labels) != 2 || labelled.Labels[1] != "someday" {
+		t.Fatalf("labelled = %+v", labelled)
+	}
+
+	items, err := c.GetSomeday(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(items) != 2 || items[0].ID != "2" || items[1].ID != "1" {
+		t.Fatalf("someday = %+v", items)
+	}
+
+	active, err := c.ActivateSomeday(items[0], cfg, "inbox", "next monday")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if active.ProjectID != "inbox" || len(active.Labels) != 1 || active.Labels[0] != "writing" || active.Due == nil || active.Due.String != "next monday" {
+		t.Errorf("activated = %+v", active)
+	}
+	if items, _ := c.GetSomeday(cfg); len(items) != 1 || items[0].ID != "1" {
+		t.Errorf("someday after activation = %+v", items)
+	}
+}
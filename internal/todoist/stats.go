@@ -0,0 +1,23 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// GetProductivityStats returns completion counts for recent days and
+// weeks, goals, streaks and karma.
+func (c *Client) GetProductivityStats() (*models.ProductivityStats, error) {
+	data, err := c.do("GET", "/tasks/completed/stats", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var stats models.ProductivityStats
+	if err := json.Unmarshal(data, &stats); err != nil {
+		return nil, fmt.Errorf("failed to parse productivity stats: %w", err)
+	}
+	return &stats, nil
+}
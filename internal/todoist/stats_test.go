@@ -0,0 +1,38 @@
+package todoist
+
+import (
+	"net/http"
+	"testing"
+)
+
+func TestGetProductivityStats(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tasks/completed/stats" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		_, _ = w.Write([]byte(`{
+			"completed_count": 120,
+			"karma": 5230.0,
+			"karma_trend": "up",
+			"days_items": [{"date":"2026-10-18","total_completed":4,"items":[{"id":"p1","completed":4}]}],
+			"week_items": [{"from":"2026-10-12","to":"2026-10-18","total_completed":21}],
+			"karma_update_reasons": [{"time":"2026-10-18T09:00:00Z","new_karma":5230,"positive_karma":12,"negative_karma":0}],
+			"goals": {"daily_goal":5,"weekly_goal":25,"current_daily_streak":{"count":3},"max_daily_streak":{"count":9}}
+		}`))
+	})
+	defer srv.Close()
+
+	stats, err := c.GetProductivityStats()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if stats.CompletedCount != 120 || stats.KarmaTrend != "up" || stats.Goals.DailyGoal != 5 {
+		t.Errorf("unexpected stats: %+v", stats)
+	}
+	if len(stats.DaysItems) != 1 || stats.DaysItems[0].Items[0].ProjectID != "p1" {
+		t.Errorf("unexpected days: %+v", stats.DaysItems)
+	}
+	if stats.Goals.MaxDailyStreak.Count != 9 {
+		t.Errorf("max daily streak = %d", stats.Goals.MaxDailyStreak.Count)
+	}
+}
@@ -0,0 +1,124 @@
+package todoist
+
+import (
+	"crypto/rand"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+)
+
+// syncRead performs a full sync for the given resource types and returns
+// the raw JSON of the response keyed by resource type. Some resources,
+// such as reminders, are only exposed through the Sync API.
+func (c *Client) syncRead(resourceTypes ...string) (map[string]json.RawMessage, error) {
+	types, err := json.Marshal(resourceTypes)
+	if err != nil {
+		return nil, fmt.Errorf("failed to marshal resource types: %w", err)
+	}
+	form := url.Values{}
+	form.Set("sync_token", "*")
+	form.Set("resource_types", string(types))
+
+	data, err := c.send("POST", "/sync", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, err
+	}
+
+	var resp map[string]json.RawMessage
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, fmt.Errorf("failed to parse sync response: %w", err)
+	}
+	return resp, nil
+}
+
+// syncCommand is a single write command for the Sync API.
+type syncCommand struct {
+	Type   string                 `json:"type"`
+	UUID   string                 `json:"uuid"`
+	TempID string                 `json:"temp_id,omitempty"`
+	Args   map[string]interface{} `json:"args"`
+}
+
+// newSyncCommand builds a command with a fresh UUID. Commands that create
+// objects also get a temp ID so the real ID can be read back.
+func newSyncCommand(typ string, args map[string]interface{}, creates bool) syncCommand {
+	cmd := syncCommand{Type: typ, UUID: newUUID(), Args: args}
+	if creates {
+		cmd.TempID = newUUID()
+	}
+	return cmd
+}
+
+// syncWrite sends commands to the Sync API and returns the mapping from
+// temp IDs to real IDs. It fails if any command was rejected. Commands
+// are applied independently, so on such a failure the others may still
+// have taken effect; the mapping for them is returned with the error.
+func (c *Client) syncWrite(cmds ...syncCommand) (map[string]string, error) {
+	_, mapping, err := c.syncWriteEach(cmds...)
+	return mapping, err
+}
+
+// syncWriteEach is syncWrite that also reports, for each command, whether
+// it was applied, so callers can tell what a partly failed request did.
+// The error is that of the first rejected command.
+func (c *Client) syncWriteEach(cmds ...syncCommand) ([]bool, map[string]string, error) {
+	encoded, err := json.Marshal(cmds)
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to marshal sync commands: %w", err)
+	}
+	form := url.Values{}
+	form.Set("commands", string(encoded))
+
+	data, err := c.send("POST", "/sync", "application/x-www-form-urlencoded", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var resp struct {
+		SyncStatus    map[string]json.RawMessage `json:"sync_status"`
+		TempIDMapping map[string]string          `json:"temp_id_mapping"`
+	}
+	if err := json.Unmarshal(data, &resp); err != nil {
+		return nil, nil, fmt.Errorf("failed to parse sync response: %w", err)
+	}
+
+	applied := make([]bool, len(cmds))
+	var first error
+	for i, cmd := range cmds {
+		status, ok := resp.SyncStatus[cmd.UUID]
+		if !ok {
+			if first == nil {
+				first = fmt.Errorf("sync command %s: no status returned", cmd.Type)
+			}
+			continue
+		}
+		var okStr string
+		if json.Unmarshal(status, &okStr) == nil && okStr == "ok" {
+			applied[i] = true
+			continue
+		}
+		if first != nil {
+			continue
+		}
+		var cmdErr struct {
+			Error     string `json:"error"`
+			ErrorCode int    `json:"error_code"`
+		}
+		if err := json.Unmarshal(status, &cmdErr); err != nil || cmdErr.Error == "" {
+			first = fmt.Errorf("sync command %s failed: %s", cmd.Type, string(status))
+		} else {
+			first = fmt.Errorf("sync command %s failed (code %d): %s", cmd.Type, cmdErr.ErrorCode, cmdErr.Error)
+		}
+	}
+	return applied, resp.TempIDMapping, first
+}
+
+// newUUID returns a random RFC 4122 version 4 UUID.
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = (b[6] & 0x0f) | 0x40
+	b[8] = (b[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
@@ -0,0 +1,308 @@
+package todoist
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// TaskDraft is a task read from an external file, not yet created.
+// At most one of DueDate, DueDatetime and DueString is set.
+type TaskDraft struct {
+	Source      string // where the task came from, e.g. "row 3" or "VTODO 2"
+	Content     string
+	Description string
+	DueDate     string // YYYY-MM-DD
+	DueDatetime string // RFC 3339 in UTC, or floating without an offset
+	DueString   string // natural language, including recurrences
+	Priority    int    // API priority (4 = p1), 0 if unset
+	Labels      []string
+	Duration    *models.Duration
+}
+
+// dueKey is the draft's due date for de-duplication: the date for dated
+// tasks, taking times in loc, the due string for natural-language ones.
+func (d TaskDraft) dueKey(loc *time.Location) string {
+	switch {
+	case d.DueDate != "":
+		return d.DueDate
+	case d.DueDatetime != "":
+		return localDate(d.DueDatetime, loc)
+	}
+	return strings.ToLower(d.DueString)
+}
+
+// DueText describes the draft's due date for display.
+func (d TaskDraft) DueText() string {
+	switch {
+	case d.DueDate != "":
+		return d.DueDate
+	case d.DueDatetime != "":
+		return d.DueDatetime
+	}
+	return d.DueString
+}
+
+// localDate returns the calendar date of a datetime in loc, the user's
+// time zone, which is what Todoist reports as Due.Date.
+func localDate(dt string, loc *time.Location) string {
+	if at, err := time.Parse(time.RFC3339, dt); err == nil {
+		return at.In(loc).Format("2006-01-02")
+	}
+	if len(dt) >= 10 {
+		return dt[:10]
+	}
+	return dt
+}
+
+// userLocation returns the Todoist user's time zone when a draft has a
+// due time with an offset, whose date depends on it. It falls back to the
+// local time zone if the user's cannot be loaded.
+func (c *Client) userLocation(drafts []TaskDraft) (*time.Location, error) {
+	for _, d := range drafts {
+		if _, err := time.Parse(time.RFC3339, d.DueDatetime); err != nil {
+			continue
+		}
+		user, err := c.GetUser()
+		if err != nil {
+			return nil, err
+		}
+		if user.TZInfo != nil {
+			if loc, err := time.LoadLocation(user.TZInfo.Timezone); err == nil {
+				return loc, nil
+			}
+		}
+		break
+	}
+	return time.Local, nil
+}
+
+// TaskImportResult describes what ImportTasks created and skipped.
+type TaskImportResult struct {
+	Created    []ImportedTask
+	Duplicates []ImportedTask // TaskID is the existing task's ID, or "" for repeats within the file
+}
+
+// ImportedTask pairs a draft with a task ID.
+type ImportedTask struct {
+	Draft  TaskDraft
+	TaskID string
+}
+
+// taskCSVFields are the fields a CSV column can be mapped to, with the
+// header names recognised when no mapping is given.
+var taskCSVFields = map[string][]string{
+	"content":     {"content", "title", "task", "name", "summary", "subject"},
+	"description": {"description", "notes", "note", "details"},
+	"due":         {"due", "due date", "due_date", "date", "deadline"},
+	"priority":    {"priority"},
+	"labels":      {"labels", "label", "tags", "tag", "categories"},
+	"duration":    {"duration", "duration (minutes)", "minutes", "estimate"},
+}
+
+// ParseTaskCSV reads tasks from a CSV file with a header row. columns maps
+// task fields (content, description, due, priority, labels, duration) to
+// header names; fields without a mapping are matched against common
+// header names, and content is required. Rows with empty content are
+// skipped.
+func ParseTaskCSV(r io.Reader, columns map[string]string) ([]TaskDraft, error) {
+	for field := range columns {
+		if _, ok := taskCSVFields[field]; !ok {
+			return nil, fmt.Errorf("unknown field %q in column mapping (use content, description, due, priority, labels or duration)", field)
+		}
+	}
+
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("CSV is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read CSV: %w", err)
+	}
+	byName := map[string]int{}
+	for i, name := range header {
+		byName[strings.ToLower(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+
+	cols := map[string]int{}
+	for field, aliases := range taskCSVFields {
+		if name, ok := columns[field]; ok {
+			i, found := byName[strings.ToLower(strings.TrimSpace(name))]
+			if !found {
+				return nil, fmt.Errorf("column %q mapped to %s is not in the CSV header", name, field)
+			}
+			cols[field] = i
+			continue
+		}
+		for _, alias := range aliases {
+			if i, ok := byName[alias]; ok {
+				cols[field] = i
+				break
+			}
+		}
+	}
+	if _, ok := cols["content"]; !ok {
+		return nil, fmt.Errorf("no content column found; map one with columns.content")
+	}
+
+	var drafts []TaskDraft
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read CSV: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		d := TaskDraft{Source: fmt.Sprintf("row %d", line), Content: field("content"), Description: field("description")}
+		if d.Content == "" {
+			continue
+		}
+		setDraftDue(&d, field("due"))
+		if d.Priority, err = parseImportPriority(field("priority")); err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		for _, l := range strings.FieldsFunc(field("labels"), func(r rune) bool { return r == ',' || r == ';' || r == ' ' }) {
+			d.Labels = append(d.Labels, strings.TrimPrefix(l, "@"))
+		}
+		if v := field("duration"); v != "" {
+			minutes, err := strconv.Atoi(v)
+			if err != nil || minutes <= 0 {
+				return nil, fmt.Errorf("line %d: invalid duration %q (use minutes)", line, v)
+			}
+			d.Duration = &models.Duration{Amount: minutes, Unit: "minute"}
+		}
+		drafts = append(drafts, d)
+	}
+	return drafts, nil
+}
+
+// setDraftDue classifies a due value as a date, a datetime or a phrase.
+func setDraftDue(d *TaskDraft, v string) {
+	if v == "" {
+		return
+	}
+	if _, err := time.Parse("2006-01-02", v); err == nil {
+		d.DueDate = v
+		return
+	}
+	if at, err := time.Parse(time.RFC3339, v); err == nil {
+		d.DueDatetime = at.UTC().Format(time.RFC3339)
+		return
+	}
+	for _, layout := range []string{"2006-01-02T15:04:05", "2006-01-02T15:04", "2006-01-02 15:04"} {
+		if at, err := time.Parse(layout, v); err == nil {
+			d.DueDatetime = at.Format("2006-01-02T15:04:05")
+			return
+		}
+	}
+	d.DueString = v
+}
+
+// parseImportPriority accepts p1-p4, 1-4 (1 highest, as in the app) and
+// words such as "high" or "low".
+func parseImportPriority(v string) (int, error) {
+	switch strings.TrimPrefix(strings.ToLower(v), "p") {
+	case "":
+		return 0, nil
+	case "1", "urgent", "highest":
+		return 4, nil
+	case "2", "high":
+		return 3, nil
+	case "3", "medium", "normal":
+		return 2, nil
+	case "4", "low", "none":
+		return 1, nil
+	}
+	return 0, fmt.Errorf("invalid priority %q (use p1-p4)", v)
+}
+
+// ImportTasks creates drafts in a project (the inbox if projectID is
+// empty), skipping any whose content and due date match an active task
+// or an earlier draft. With dryRun nothing is created and Created lists
+// the drafts that would be.
+func (c *Client) ImportTasks(drafts []TaskDraft, projectID string, dryRun bool) (*TaskImportResult, error) {
+	existing, err := c.GetTasks("", "")
+	if err != nil {
+		return nil, err
+	}
+	seen := map[string]string{}
+	for _, t := range existing {
+		content := strings.ToLower(strings.TrimSpace(t.Content))
+		if t.Due == nil {
+			seen[content+"|"] = t.ID
+			continue
+		}
+		seen[content+"|"+t.Due.Date[:min(10, len(t.Due.Date))]] = t.ID
+		if t.Due.String != "" {
+			seen[content+"|"+strings.ToLower(t.Due.String)] = t.ID
+		}
+	}
+
+	loc, err := c.userLocation(drafts)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &TaskImportResult{}
+	for _, d := range drafts {
+		key := strings.ToLower(strings.TrimSpace(d.Content)) + "|" + d.dueKey(loc)
+		if id, dup := seen[key]; dup {
+			result.Duplicates = append(result.Duplicates, ImportedTask{Draft: d, TaskID: id})
+			continue
+		}
+		seen[key] = ""
+
+		if dryRun {
+			result.Created = append(result.Created, ImportedTask{Draft: d})
+			continue
+		}
+		body := map[string]interface{}{"content": d.Content}
+		if projectID != "" {
+			body["project_id"] = projectID
+		}
+		if d.Description != "" {
+			body["description"] = d.Description
+		}
+		switch {
+		case d.DueDate != "":
+			body["due_date"] = d.DueDate
+		case d.DueDatetime != "":
+			body["due_datetime"] = d.DueDatetime
+		case d.DueString != "":
+			body["due_string"] = d.DueString
+		}
+		if d.Priority > 1 {
+			body["priority"] = d.Priority
+		}
+		if len(d.Labels) > 0 {
+			body["labels"] = d.Labels
+		}
+		if d.Duration != nil {
+			body["duration"] = d.Duration.Amount
+			body["duration_unit"] = d.Duration.Unit
+		}
+		t, err := c.CreateTask(body)
+		if err != nil {
+			return result, fmt.Errorf("%s (%s): %w", d.Source, d.Content, err)
+		}
+		result.Created = append(result.Created, ImportedTask{Draft: d, TaskID: t.ID})
+	}
+	return result, nil
+}
@@ -0,0 +1,124 @@
+package todoist
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestParseTaskCSV(t *testing.T) {
+	in := "\ufeffTitle,Due Date,Priority,Tags,Notes,Estimate\n" +
+		"Buy milk,2026-10-20,high,\"errands, @home\",2%,\n" +
+		",,,,,\n" +
+		"Call Ana,next monday,p1,,,30\n" +
+		"Standup,2026-10-21 09:30,,,,\n"
+	drafts, err := ParseTaskCSV(strings.NewReader(in), map[string]string{"due": "due date", "description": "Notes"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []TaskDraft{
+		{Source: "row 2", Content: "Buy milk", DueDate: "2026-10-20", Priority: 3, Labels: []string{"errands", "home"}, Description: "2%"},
+		{Source: "row 4", Content: "Call Ana", DueString: "next monday", Priority: 4, Duration: &models.Duration{Amount: 30, Unit: "minute"}},
+		{Source: "row 5", Content: "Standup", DueDatetime: "2026-10-21T09:30:00"},
+	}
+	if !reflect.DeepEqual(drafts, want) {
+		t.Errorf("drafts:\n got  %+v\n want %+v", drafts, want)
+	}
+}
+
+func TestParseTaskCSV_errors(t *testing.T) {
+	tests := []struct {
+		in      string
+		columns map[string]string
+		want    string
+	}{
+		{"A,B\nx,y\n", nil, "no content column found"},
+		{"Task\nx\n", map[string]string{"due": "When"}, `column "When" mapped to due is not in the CSV header`},
+		{"Task\nx\n", map[string]string{"owner": "Task"}, `unknown field "owner"`},
+		{"Task,Priority\nx,urgentish\n", nil, `line 2: invalid priority "urgentish"`},
+	}
+	for _, tt := range tests {
+		_, err := ParseTaskCSV(strings.NewReader(tt.in), tt.columns)
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseTaskCSV(%q) error = %v, want %q", tt.in, err, tt.want)
+		}
+	}
+}
+
+func TestImportTasks_dedupe(t *testing.T) {
+	var created []map[string]interface{}
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/tasks":
+			_, _ = w.Write([]byte(`{"results":[
+				{"id":"7","content":"Buy milk","due":{"date":"2026-10-20"}},
+				{"id":"8","content":"Water plants","due":{"date":"2026-10-21","string":"every tue","recurring":true}},
+				{"id":"9","content":"Read book"}],"next_cursor":null}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body)
+			_, _ = w.Write([]byte(`{"id":"100","content":"x"}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer srv.Close()
+
+	drafts := []TaskDraft{
+		{Source: "row 2", Content: "buy milk ", DueDate: "2026-10-20"},
+		{Source: "row 3", Content: "Buy milk", DueDate: "2026-10-27"},
+		{Source: "row 4", Content: "Water plants", DueString: "Every Tue"},
+		{Source: "row 5", Content: "Read book"},
+		{Source: "row 6", Content: "Buy milk", DueDate: "2026-10-27"},
+	}
+	result, err := c.ImportTasks(drafts, "p1", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 0 || len(result.Created) != 1 || result.Created[0].Draft.Source != "row 3" {
+		t.Fatalf("dry run: created=%v result=%+v", created, result.Created)
+	}
+	var dups []string
+	for _, d := range result.Duplicates {
+		dups = append(dups, d.Draft.Source+"="+d.TaskID)
+	}
+	if want := []string{"row 2=7", "row 4=8", "row 5=9", "row 6="}; !reflect.DeepEqual(dups, want) {
+		t.Errorf("duplicates = %v, want %v", dups, want)
+	}
+
+	result, err = c.ImportTasks(drafts[1:2], "p1", false)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(created) != 1 || created[0]["project_id"] != "p1" || created[0]["due_date"] != "2026-10-27" || result.Created[0].TaskID != "100" {
+		t.Errorf("created=%v result=%+v", created, result.Created)
+	}
+}
+
+func TestImportTasks_dedupeInUserTimezone(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks":
+			_, _ = w.Write([]byte(`{"results":[{"id":"7","content":"Call NY","due":{"date":"2026-10-20T21:30:00","timezone":"America/New_York"}}],"next_cursor":null}`))
+		case "/user":
+			_, _ = w.Write([]byte(`{"id":"me","tz_info":{"timezone":"America/New_York"}}`))
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer srv.Close()
+
+	// 01:30 UTC on the 21st is still the 20th in New York.
+	result, err := c.ImportTasks([]TaskDraft{{Source: "VTODO 1", Content: "Call NY", DueDatetime: "2026-10-21T01:30:00Z"}}, "", true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Duplicates) != 1 || result.Duplicates[0].TaskID != "7" {
+		t.Errorf("result = %+v", result)
+	}
+}
@@ -3,35 +3,23 @@ package todoist
 import (
 	"encoding/json"
 	"fmt"
+	"net/url"
 	"strings"
+	"time"
 
 	"github.com/nsega/mcp-todoist/internal/models"
 )
 
 // GetTasks returns active tasks, optionally filtered.
 func (c *Client) GetTasks(projectID, filter string) ([]models.Task, error) {
-	endpoint := "/tasks"
-	var params []string
+	params := url.Values{}
 	if projectID != "" {
-		params = append(params, fmt.Sprintf("project_id=%s", projectID))
+		params.Set("project_id", projectID)
 	}
 	if filter != "" {
-		params = append(params, fmt.Sprintf("filter=%s", filter))
+		params.Set("filter", filter)
 	}
-	if len(params) > 0 {
-		endpoint += "?" + strings.Join(params, "&")
-	}
-
-	data, err := c.do("GET", endpoint, nil)
-	if err != nil {
-		return nil, err
-	}
-
-	var page PaginatedResponse[models.Task]
-	if err := json.Unmarshal(data, &page); err != nil {
-		return nil, fmt.Errorf("failed to parse tasks: %w", err)
-	}
-	return page.Results, nil
+	return getAll[models.Task](c, "/tasks", params, "tasks")
 }
 
 // GetTask returns a single task by ID.
@@ -94,6 +82,57 @@ func (c *Client) ReopenTask(id string) error {
 	return err
 }
 
+// MoveTask moves a task to another project, section or parent task.
+// Exactly one of project_id, section_id or parent_id should be set.
+func (c *Client) MoveTask(id string, body map[string]interface{}) (*models.Task, error) {
+	data, err := c.do("POST", "/tasks/"+id+"/move", body)
+	if err != nil {
+		return nil, err
+	}
+
+	var task models.Task
+	if err := json.Unmarshal(data, &task); err != nil {
+		return nil, fmt.Errorf("failed to parse task: %w", err)
+	}
+	return &task, nil
+}
+
+// GetCompletedTasks returns tasks completed between since and until,
+// optionally limited to one project, following next_cursor across
+// pages. The API caps the window at three months.
+func (c *Client) GetCompletedTasks(projectID string, since, until time.Time) ([]models.Task, error) {
+	params := url.Values{}
+	params.Set("since", since.UTC().Format(time.RFC3339))
+	params.Set("until", until.UTC().Format(time.RFC3339))
+	if projectID != "" {
+		params.Set("project_id", projectID)
+	}
+
+	var all []models.Task
+	for {
+		data, err := c.do("GET", "/tasks/completed/by_completion_date?"+params.Encode(), nil)
+		if err != nil {
+			return nil, err
+		}
+
+		var page struct {
+			Items      []models.Task `json:"items"`
+			NextCursor string        `json:"next_cursor"`
+		}
+		if err := json.Unmarshal(data, &page); err != nil {
+			return nil, fmt.Errorf("failed to parse completed tasks: %w", err)
+		}
+		for i := range page.Items {
+			page.Items[i].IsCompleted = true
+		}
+		all = append(all, page.Items...)
+		if page.NextCursor == "" {
+			return all, nil
+		}
+		params.Set("cursor", page.NextCursor)
+	}
+}
+
 // FindTaskByName searches for a task by partial name matching.
 // Returns nil if no match is found.
 func (c *Client) FindTaskByName(name string) (*models.Task, error) {
@@ -5,6 +5,7 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 )
 
 func TestGetTasks(t *testing.T) {
@@ -50,6 +51,45 @@ func TestGetTasks_withFilters(t *testing.T) {
 	}
 }
 
+func TestGetTasks_escapesFilter(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if got := r.URL.Query().Get("filter"); got != "#Work & p1" {
+			t.Errorf("filter = %q", got)
+		}
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	defer srv.Close()
+
+	_, err := c.GetTasks("", "#Work & p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestGetTasks_followsCursor(t *testing.T) {
+	var cursors []string
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		cursors = append(cursors, r.URL.Query().Get("cursor"))
+		if r.URL.Query().Get("project_id") != "p1" {
+			t.Errorf("query = %s", r.URL.RawQuery)
+		}
+		if r.URL.Query().Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"results":[{"id":"1"}],"next_cursor":"abc"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"2"}],"next_cursor":null}`))
+	})
+	defer srv.Close()
+
+	tasks, err := c.GetTasks("p1", "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || strings.Join(cursors, ",") != ",abc" {
+		t.Errorf("tasks=%+v cursors=%v", tasks, cursors)
+	}
+}
+
 func TestGetTask(t *testing.T) {
 	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
 		if r.URL.Path != "/tasks/42" {
@@ -154,6 +194,80 @@ func TestReopenTask(t *testing.T) {
 	}
 }
 
+func TestMoveTask(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/tasks/7/move" {
+			t.Errorf("method=%s path=%s", r.Method, r.URL.Path)
+		}
+		var body map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatal(err)
+		}
+		if body["parent_id"] != "3" {
+			t.Errorf("parent_id = %v", body["parent_id"])
+		}
+		_, _ = w.Write([]byte(`{"id":"7","content":"Moved","parent_id":"3"}`))
+	})
+	defer srv.Close()
+
+	task, err := c.MoveTask("7", map[string]interface{}{"parent_id": "3"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if task.ParentID != "3" {
+		t.Errorf("parent_id = %q", task.ParentID)
+	}
+}
+
+func TestGetCompletedTasks(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tasks/completed/by_completion_date" {
+			t.Errorf("path = %s", r.URL.Path)
+		}
+		q := r.URL.Query()
+		if q.Get("project_id") != "p1" || q.Get("since") != "2026-10-01T00:00:00Z" {
+			t.Errorf("query = %v", q)
+		}
+		_, _ = w.Write([]byte(`{"items":[{"id":"9","content":"Done"}],"next_cursor":null}`))
+	})
+	defer srv.Close()
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tasks, err := c.GetCompletedTasks("p1", since, since.AddDate(0, 0, 7))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 1 || !tasks[0].IsCompleted {
+		t.Errorf("unexpected tasks: %+v", tasks)
+	}
+}
+
+func TestGetCompletedTasks_followsCursor(t *testing.T) {
+	var cursors []string
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		q := r.URL.Query()
+		cursors = append(cursors, q.Get("cursor"))
+		if q.Get("since") != "2026-10-01T00:00:00Z" {
+			t.Errorf("query = %v", q)
+		}
+		if q.Get("cursor") == "" {
+			_, _ = w.Write([]byte(`{"items":[{"id":"1"}],"next_cursor":"abc"}`))
+			return
+		}
+		_, _ = w.Write([]byte(`{"items":[{"id":"2"}],"next_cursor":null}`))
+	})
+	defer srv.Close()
+
+	since := time.Date(2026, 10, 1, 0, 0, 0, 0, time.UTC)
+	tasks, err := c.GetCompletedTasks("", since, since.AddDate(0, 1, 0))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tasks) != 2 || !tasks[1].IsCompleted || strings.Join(cursors, ",") != ",abc" {
+		t.Errorf("tasks=%+v cursors=%v", tasks, cursors)
+	}
+}
+
 func TestFindTaskByName_exactMatch(t *testing.T) {
 	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
 		_, _ = w.Write([]byte(`{"results":[
@@ -0,0 +1,367 @@
+package todoist
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// templateColumns are the columns of a Todoist CSV template, in the order
+// Todoist itself exports them.
+var templateColumns = []string{
+	"TYPE", "CONTENT", "DESCRIPTION", "PRIORITY", "INDENT", "AUTHOR", "RESPONSIBLE",
+	"DATE", "DATE_LANG", "TIMEZONE", "DURATION", "DURATION_UNIT", "DEADLINE", "DEADLINE_LANG",
+}
+
+// TemplateItem is one section or task row of a project template.
+// Priority uses API numbering (4 is p1) and Indent is 1 for top-level
+// tasks. Labels are written inline in the CSV content as @label.
+type TemplateItem struct {
+	Type         string
+	Content      string
+	Description  string
+	Priority     int
+	Indent       int
+	Labels       []string
+	Date         string
+	DateLang     string
+	Timezone     string
+	Duration     int
+	DurationUnit string
+	Deadline     string
+	DeadlineLang string
+}
+
+// Template is a project structure in Todoist CSV template form.
+type Template struct {
+	Items []TemplateItem
+	// Skipped counts rows that were read but are not imported, such as
+	// comments ("note") and view settings ("meta").
+	Skipped int
+}
+
+// TemplateImport summarises what ImportTemplate created.
+type TemplateImport struct {
+	SectionsCreated int
+	SectionsReused  int
+	TaskIDs         []string
+}
+
+// BuildTemplate captures a project's sections and active tasks, with
+// subtasks, in display order. Tasks outside any section come first.
+func BuildTemplate(sections []models.Section, tasks []models.Task) *Template {
+	tree := BuildTaskTree(tasks)
+	t := &Template{}
+
+	addRoots := func(sectionID string) {
+		for _, root := range tree.Roots {
+			if root.Task.SectionID != sectionID {
+				continue
+			}
+			root.Walk(func(n *TaskNode, depth int) {
+				t.Items = append(t.Items, templateTask(n.Task, depth+1))
+			})
+		}
+	}
+
+	addRoots("")
+	ordered := append([]models.Section(nil), sections...)
+	sort.SliceStable(ordered, func(i, j int) bool { return ordered[i].Order < ordered[j].Order })
+	for _, s := range ordered {
+		t.Items = append(t.Items, TemplateItem{Type: "section", Content: s.Name})
+		addRoots(s.ID)
+	}
+	return t
+}
+
+func templateTask(task models.Task, indent int) TemplateItem {
+	item := TemplateItem{
+		Type:        "task",
+		Content:     task.Content,
+		Description: task.Description,
+		Priority:    task.Priority,
+		Indent:      indent,
+		Labels:      task.Labels,
+	}
+	if task.Due != nil {
+		item.Date = task.Due.String
+		if item.Date == "" {
+			item.Date = task.Due.Date
+		}
+		item.DateLang = task.Due.Lang
+		item.Timezone = task.Due.Timezone
+	}
+	if task.Duration != nil {
+		item.Duration = task.Duration.Amount
+		item.DurationUnit = task.Duration.Unit
+	}
+	if task.Deadline != nil {
+		item.Deadline = task.Deadline.Date
+		item.DeadlineLang = task.Deadline.Lang
+	}
+	return item
+}
+
+// WriteCSV writes the template in Todoist's CSV template format.
+func (t *Template) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(templateColumns); err != nil {
+		return err
+	}
+	for _, it := range t.Items {
+		content := it.Content
+		for _, l := range it.Labels {
+			content += " @" + l
+		}
+		row := []string{it.Type, content, it.Description, "", "", "", "", it.Date, it.DateLang, it.Timezone, "", it.DurationUnit, it.Deadline, it.DeadlineLang}
+		if it.Type == "task" {
+			row[3] = strconv.Itoa(csvPriority(it.Priority))
+			row[4] = strconv.Itoa(it.Indent)
+		}
+		if it.Duration > 0 {
+			row[10] = strconv.Itoa(it.Duration)
+		}
+		if err := cw.Write(row); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvPriority converts an API priority (4 is p1) to template numbering
+// (1 is p1), and back; the mapping is its own inverse.
+func csvPriority(p int) int {
+	if p < 1 || p > 4 {
+		return 4
+	}
+	return 5 - p
+}
+
+// ParseTemplate reads a Todoist CSV template. Column order is taken from
+// the header row; only TYPE and CONTENT are required.
+func ParseTemplate(r io.Reader) (*Template, error) {
+	cr := csv.NewReader(r)
+	cr.FieldsPerRecord = -1
+	header, err := cr.Read()
+	if err == io.EOF {
+		return nil, fmt.Errorf("template is empty")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read template: %w", err)
+	}
+
+	cols := map[string]int{}
+	for i, name := range header {
+		cols[strings.ToUpper(strings.TrimSpace(strings.TrimPrefix(name, "\ufeff")))] = i
+	}
+	for _, required := range []string{"TYPE", "CONTENT"} {
+		if _, ok := cols[required]; !ok {
+			return nil, fmt.Errorf("template is missing the %s column", required)
+		}
+	}
+
+	t := &Template{}
+	depth := 0
+	for {
+		record, err := cr.Read()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read template: %w", err)
+		}
+		line, _ := cr.FieldPos(0)
+		field := func(name string) string {
+			i, ok := cols[name]
+			if !ok || i >= len(record) {
+				return ""
+			}
+			return strings.TrimSpace(record[i])
+		}
+
+		switch typ := strings.ToLower(field("TYPE")); typ {
+		case "":
+			continue
+		case "note", "meta":
+			t.Skipped++
+		case "section":
+			t.Items = append(t.Items, TemplateItem{Type: "section", Content: field("CONTENT")})
+			depth = 0
+		case "task":
+			item, err := parseTemplateTask(field)
+			if err != nil {
+				return nil, fmt.Errorf("line %d: %w", line, err)
+			}
+			if item.Indent > depth+1 {
+				return nil, fmt.Errorf("line %d: indent %d follows a task at indent %d", line, item.Indent, depth)
+			}
+			depth = item.Indent
+			t.Items = append(t.Items, item)
+		default:
+			return nil, fmt.Errorf("line %d: unknown row type %q", line, typ)
+		}
+	}
+	return t, nil
+}
+
+func parseTemplateTask(field func(string) string) (TemplateItem, error) {
+	item := TemplateItem{
+		Type:         "task",
+		Description:  field("DESCRIPTION"),
+		Priority:     1,
+		Indent:       1,
+		Date:         field("DATE"),
+		DateLang:     field("DATE_LANG"),
+		Timezone:     field("TIMEZONE"),
+		DurationUnit: field("DURATION_UNIT"),
+		Deadline:     field("DEADLINE"),
+		DeadlineLang: field("DEADLINE_LANG"),
+	}
+
+	for _, word := range strings.Fields(field("CONTENT")) {
+		if len(word) > 1 && word[0] == '@' {
+			item.Labels = append(item.Labels, word[1:])
+			continue
+		}
+		if item.Content != "" {
+			item.Content += " "
+		}
+		item.Content += word
+	}
+	if item.Content == "" {
+		return item, fmt.Errorf("task has no content")
+	}
+
+	if s := field("PRIORITY"); s != "" {
+		p, err := strconv.Atoi(s)
+		if err != nil || p < 1 || p > 4 {
+			return item, fmt.Errorf("invalid priority %q", s)
+		}
+		item.Priority = csvPriority(p)
+	}
+	if s := field("INDENT"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 1 {
+			return item, fmt.Errorf("invalid indent %q", s)
+		}
+		item.Indent = n
+	}
+	if s := field("DURATION"); s != "" {
+		n, err := strconv.Atoi(s)
+		if err != nil || n < 0 {
+			return item, fmt.Errorf("invalid duration %q", s)
+		}
+		item.Duration = n
+		if item.DurationUnit == "" {
+			item.DurationUnit = "minute"
+		}
+	}
+	return item, nil
+}
+
+// taskBody builds the CreateTask request for a template task. ISO dates
+// are sent as exact dates; anything else is parsed by Todoist.
+func (it TemplateItem) taskBody(projectID, sectionID, parentID string) map[string]interface{} {
+	body := map[string]interface{}{"content": it.Content, "project_id": projectID}
+	if it.Description != "" {
+		body["description"] = it.Description
+	}
+	if it.Priority > 1 {
+		body["priority"] = it.Priority
+	}
+	if len(it.Labels) > 0 {
+		body["labels"] = it.Labels
+	}
+	if sectionID != "" {
+		body["section_id"] = sectionID
+	}
+	if parentID != "" {
+		body["parent_id"] = parentID
+	}
+	switch {
+	case it.Date == "":
+	case isTemplateLayout(it.Date, "2006-01-02"):
+		body["due_date"] = it.Date
+	case isTemplateLayout(it.Date, "2006-01-02T15:04:05"), isTemplateLayout(it.Date, time.RFC3339):
+		body["due_datetime"] = it.Date
+	default:
+		body["due_string"] = it.Date
+		if it.DateLang != "" {
+			body["due_lang"] = it.DateLang
+		}
+	}
+	if it.Duration > 0 {
+		body["duration"] = it.Duration
+		body["duration_unit"] = it.DurationUnit
+	}
+	if it.Deadline != "" {
+		body["deadline_date"] = it.Deadline
+		if it.DeadlineLang != "" {
+			body["deadline_lang"] = it.DeadlineLang
+		}
+	}
+	return body
+}
+
+func isTemplateLayout(s, layout string) bool {
+	_, err := time.Parse(layout, s)
+	return err == nil
+}
+
+// ImportTemplate creates a template's sections and tasks in a project.
+// Sections that already exist (matched by name) are reused. On error the
+// returned summary describes what was created before the failure.
+func (c *Client) ImportTemplate(t *Template, projectID string) (*TemplateImport, error) {
+	existing, err := c.GetSections(projectID)
+	if err != nil {
+		return nil, err
+	}
+	sectionIDs := map[string]string{}
+	for _, s := range existing {
+		sectionIDs[strings.ToLower(s.Name)] = s.ID
+	}
+
+	result := &TemplateImport{}
+	var sectionID string
+	var parents []string // parents[i] is the last task created at indent i+1
+	for _, it := range t.Items {
+		if it.Type == "section" {
+			parents = nil
+			if id, ok := sectionIDs[strings.ToLower(it.Content)]; ok {
+				sectionID = id
+				result.SectionsReused++
+				continue
+			}
+			s, err := c.CreateSection(map[string]interface{}{"name": it.Content, "project_id": projectID})
+			if err != nil {
+				return result, err
+			}
+			sectionID = s.ID
+			sectionIDs[strings.ToLower(it.Content)] = s.ID
+			result.SectionsCreated++
+			continue
+		}
+
+		if it.Indent-1 < len(parents) {
+			parents = parents[:it.Indent-1]
+		}
+		parentID := ""
+		if len(parents) > 0 {
+			parentID = parents[len(parents)-1]
+		}
+		task, err := c.CreateTask(it.taskBody(projectID, sectionID, parentID))
+		if err != nil {
+			return result, err
+		}
+		parents = append(parents, task.ID)
+		result.TaskIDs = append(result.TaskIDs, task.ID)
+	}
+	return result, nil
+}
@@ -0,0 +1,152 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func TestTemplate_roundTrip(t *testing.T) {
+	sections := []models.Section{
+		{ID: "s2", Name: "Later", Order: 2},
+		{ID: "s1", Name: "Prep", Order: 1},
+	}
+	tasks := []models.Task{
+		{ID: "1", Content: "Book venue", SectionID: "s1", Priority: 4, Order: 1, Labels: []string{"calls"},
+			Due: &models.DueDate{Date: "2026-10-20", String: "next tue"}},
+		{ID: "2", Content: "Compare quotes", SectionID: "s1", ParentID: "1", Order: 1,
+			Description: "At least three", Duration: &models.Duration{Amount: 30, Unit: "minute"}},
+		{ID: "3", Content: "Send invites", SectionID: "s2", Priority: 2, Deadline: &models.Deadline{Date: "2026-11-01"}},
+		{ID: "4", Content: "Set budget", Priority: 1},
+	}
+
+	var buf bytes.Buffer
+	if err := BuildTemplate(sections, tasks).WriteCSV(&buf); err != nil {
+		t.Fatal(err)
+	}
+	want := `TYPE,CONTENT,DESCRIPTION,PRIORITY,INDENT,AUTHOR,RESPONSIBLE,DATE,DATE_LANG,TIMEZONE,DURATION,DURATION_UNIT,DEADLINE,DEADLINE_LANG
+task,Set budget,,4,1,,,,,,,,,
+section,Prep,,,,,,,,,,,,
+task,Book venue @calls,,1,1,,,next tue,,,,,,
+task,Compare quotes,At least three,4,2,,,,,,30,minute,,
+section,Later,,,,,,,,,,,,
+task,Send invites,,3,1,,,,,,,,2026-11-01,
+`
+	if buf.String() != want {
+		t.Fatalf("CSV =\n%s\nwant\n%s", buf.String(), want)
+	}
+
+	tmpl, err := ParseTemplate(&buf)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var got []string
+	for _, it := range tmpl.Items {
+		got = append(got, fmt.Sprintf("%s:%s:p%d:i%d:%v", it.Type, it.Content, it.Priority, it.Indent, it.Labels))
+	}
+	wantItems := []string{
+		"task:Set budget:p1:i1:[]",
+		"section:Prep:p0:i0:[]",
+		"task:Book venue:p4:i1:[calls]",
+		"task:Compare quotes:p1:i2:[]",
+		"section:Later:p0:i0:[]",
+		"task:Send invites:p2:i1:[]",
+	}
+	if !reflect.DeepEqual(got, wantItems) {
+		t.Errorf("items = %v\nwant %v", got, wantItems)
+	}
+}
+
+func TestParseTemplate_errors(t *testing.T) {
+	tests := []struct {
+		csv, want string
+	}{
+		{"", "template is empty"},
+		{"CONTENT\nfoo\n", "missing the TYPE column"},
+		{"TYPE,CONTENT,PRIORITY\ntask,A,9\n", "line 2: invalid priority"},
+		{"TYPE,CONTENT,INDENT\ntask,A,1\ntask,B,3\n", "line 3: indent 3 follows a task at indent 1"},
+		{"TYPE,CONTENT,INDENT\nsection,S,\ntask,B,2\n", "line 3: indent 2"},
+		{"TYPE,CONTENT\nwidget,A\n", `line 2: unknown row type "widget"`},
+		{"TYPE,CONTENT\ntask,@only\n", "line 2: task has no content"},
+	}
+	for _, tt := range tests {
+		_, err := ParseTemplate(strings.NewReader(tt.csv))
+		if err == nil || !strings.Contains(err.Error(), tt.want) {
+			t.Errorf("ParseTemplate(%q) error = %v, want %q", tt.csv, err, tt.want)
+		}
+	}
+}
+
+func TestParseTemplate_skipsNotesAndBlankRows(t *testing.T) {
+	csv := "\ufeffType,Content,Priority,Indent\nmeta,view_style=list,,\ntask,A,,\n,,,\nnote,Remember,,\n"
+	tmpl, err := ParseTemplate(strings.NewReader(csv))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(tmpl.Items) != 1 || tmpl.Skipped != 2 || tmpl.Items[0].Priority != 1 || tmpl.Items[0].Indent != 1 {
+		t.Errorf("unexpected template: %+v", tmpl)
+	}
+}
+
+func TestImportTemplate(t *testing.T) {
+	var created []map[string]interface{}
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/sections":
+			_, _ = w.Write([]byte(`{"results":[{"id":"s1","project_id":"p1","name":"Prep"}],"next_cursor":""}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/sections":
+			_, _ = w.Write([]byte(`{"id":"s9","project_id":"p1","name":"Later"}`))
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			var body map[string]interface{}
+			_ = json.NewDecoder(r.Body).Decode(&body)
+			created = append(created, body)
+			_, _ = fmt.Fprintf(w, `{"id":"t%d","content":%q}`, len(created), body["content"])
+		default:
+			t.Errorf("unexpected %s %s", r.Method, r.URL.Path)
+		}
+	})
+	defer srv.Close()
+
+	tmpl, err := ParseTemplate(strings.NewReader(`TYPE,CONTENT,PRIORITY,INDENT,DATE
+section,prep,,,
+task,Book venue @calls,1,1,2026-10-20
+task,Compare quotes,,2,every monday
+task,Ask around,,3,
+task,Pay deposit,,1,2026-10-21T09:00:00
+section,Later,,,
+task,Send invites,,1,
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	result, err := c.ImportTemplate(tmpl, "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if result.SectionsReused != 1 || result.SectionsCreated != 1 || len(result.TaskIDs) != 5 {
+		t.Errorf("unexpected result: %+v", result)
+	}
+
+	check := func(i int, key string, want interface{}) {
+		t.Helper()
+		if got := created[i][key]; !reflect.DeepEqual(got, want) {
+			t.Errorf("task %d %s = %v, want %v", i, key, got, want)
+		}
+	}
+	check(0, "section_id", "s1")
+	check(0, "priority", float64(4))
+	check(0, "labels", []interface{}{"calls"})
+	check(0, "due_date", "2026-10-20")
+	check(1, "parent_id", "t1")
+	check(1, "due_string", "every monday")
+	check(2, "parent_id", "t2")
+	check(3, "parent_id", nil)
+	check(3, "due_datetime", "2026-10-21T09:00:00")
+	check(4, "section_id", "s9")
+}
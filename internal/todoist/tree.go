@@ -0,0 +1,141 @@
+package todoist
+
+import (
+	"sort"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// TaskNode is a task together with its subtasks.
+type TaskNode struct {
+	Task     models.Task
+	Parent   *TaskNode
+	Children []*TaskNode
+}
+
+// TaskTree is a forest of tasks linked by ParentID.
+type TaskTree struct {
+	Roots []*TaskNode
+	nodes map[string]*TaskNode
+}
+
+// BuildTaskTree links tasks into a tree using ParentID. Tasks whose parent
+// is not in the input become roots. Siblings are sorted by child order.
+func BuildTaskTree(tasks []models.Task) *TaskTree {
+	tree := &TaskTree{nodes: make(map[string]*TaskNode, len(tasks))}
+	var ordered []*TaskNode
+	for _, t := range tasks {
+		if _, dup := tree.nodes[t.ID]; dup {
+			continue
+		}
+		n := &TaskNode{Task: t}
+		tree.nodes[t.ID] = n
+		ordered = append(ordered, n)
+	}
+
+	for _, n := range ordered {
+		parent, ok := tree.nodes[n.Task.ParentID]
+		if !ok || n.Task.ParentID == "" || createsCycle(parent, n) {
+			tree.Roots = append(tree.Roots, n)
+			continue
+		}
+		n.Parent = parent
+		parent.Children = append(parent.Children, n)
+	}
+
+	sortNodes(tree.Roots)
+	for _, n := range ordered {
+		sortNodes(n.Children)
+	}
+	return tree
+}
+
+// createsCycle reports whether attaching child under parent would form a
+// loop, which can only happen with inconsistent input.
+func createsCycle(parent, child *TaskNode) bool {
+	for p := parent; p != nil; p = p.Parent {
+		if p == child {
+			return true
+		}
+	}
+	return false
+}
+
+func sortNodes(nodes []*TaskNode) {
+	sort.SliceStable(nodes, func(i, j int) bool {
+		return nodes[i].Task.Order < nodes[j].Task.Order
+	})
+}
+
+// Find returns the node for a task ID, or nil.
+func (t *TaskTree) Find(id string) *TaskNode {
+	return t.nodes[id]
+}
+
+// Siblings returns the nodes sharing n's parent, including n. For root
+// nodes these are the roots in the same project and section.
+func (t *TaskTree) Siblings(n *TaskNode) []*TaskNode {
+	if n.Parent != nil {
+		return n.Parent.Children
+	}
+	var out []*TaskNode
+	for _, r := range t.Roots {
+		if r.Task.ProjectID == n.Task.ProjectID && r.Task.SectionID == n.Task.SectionID {
+			out = append(out, r)
+		}
+	}
+	return out
+}
+
+// Walk visits every node depth-first in display order.
+func (t *TaskTree) Walk(fn func(n *TaskNode, depth int)) {
+	for _, r := range t.Roots {
+		r.Walk(fn)
+	}
+}
+
+// Walk visits n and its descendants depth-first, with depth 0 for n.
+func (n *TaskNode) Walk(fn func(n *TaskNode, depth int)) {
+	n.walk(fn, 0)
+}
+
+func (n *TaskNode) walk(fn func(n *TaskNode, depth int), depth int) {
+	fn(n, depth)
+	for _, c := range n.Children {
+		c.walk(fn, depth+1)
+	}
+}
+
+// Depth returns the nesting level of n, 0 for roots.
+func (n *TaskNode) Depth() int {
+	d := 0
+	for p := n.Parent; p != nil; p = p.Parent {
+		d++
+	}
+	return d
+}
+
+// IsAncestorOf reports whether n is a (transitive) parent of other.
+func (n *TaskNode) IsAncestorOf(other *TaskNode) bool {
+	for p := other.Parent; p != nil; p = p.Parent {
+		if p == n {
+			return true
+		}
+	}
+	return false
+}
+
+// Progress rolls up completion over all descendants of n, returning the
+// number of completed subtasks and the total number of subtasks.
+func (n *TaskNode) Progress() (completed, total int) {
+	for _, c := range n.Children {
+		total++
+		if c.Task.IsCompleted {
+			completed++
+		}
+		cc, ct := c.Progress()
+		completed += cc
+		total += ct
+	}
+	return completed, total
+}
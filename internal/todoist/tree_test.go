@@ -0,0 +1,99 @@
+package todoist
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+func treeFixture() []models.Task {
+	return []models.Task{
+		{ID: "c2", Content: "Child 2", ProjectID: "p", ParentID: "r1", Order: 2},
+		{ID: "r1", Content: "Root 1", ProjectID: "p", Order: 1},
+		{ID: "c1", Content: "Child 1", ProjectID: "p", ParentID: "r1", Order: 1, IsCompleted: true},
+		{ID: "g1", Content: "Grandchild", ProjectID: "p", ParentID: "c2", Order: 1, IsCompleted: true},
+		{ID: "r2", Content: "Root 2", ProjectID: "p", Order: 2},
+		{ID: "r3", Content: "Other section", ProjectID: "p", SectionID: "s", Order: 1},
+		{ID: "orphan", Content: "Orphan", ProjectID: "p", ParentID: "missing", Order: 3},
+	}
+}
+
+func TestBuildTaskTree(t *testing.T) {
+	tree := BuildTaskTree(treeFixture())
+
+	var roots []string
+	for _, r := range tree.Roots {
+		roots = append(roots, r.Task.ID)
+	}
+	if got := strings.Join(roots, ","); got != "r1,r3,r2,orphan" {
+		t.Errorf("roots = %s", got)
+	}
+
+	r1 := tree.Find("r1")
+	if r1 == nil || len(r1.Children) != 2 || r1.Children[0].Task.ID != "c1" {
+		t.Fatalf("unexpected r1 children: %+v", r1)
+	}
+	if d := tree.Find("g1").Depth(); d != 2 {
+		t.Errorf("depth(g1) = %d", d)
+	}
+	if !r1.IsAncestorOf(tree.Find("g1")) || tree.Find("g1").IsAncestorOf(r1) {
+		t.Error("IsAncestorOf mismatch")
+	}
+}
+
+func TestTaskNode_Progress(t *testing.T) {
+	tree := BuildTaskTree(treeFixture())
+
+	done, total := tree.Find("r1").Progress()
+	if done != 2 || total != 3 {
+		t.Errorf("progress = %d/%d, want 2/3", done, total)
+	}
+	if done, total := tree.Find("r2").Progress(); done != 0 || total != 0 {
+		t.Errorf("leaf progress = %d/%d", done, total)
+	}
+}
+
+func TestTaskTree_Siblings(t *testing.T) {
+	tree := BuildTaskTree(treeFixture())
+
+	var ids []string
+	for _, n := range tree.Siblings(tree.Find("r2")) {
+		ids = append(ids, n.Task.ID)
+	}
+	if got := strings.Join(ids, ","); got != "r1,r2,orphan" {
+		t.Errorf("root siblings = %s", got)
+	}
+
+	ids = nil
+	for _, n := range tree.Siblings(tree.Find("c2")) {
+		ids = append(ids, n.Task.ID)
+	}
+	if got := strings.Join(ids, ","); got != "c1,c2" {
+		t.Errorf("child siblings = %s", got)
+	}
+}
+
+func TestTaskTree_Walk(t *testing.T) {
+	tree := BuildTaskTree(treeFixture())
+
+	var visited []string
+	tree.Walk(func(n *TaskNode, depth int) {
+		visited = append(visited, n.Task.ID)
+	})
+	if got := strings.Join(visited, ","); got != "r1,c1,c2,g1,r3,r2,orphan" {
+		t.Errorf("walk order = %s", got)
+	}
+}
+
+func TestBuildTaskTree_cycle(t *testing.T) {
+	tree := BuildTaskTree([]models.Task{
+		{ID: "a", ParentID: "b"},
+		{ID: "b", ParentID: "a"},
+	})
+	count := 0
+	tree.Walk(func(*TaskNode, int) { count++ })
+	if count != 2 || len(tree.Roots) != 1 {
+		t.Errorf("cycle not broken: roots=%d visited=%d", len(tree.Roots), count)
+	}
+}
@@ -0,0 +1,67 @@
+package todoist
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"mime/multipart"
+	"net/textproto"
+	"net/url"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// quoteEscaper escapes a multipart filename the way mime/multipart does.
+var quoteEscaper = strings.NewReplacer("\\", "\\\\", `"`, "\\\"")
+
+// UploadFile uploads a file so it can be attached to a comment. fileType
+// is the MIME type and defaults to application/octet-stream; projectID is
+// optional and scopes the upload to a shared project.
+func (c *Client) UploadFile(fileName, fileType string, content io.Reader, projectID string) (*models.FileAttachment, error) {
+	if fileType == "" {
+		fileType = "application/octet-stream"
+	}
+
+	var buf bytes.Buffer
+	w := multipart.NewWriter(&buf)
+	if projectID != "" {
+		if err := w.WriteField("project_id", projectID); err != nil {
+			return nil, fmt.Errorf("failed to encode upload: %w", err)
+		}
+	}
+	h := textproto.MIMEHeader{}
+	h.Set("Content-Disposition", fmt.Sprintf(`form-data; name="file"; filename="%s"`, quoteEscaper.Replace(fileName)))
+	h.Set("Content-Type", fileType)
+	part, err := w.CreatePart(h)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode upload: %w", err)
+	}
+	if _, err := io.Copy(part, content); err != nil {
+		return nil, fmt.Errorf("failed to read upload: %w", err)
+	}
+	if err := w.Close(); err != nil {
+		return nil, fmt.Errorf("failed to encode upload: %w", err)
+	}
+
+	data, err := c.send("POST", "/uploads", w.FormDataContentType(), &buf)
+	if err != nil {
+		return nil, err
+	}
+
+	var a models.FileAttachment
+	if err := json.Unmarshal(data, &a); err != nil {
+		return nil, fmt.Errorf("failed to parse upload: %w", err)
+	}
+	if a.ResourceType == "" {
+		a.ResourceType = "file"
+	}
+	return &a, nil
+}
+
+// DeleteUpload removes an uploaded file that is no longer needed.
+func (c *Client) DeleteUpload(fileURL string) error {
+	_, err := c.do("DELETE", "/uploads?"+url.Values{"file_url": {fileURL}}.Encode(), nil)
+	return err
+}
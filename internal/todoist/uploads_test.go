@@ -0,0 +1,55 @@
+package todoist
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+)
+
+func TestUploadFile(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != "/uploads" {
+			t.Errorf("method=%s path=%s", r.Method, r.URL.Path)
+		}
+		if err := r.ParseMultipartForm(1 << 20); err != nil {
+			t.Fatal(err)
+		}
+		if got := r.FormValue("project_id"); got != "p1" {
+			t.Errorf("project_id = %q", got)
+		}
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer func() { _ = f.Close() }()
+		data, _ := io.ReadAll(f)
+		if hdr.Filename != `notes "v2".txt` || string(data) != "hello" || hdr.Header.Get("Content-Type") != "text/plain" {
+			t.Errorf("file=%q type=%q data=%q", hdr.Filename, hdr.Header.Get("Content-Type"), data)
+		}
+		_, _ = w.Write([]byte(`{"file_name":"notes.txt","file_size":5,"file_type":"text/plain","file_url":"https://files.example.com/notes.txt","upload_state":"completed"}`))
+	})
+	defer srv.Close()
+
+	a, err := c.UploadFile(`notes "v2".txt`, "text/plain", strings.NewReader("hello"), "p1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if a.FileURL != "https://files.example.com/notes.txt" || a.ResourceType != "file" || a.FileSize != 5 {
+		t.Errorf("unexpected attachment: %+v", a)
+	}
+}
+
+func TestDeleteUpload(t *testing.T) {
+	c, srv := testServer(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodDelete || r.URL.Query().Get("file_url") != "https://files.example.com/a b.txt" {
+			t.Errorf("method=%s url=%s", r.Method, r.URL)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	defer srv.Close()
+
+	if err := c.DeleteUpload("https://files.example.com/a b.txt"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -0,0 +1,22 @@
+package todoist
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/nsega/mcp-todoist/internal/models"
+)
+
+// GetUser returns the authenticated user.
+func (c *Client) GetUser() (*models.User, error) {
+	data, err := c.do("GET", "/user", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var user models.User
+	if err := json.Unmarshal(data, &user); err != nil {
+		return nil, fmt.Errorf("failed to parse user: %w", err)
+	}
+	return &user, nil
+}
@@ -0,0 +1,176 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+// Activity pages default to 50 events; the endpoint caps them at 100.
+const (
+	defaultActivityLimit = 50
+	maxActivityLimit     = 100
+)
+
+// activityObjectTypes maps user-facing object names to activity log types.
+var activityObjectTypes = map[string]string{
+	"task":    "item",
+	"item":    "item",
+	"project": "project",
+	"comment": "note",
+	"note":    "note",
+}
+
+type GetActivityInput struct {
+	ObjectType  string `json:"object_type,omitempty" jsonschema:"Only events on this kind of object: task, project or comment (optional)"`
+	ObjectID    string `json:"object_id,omitempty" jsonschema:"Only events on this object ID (optional)"`
+	EventType   string `json:"event_type,omitempty" jsonschema:"Only this event type, e.g. added, updated, completed, uncompleted, deleted, archived (optional)"`
+	ProjectID   string `json:"project_id,omitempty" jsonschema:"Only events inside this project (optional)"`
+	TaskID      string `json:"task_id,omitempty" jsonschema:"Only events on this task's subtasks and comments (optional)"`
+	InitiatorID string `json:"initiator_id,omitempty" jsonschema:"Only events caused by this user ID (optional)"`
+	Since       string `json:"since,omitempty" jsonschema:"Start of the date range as YYYY-MM-DD or RFC 3339 (optional)"`
+	Until       string `json:"until,omitempty" jsonschema:"End of the date range as YYYY-MM-DD or RFC 3339 (optional)"`
+	Days        int    `json:"days,omitempty" jsonschema:"Shorthand for the last N days when since is not set (optional)"`
+	Limit       int    `json:"limit,omitempty" jsonschema:"Maximum events to return, 1-100 (default 50)"`
+	Cursor      string `json:"cursor,omitempty" jsonschema:"Cursor from a previous call to fetch the next page (optional)"`
+}
+type GetActivityOutput struct {
+	Success    bool   `json:"success"`
+	Message    string `json:"message"`
+	NextCursor string `json:"next_cursor,omitempty"`
+}
+
+// parseDateArg accepts a calendar date (start of day, local time) or an
+// RFC 3339 timestamp. endOfDay moves plain dates to the end of the day so
+// that "until: 2026-10-18" includes that whole day.
+func parseDateArg(s string, endOfDay bool) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, s); err == nil {
+		return t, nil
+	}
+	t, err := time.ParseInLocation("2006-01-02", s, time.Local)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q: use YYYY-MM-DD or RFC 3339", s)
+	}
+	if endOfDay {
+		t = t.AddDate(0, 0, 1).Add(-time.Nanosecond)
+	}
+	return t, nil
+}
+
+// describeActivity renders one activity event as a list line.
+func describeActivity(e models.ActivityEvent, projectNames map[string]string) string {
+	kind := e.ObjectType
+	switch kind {
+	case "item":
+		kind = "task"
+	case "note":
+		kind = "comment"
+	}
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "- %s %s %s", e.EventDate.Local().Format("2006-01-02 15:04"), kind, e.EventType)
+	for _, key := range []string{"content", "name", "last_content", "last_name"} {
+		if v, ok := e.ExtraData[key].(string); ok && v != "" {
+			fmt.Fprintf(&sb, ": \"%s\"", v)
+			break
+		}
+	}
+	fmt.Fprintf(&sb, " (ID: %s)", e.ObjectID)
+	if e.ParentProjectID != "" {
+		if name, ok := projectNames[e.ParentProjectID]; ok {
+			fmt.Fprintf(&sb, " in %s", name)
+		} else {
+			fmt.Fprintf(&sb, " in project %s", e.ParentProjectID)
+		}
+	}
+	if e.InitiatorID != "" {
+		fmt.Fprintf(&sb, " by %s", e.InitiatorID)
+	}
+	return sb.String()
+}
+
+func registerActivityTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_activity",
+		Description: "Get the activity log: what was added, updated, completed or deleted, filtered by object, project, event type, initiator and date range",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetActivityInput) (*mcp.CallToolResult, GetActivityOutput, error) {
+		q := todoist.ActivityQuery{
+			ObjectID:        input.ObjectID,
+			EventType:       strings.ToLower(input.EventType),
+			ParentProjectID: input.ProjectID,
+			ParentItemID:    input.TaskID,
+			InitiatorID:     input.InitiatorID,
+			Limit:           input.Limit,
+			Cursor:          input.Cursor,
+		}
+		if input.ObjectType != "" {
+			typ, ok := activityObjectTypes[strings.ToLower(input.ObjectType)]
+			if !ok {
+				msg := fmt.Sprintf("Unknown object_type %q: use task, project or comment", input.ObjectType)
+				return textResult(msg, true), GetActivityOutput{Success: false, Message: msg}, nil
+			}
+			q.ObjectType = typ
+		}
+		if q.Limit <= 0 {
+			q.Limit = defaultActivityLimit
+		} else if q.Limit > maxActivityLimit {
+			q.Limit = maxActivityLimit
+		}
+
+		var err error
+		if input.Since != "" {
+			if q.Since, err = parseDateArg(input.Since, false); err != nil {
+				return textResult(err.Error(), true), GetActivityOutput{Success: false, Message: err.Error()}, nil
+			}
+		} else if input.Days > 0 {
+			now := time.Now()
+			q.Since = time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location()).AddDate(0, 0, -input.Days)
+		}
+		if input.Until != "" {
+			if q.Until, err = parseDateArg(input.Until, true); err != nil {
+				return textResult(err.Error(), true), GetActivityOutput{Success: false, Message: err.Error()}, nil
+			}
+		}
+
+		events, next, err := c.GetActivity(q)
+		if err != nil {
+			return nil, GetActivityOutput{Success: false, Message: err.Error()}, err
+		}
+
+		if len(events) == 0 {
+			msg := "No activity found"
+			if next != "" {
+				// Since/until are applied locally, so a page can be empty
+				// even though later pages still hold matching events.
+				msg = fmt.Sprintf("No matching activity on this page; more events available, pass cursor \"%s\" to continue.", next)
+			}
+			return textResult(msg, false), GetActivityOutput{Success: true, Message: msg, NextCursor: next}, nil
+		}
+
+		// Project names are a nicety; fall back to IDs if they cannot be loaded.
+		projectNames := map[string]string{}
+		if projects, err := c.GetProjects(); err == nil {
+			for _, p := range projects {
+				projectNames[p.ID] = p.Name
+			}
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "## Activity (%d events)\n\n", len(events))
+		for _, e := range events {
+			sb.WriteString(describeActivity(e, projectNames))
+			sb.WriteString("\n")
+		}
+		if next != "" {
+			fmt.Fprintf(&sb, "\nMore events available; pass cursor \"%s\" to continue.\n", next)
+		}
+
+		msg := sb.String()
+		return textResult(msg, false), GetActivityOutput{Success: true, Message: msg, NextCursor: next}, nil
+	})
+}
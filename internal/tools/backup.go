@@ -0,0 +1,107 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type BackupInput struct {
+	FilePath      string `json:"file_path,omitempty" jsonschema:"Write the backup to this file (optional; otherwise it is returned as an embedded JSON resource)"`
+	CompletedDays *int   `json:"completed_days,omitempty" jsonschema:"Include tasks completed in the last N days (default 90, 0 to skip)"`
+}
+type BackupOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type RestoreBackupInput struct {
+	FilePath string `json:"file_path" jsonschema:"Path of a backup archive written by todoist_backup or mcp-todoist backup"`
+	DryRun   bool   `json:"dry_run,omitempty" jsonschema:"Only describe the backup without creating anything"`
+}
+type RestoreBackupOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func registerBackupTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_backup",
+		Description: "Back up all projects, sections, active and completed tasks, comments and labels to a versioned JSON archive",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input BackupInput) (*mcp.CallToolResult, BackupOutput, error) {
+		days := todoist.DefaultBackupCompletedDays
+		if input.CompletedDays != nil {
+			days = *input.CompletedDays
+		}
+		if days < 0 {
+			msg := fmt.Sprintf("Invalid completed_days %d: use 0 to skip completed tasks", days)
+			return textResult(msg, true), BackupOutput{Success: false, Message: msg}, nil
+		}
+		var since time.Time
+		if days > 0 {
+			since = time.Now().AddDate(0, 0, -days)
+		}
+
+		b, err := c.CreateBackup(since)
+		if err != nil {
+			return nil, BackupOutput{Success: false, Message: err.Error()}, err
+		}
+		var buf bytes.Buffer
+		if err := b.Write(&buf); err != nil {
+			return nil, BackupOutput{Success: false, Message: err.Error()}, err
+		}
+
+		if input.FilePath == "" {
+			msg := fmt.Sprintf("Backed up %s", b.Summary())
+			result := textResult(msg, false)
+			result.Content = append(result.Content, &mcp.EmbeddedResource{Resource: &mcp.ResourceContents{
+				URI:      fmt.Sprintf("todoist://backup/%s.json", b.CreatedAt.Format("20060102T150405Z")),
+				MIMEType: "application/json",
+				Text:     buf.String(),
+			}})
+			return result, BackupOutput{Success: true, Message: msg}, nil
+		}
+
+		if err := os.WriteFile(input.FilePath, buf.Bytes(), 0o600); err != nil {
+			msg := fmt.Sprintf("Failed to write backup: %s", err.Error())
+			return textResult(msg, true), BackupOutput{Success: false, Message: msg}, nil
+		}
+		msg := fmt.Sprintf("Backed up %s to %s", b.Summary(), input.FilePath)
+		return textResult(msg, false), BackupOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_restore_backup",
+		Description: "Restore a backup archive into this account, recreating projects, sections, tasks, comments and labels with new IDs",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RestoreBackupInput) (*mcp.CallToolResult, RestoreBackupOutput, error) {
+		f, err := os.Open(input.FilePath)
+		if err != nil {
+			msg := fmt.Sprintf("Failed to open backup: %s", err.Error())
+			return textResult(msg, true), RestoreBackupOutput{Success: false, Message: msg}, nil
+		}
+		defer func() { _ = f.Close() }()
+
+		b, err := todoist.ReadBackup(f)
+		if err != nil {
+			return textResult(err.Error(), true), RestoreBackupOutput{Success: false, Message: err.Error()}, nil
+		}
+
+		if input.DryRun {
+			msg := fmt.Sprintf("Backup from %s contains %s", b.CreatedAt.Format(time.RFC3339), b.Summary())
+			return textResult(msg, false), RestoreBackupOutput{Success: true, Message: msg}, nil
+		}
+
+		result, err := c.RestoreBackup(b)
+		if err != nil {
+			msg := fmt.Sprintf("Restore stopped after %s: %s", result.Summary(), err.Error())
+			return textResult(msg, true), RestoreBackupOutput{Success: false, Message: msg}, nil
+		}
+		msg := fmt.Sprintf("Restored %s", result.Summary())
+		return textResult(msg, false), RestoreBackupOutput{Success: true, Message: msg}, nil
+	})
+}
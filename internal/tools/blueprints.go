@@ -0,0 +1,101 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type ApplyBlueprintInput struct {
+	Blueprint string `json:"blueprint,omitempty" jsonschema:"Blueprint in YAML or JSON (provide blueprint or file_path)"`
+	FilePath  string `json:"file_path,omitempty" jsonschema:"Path of a YAML or JSON blueprint file (provide blueprint or file_path)"`
+	StartDate string `json:"start_date,omitempty" jsonschema:"Reference date for relative due dates as YYYY-MM-DD (overrides the blueprint's start; required for relative dates if the blueprint has none)"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Only show the diff of what would be created or updated"`
+}
+type ApplyBlueprintOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// renderBlueprintChanges summarises changes as a diff, listing unchanged
+// objects only by count.
+func renderBlueprintChanges(changes []todoist.BlueprintChange, dryRun bool) string {
+	counts := map[string]int{}
+	var lines []string
+	for _, ch := range changes {
+		counts[ch.Op]++
+		if ch.Op != "unchanged" {
+			lines = append(lines, ch.String())
+		}
+	}
+
+	var sb strings.Builder
+	if dryRun {
+		sb.WriteString("## Blueprint Preview (dry run)\n\n")
+		fmt.Fprintf(&sb, "%d to create, %d to update, %d unchanged\n", counts["create"], counts["update"], counts["unchanged"])
+	} else {
+		sb.WriteString("## Blueprint Applied\n\n")
+		fmt.Fprintf(&sb, "%d created, %d updated, %d unchanged\n", counts["create"], counts["update"], counts["unchanged"])
+	}
+	if len(lines) > 0 {
+		sb.WriteString("\n" + strings.Join(lines, "\n") + "\n")
+	}
+	return sb.String()
+}
+
+func registerBlueprintTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_apply_blueprint",
+		Description: "Create or update projects, sub-projects, sections and tasks from a declarative YAML/JSON blueprint. " +
+			"Tasks support relative due dates (\"+3d\", \"+1w from start\", \"+2d after <id>\"), labels, priorities (p1-p4), durations, subtasks and depends_on. " +
+			"Re-applying updates existing objects instead of duplicating them; use dry_run for a diff preview",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ApplyBlueprintInput) (*mcp.CallToolResult, ApplyBlueprintOutput, error) {
+		var data []byte
+		switch {
+		case input.Blueprint != "" && input.FilePath != "":
+			msg := "Provide blueprint or file_path, not both"
+			return textResult(msg, true), ApplyBlueprintOutput{Success: false, Message: msg}, nil
+		case input.FilePath != "":
+			var err error
+			if data, err = os.ReadFile(input.FilePath); err != nil {
+				msg := fmt.Sprintf("Failed to read blueprint: %s", err.Error())
+				return textResult(msg, true), ApplyBlueprintOutput{Success: false, Message: msg}, nil
+			}
+		case input.Blueprint != "":
+			data = []byte(input.Blueprint)
+		default:
+			msg := "Provide a blueprint via blueprint or file_path"
+			return textResult(msg, true), ApplyBlueprintOutput{Success: false, Message: msg}, nil
+		}
+
+		bp, err := todoist.ParseBlueprint(data)
+		if err != nil {
+			return textResult(err.Error(), true), ApplyBlueprintOutput{Success: false, Message: err.Error()}, nil
+		}
+
+		var start time.Time
+		if input.StartDate != "" {
+			if start, err = time.ParseInLocation("2006-01-02", input.StartDate, time.Local); err != nil {
+				msg := fmt.Sprintf("Invalid start_date %q: use YYYY-MM-DD", input.StartDate)
+				return textResult(msg, true), ApplyBlueprintOutput{Success: false, Message: msg}, nil
+			}
+		}
+
+		changes, err := c.ApplyBlueprint(bp, start, input.DryRun)
+		if err != nil {
+			msg := fmt.Sprintf("Blueprint failed: %s", err.Error())
+			if len(changes) > 0 {
+				msg += "\n\n" + renderBlueprintChanges(changes, input.DryRun)
+			}
+			return textResult(msg, true), ApplyBlueprintOutput{Success: false, Message: msg}, nil
+		}
+
+		msg := renderBlueprintChanges(changes, input.DryRun)
+		return textResult(msg, false), ApplyBlueprintOutput{Success: true, Message: msg}, nil
+	})
+}
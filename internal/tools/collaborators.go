@@ -0,0 +1,328 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type GetCollaboratorsInput struct {
+	ProjectID   string `json:"project_id,omitempty" jsonschema:"List collaborators of this shared project (provide project_id or workspace)"`
+	Workspace   bool   `json:"workspace,omitempty" jsonschema:"List workspace members instead of project collaborators (optional)"`
+	WorkspaceID string `json:"workspace_id,omitempty" jsonschema:"Limit workspace members to this workspace ID (optional)"`
+}
+type GetCollaboratorsOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type ShareProjectInput struct {
+	ProjectID string `json:"project_id" jsonschema:"The project ID to share"`
+	Email     string `json:"email" jsonschema:"Email address of the person to invite"`
+	Role      string `json:"role,omitempty" jsonschema:"Role for workspace projects, e.g. ADMIN, READ_WRITE, READ_ONLY (optional)"`
+}
+type ShareProjectOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type GetInvitationsInput struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"Only list invitations for this project (optional)"`
+}
+type GetInvitationsOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type RespondInvitationInput struct {
+	InvitationID string `json:"invitation_id" jsonschema:"The received invitation ID, from todoist_get_invitations"`
+}
+type RespondInvitationOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type RevokeInvitationInput struct {
+	InvitationID string `json:"invitation_id,omitempty" jsonschema:"The ID of an invitation you sent (provide invitation_id, or project_id and email)"`
+	ProjectID    string `json:"project_id,omitempty" jsonschema:"Project of a sent invitation listed by todoist_get_invitations"`
+	Email        string `json:"email,omitempty" jsonschema:"Email address the invitation was sent to"`
+}
+type RevokeInvitationOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type RemoveCollaboratorInput struct {
+	ProjectID string `json:"project_id" jsonschema:"The shared project ID"`
+	Email     string `json:"email" jsonschema:"Email address of the collaborator to remove"`
+}
+type RemoveCollaboratorOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type LeaveProjectInput struct {
+	ProjectID string `json:"project_id" jsonschema:"The shared project ID to leave"`
+}
+type LeaveProjectOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type ChangeCollaboratorRoleInput struct {
+	ProjectID    string `json:"project_id" jsonschema:"The shared project ID"`
+	Collaborator string `json:"collaborator" jsonschema:"Name, email or user ID of the collaborator"`
+	Role         string `json:"role" jsonschema:"New role, e.g. ADMIN, READ_WRITE, READ_ONLY"`
+}
+type ChangeCollaboratorRoleOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// resolveAssignee maps a collaborator name or email in a project to a
+// user ID.
+func resolveAssignee(c *todoist.Client, projectID, query string) (string, error) {
+	if projectID == "" {
+		return "", fmt.Errorf("assigning by name requires a project; set project_id")
+	}
+	collaborators, err := c.GetProjectCollaborators(projectID)
+	if err != nil {
+		return "", err
+	}
+	match, err := todoist.MatchCollaborator(collaborators, query)
+	if err != nil {
+		return "", err
+	}
+	if match == nil {
+		return "", fmt.Errorf("no collaborator in project %s matches %q", projectID, query)
+	}
+	return match.ID, nil
+}
+
+func writeCollaborators(sb *strings.Builder, people []models.Collaborator) {
+	for _, p := range people {
+		fmt.Fprintf(sb, "- %s <%s> (ID: %s)", p.Name, p.Email, p.ID)
+		if p.Role != "" {
+			fmt.Fprintf(sb, " [%s]", p.Role)
+		}
+		sb.WriteString("\n")
+	}
+}
+
+// findInvitation looks up a received invitation by ID so its secret can
+// be sent with an accept or reject. It returns nil if none matches.
+func findInvitation(c *todoist.Client, id string) (*models.Invitation, error) {
+	invitations, err := c.GetInvitations("")
+	if err != nil {
+		return nil, err
+	}
+	for i := range invitations {
+		if invitations[i].ID == id {
+			return &invitations[i], nil
+		}
+	}
+	return nil, nil
+}
+
+func invitationProject(inv *models.Invitation) string {
+	if inv.ProjectName != "" {
+		return inv.ProjectName
+	}
+	return inv.ProjectID
+}
+
+func registerCollaboratorTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_collaborators",
+		Description: "List the collaborators of a shared project, or the members of your workspaces",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetCollaboratorsInput) (*mcp.CallToolResult, GetCollaboratorsOutput, error) {
+		var people []models.Collaborator
+		var err error
+		switch {
+		case input.Workspace || input.WorkspaceID != "":
+			people, err = c.GetWorkspaceMembers(input.WorkspaceID)
+		case input.ProjectID != "":
+			people, err = c.GetProjectCollaborators(input.ProjectID)
+		default:
+			msg := "Either project_id or workspace is required"
+			return textResult(msg, true), GetCollaboratorsOutput{Success: false, Message: msg}, nil
+		}
+		if err != nil {
+			return nil, GetCollaboratorsOutput{}, err
+		}
+
+		if len(people) == 0 {
+			msg := "No collaborators found"
+			return textResult(msg, false), GetCollaboratorsOutput{Success: true, Message: msg}, nil
+		}
+
+		var sb strings.Builder
+		writeCollaborators(&sb, people)
+		msg := strings.TrimRight(sb.String(), "\n")
+		return textResult(msg, false), GetCollaboratorsOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_share_project",
+		Description: "Share a project by inviting someone by email",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ShareProjectInput) (*mcp.CallToolResult, ShareProjectOutput, error) {
+		if err := c.ShareProject(input.ProjectID, input.Email, strings.ToUpper(input.Role)); err != nil {
+			return nil, ShareProjectOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Invited %s to project %s", input.Email, input.ProjectID)
+		return textResult(msg, false), ShareProjectOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_invitations",
+		Description: "List pending project invitations: those you have received, to accept or reject, and those pending on your shared projects, to revoke",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetInvitationsInput) (*mcp.CallToolResult, GetInvitationsOutput, error) {
+		invitations, err := c.GetInvitations(input.ProjectID)
+		if err != nil {
+			return nil, GetInvitationsOutput{}, err
+		}
+
+		if len(invitations) == 0 {
+			msg := "No pending invitations"
+			return textResult(msg, false), GetInvitationsOutput{Success: true, Message: msg}, nil
+		}
+
+		var lines []string
+		for i := range invitations {
+			inv := &invitations[i]
+			var line string
+			if inv.Sent {
+				line = fmt.Sprintf("- %s: sent to %s (revoke with project_id %s and this email)", invitationProject(inv), inv.ToEmail, inv.ProjectID)
+			} else {
+				line = fmt.Sprintf("- %s (Invitation ID: %s)", invitationProject(inv), inv.ID)
+				if inv.FromEmail != "" {
+					line += fmt.Sprintf(" from %s <%s>", inv.FromName, inv.FromEmail)
+				}
+			}
+			lines = append(lines, line)
+		}
+		msg := strings.Join(lines, "\n")
+		return textResult(msg, false), GetInvitationsOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_accept_invitation",
+		Description: "Accept a project invitation you have received",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RespondInvitationInput) (*mcp.CallToolResult, RespondInvitationOutput, error) {
+		inv, err := findInvitation(c, input.InvitationID)
+		if err != nil {
+			return nil, RespondInvitationOutput{Success: false, Message: err.Error()}, err
+		}
+		if inv == nil {
+			msg := fmt.Sprintf("No pending invitation with ID %s", input.InvitationID)
+			return textResult(msg, true), RespondInvitationOutput{Success: false, Message: msg}, nil
+		}
+		if err := c.AcceptInvitation(inv.ID, inv.Secret); err != nil {
+			return nil, RespondInvitationOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Accepted invitation to %s", invitationProject(inv))
+		return textResult(msg, false), RespondInvitationOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_reject_invitation",
+		Description: "Reject a project invitation you have received",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RespondInvitationInput) (*mcp.CallToolResult, RespondInvitationOutput, error) {
+		inv, err := findInvitation(c, input.InvitationID)
+		if err != nil {
+			return nil, RespondInvitationOutput{Success: false, Message: err.Error()}, err
+		}
+		if inv == nil {
+			msg := fmt.Sprintf("No pending invitation with ID %s", input.InvitationID)
+			return textResult(msg, true), RespondInvitationOutput{Success: false, Message: msg}, nil
+		}
+		if err := c.RejectInvitation(inv.ID, inv.Secret); err != nil {
+			return nil, RespondInvitationOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Rejected invitation to %s", invitationProject(inv))
+		return textResult(msg, false), RespondInvitationOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_revoke_invitation",
+		Description: "Revoke a pending invitation you sent, by invitation ID or by project and email as listed by todoist_get_invitations; received invitations must be rejected instead",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RevokeInvitationInput) (*mcp.CallToolResult, RevokeInvitationOutput, error) {
+		var err error
+		var msg string
+		switch {
+		case input.InvitationID != "":
+			err = c.DeleteInvitation(input.InvitationID)
+			msg = fmt.Sprintf("Successfully revoked invitation: %s", input.InvitationID)
+		case input.ProjectID != "" && input.Email != "":
+			err = c.DeleteCollaborator(input.ProjectID, input.Email)
+			msg = fmt.Sprintf("Successfully revoked the invitation of %s to project %s", input.Email, input.ProjectID)
+		default:
+			msg = "Either invitation_id or project_id and email are required"
+			return textResult(msg, true), RevokeInvitationOutput{Success: false, Message: msg}, nil
+		}
+		if err != nil {
+			return nil, RevokeInvitationOutput{Success: false, Message: err.Error()}, err
+		}
+		return textResult(msg, false), RevokeInvitationOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_remove_collaborator",
+		Description: "Remove a collaborator from a shared project",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RemoveCollaboratorInput) (*mcp.CallToolResult, RemoveCollaboratorOutput, error) {
+		if err := c.DeleteCollaborator(input.ProjectID, input.Email); err != nil {
+			return nil, RemoveCollaboratorOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Removed %s from project %s", input.Email, input.ProjectID)
+		return textResult(msg, false), RemoveCollaboratorOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_leave_project",
+		Description: "Leave a project that someone else shared with you",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input LeaveProjectInput) (*mcp.CallToolResult, LeaveProjectOutput, error) {
+		if err := c.LeaveProject(input.ProjectID); err != nil {
+			return nil, LeaveProjectOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Successfully left project: %s", input.ProjectID)
+		return textResult(msg, false), LeaveProjectOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_change_collaborator_role",
+		Description: "Change a collaborator's role in a shared workspace project",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ChangeCollaboratorRoleInput) (*mcp.CallToolResult, ChangeCollaboratorRoleOutput, error) {
+		people, err := c.GetProjectCollaborators(input.ProjectID)
+		if err != nil {
+			return nil, ChangeCollaboratorRoleOutput{Success: false, Message: err.Error()}, err
+		}
+		var who *models.Collaborator
+		for i := range people {
+			if people[i].ID == input.Collaborator {
+				who = &people[i]
+			}
+		}
+		if who == nil {
+			who, err = todoist.MatchCollaborator(people, input.Collaborator)
+			if err != nil {
+				msg := err.Error()
+				return textResult(msg, true), ChangeCollaboratorRoleOutput{Success: false, Message: msg}, nil
+			}
+		}
+		if who == nil {
+			msg := fmt.Sprintf("No collaborator in project %s matches \"%s\"", input.ProjectID, input.Collaborator)
+			return textResult(msg, true), ChangeCollaboratorRoleOutput{Success: false, Message: msg}, nil
+		}
+
+		role := strings.ToUpper(input.Role)
+		if err := c.ChangeCollaboratorRole(input.ProjectID, who.ID, role); err != nil {
+			return nil, ChangeCollaboratorRoleOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Changed role of %s <%s> to %s", who.Name, who.Email, role)
+		return textResult(msg, false), ChangeCollaboratorRoleOutput{Success: true, Message: msg}, nil
+	})
+}
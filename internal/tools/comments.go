@@ -1,11 +1,18 @@
 package tools
 
 import (
+	"bytes"
 	"context"
+	"encoding/base64"
 	"fmt"
+	"mime"
+	"net/http"
+	"os"
+	"path/filepath"
 	"strings"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
 	"github.com/nsega/mcp-todoist/internal/todoist"
 )
 
@@ -18,10 +25,18 @@ type GetCommentsOutput struct {
 	Message string `json:"message"`
 }
 
+type CommentAttachmentInput struct {
+	FilePath string `json:"file_path,omitempty" jsonschema:"Path of a local file to upload (provide file_path or data)"`
+	Data     string `json:"data,omitempty" jsonschema:"Base64-encoded file contents (provide file_path or data)"`
+	FileName string `json:"file_name,omitempty" jsonschema:"File name to show in Todoist (required with data; defaults to the base name of file_path)"`
+	FileType string `json:"file_type,omitempty" jsonschema:"MIME type (optional; guessed from the name or contents)"`
+}
+
 type CreateCommentInput struct {
-	Content   string `json:"content" jsonschema:"Comment text content"`
-	TaskID    string `json:"task_id,omitempty" jsonschema:"Task ID to comment on (provide task_id or project_id)"`
-	ProjectID string `json:"project_id,omitempty" jsonschema:"Project ID to comment on (provide task_id or project_id)"`
+	Content     string                   `json:"content,omitempty" jsonschema:"Comment text content (optional when attaching files)"`
+	TaskID      string                   `json:"task_id,omitempty" jsonschema:"Task ID to comment on (provide task_id or project_id)"`
+	ProjectID   string                   `json:"project_id,omitempty" jsonschema:"Project ID to comment on (provide task_id or project_id)"`
+	Attachments []CommentAttachmentInput `json:"attachments,omitempty" jsonschema:"Files to attach; Todoist allows one per comment, so each extra file is posted as a follow-up comment (optional)"`
 }
 type CreateCommentOutput struct {
 	Success bool   `json:"success"`
@@ -45,6 +60,95 @@ type DeleteCommentOutput struct {
 	Message string `json:"message"`
 }
 
+// readAttachment loads an attachment's bytes from a local path or base64
+// data and works out its name and MIME type.
+func readAttachment(a CommentAttachmentInput) (name, fileType string, data []byte, err error) {
+	name = a.FileName
+	switch {
+	case a.FilePath != "" && a.Data != "":
+		return "", "", nil, fmt.Errorf("attachment: provide file_path or data, not both")
+	case a.FilePath != "":
+		data, err = os.ReadFile(a.FilePath)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("attachment: %w", err)
+		}
+		if name == "" {
+			name = filepath.Base(a.FilePath)
+		}
+	case a.Data != "":
+		data, err = base64.StdEncoding.DecodeString(a.Data)
+		if err != nil {
+			return "", "", nil, fmt.Errorf("attachment %q: invalid base64 data: %w", name, err)
+		}
+		if name == "" {
+			return "", "", nil, fmt.Errorf("attachment: file_name is required with data")
+		}
+	default:
+		return "", "", nil, fmt.Errorf("attachment: provide file_path or data")
+	}
+
+	fileType = a.FileType
+	if fileType == "" {
+		fileType = mime.TypeByExtension(filepath.Ext(name))
+	}
+	if fileType == "" {
+		fileType = http.DetectContentType(data)
+	}
+	return name, fileType, data, nil
+}
+
+// attachmentLink exposes a comment attachment as an MCP resource link so
+// clients can download it.
+func attachmentLink(a *models.FileAttachment) *mcp.ResourceLink {
+	uri := a.FileURL
+	if uri == "" {
+		uri = a.URL
+	}
+	if uri == "" {
+		return nil
+	}
+	name := a.FileName
+	if name == "" {
+		name = a.Title
+	}
+	if name == "" {
+		name = uri
+	}
+	link := &mcp.ResourceLink{URI: uri, Name: name, MIMEType: a.FileType}
+	if a.FileSize > 0 {
+		size := a.FileSize
+		link.Size = &size
+	}
+	return link
+}
+
+// withAttachmentLinks appends resource links for the comments' attachments
+// to a tool result.
+func withAttachmentLinks(result *mcp.CallToolResult, comments []models.Comment) *mcp.CallToolResult {
+	for _, cm := range comments {
+		if cm.Attachment == nil {
+			continue
+		}
+		if link := attachmentLink(cm.Attachment); link != nil {
+			result.Content = append(result.Content, link)
+		}
+	}
+	return result
+}
+
+// renderCreatedComments lists created comments one per line.
+func renderCreatedComments(created []models.Comment) string {
+	var sb strings.Builder
+	for _, cm := range created {
+		fmt.Fprintf(&sb, "Comment created (ID: %s): %s", cm.ID, cm.Content)
+		if cm.Attachment != nil {
+			fmt.Fprintf(&sb, " [Attachment: %s]", cm.Attachment.FileName)
+		}
+		sb.WriteString("\n")
+	}
+	return strings.TrimRight(sb.String(), "\n")
+}
+
 func registerCommentTools(s *mcp.Server, c *todoist.Client) {
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "todoist_get_comments",
@@ -62,31 +166,88 @@ func registerCommentTools(s *mcp.Server, c *todoist.Client) {
 
 		var lines []string
 		for _, cm := range comments {
-			lines = append(lines, fmt.Sprintf("- [%s] %s (ID: %s)", cm.PostedAt.Format("2006-01-02 15:04"), cm.Content, cm.ID))
+			line := fmt.Sprintf("- [%s] %s (ID: %s)", cm.PostedAt.Format("2006-01-02 15:04"), cm.Content, cm.ID)
+			if a := cm.Attachment; a != nil {
+				name := a.FileName
+				if name == "" {
+					name = a.Title
+				}
+				line += fmt.Sprintf(" [Attachment: %s]", name)
+			}
+			lines = append(lines, line)
 		}
 		msg := strings.Join(lines, "\n")
-		return textResult(msg, false), GetCommentsOutput{Success: true, Message: msg}, nil
+		return withAttachmentLinks(textResult(msg, false), comments), GetCommentsOutput{Success: true, Message: msg}, nil
 	})
 
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "todoist_create_comment",
 		Description: "Add a comment to a task or project",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateCommentInput) (*mcp.CallToolResult, CreateCommentOutput, error) {
-		body := map[string]interface{}{"content": input.Content}
-		if input.TaskID != "" {
-			body["task_id"] = input.TaskID
+		if input.Content == "" && len(input.Attachments) == 0 {
+			msg := "Provide content or at least one attachment"
+			return textResult(msg, true), CreateCommentOutput{Success: false, Message: msg}, nil
+		}
+
+		// Read everything up front so a bad path fails before anything is posted.
+		type upload struct {
+			name, fileType string
+			data           []byte
 		}
-		if input.ProjectID != "" {
-			body["project_id"] = input.ProjectID
+		var uploads []upload
+		for _, a := range input.Attachments {
+			name, fileType, data, err := readAttachment(a)
+			if err != nil {
+				return textResult(err.Error(), true), CreateCommentOutput{Success: false, Message: err.Error()}, nil
+			}
+			uploads = append(uploads, upload{name, fileType, data})
 		}
 
-		cm, err := c.CreateComment(body)
-		if err != nil {
-			return nil, CreateCommentOutput{Success: false, Message: err.Error()}, err
+		var created []models.Comment
+		// fail reports the error; once comments are posted they are listed
+		// too, so the caller can see and remove what was left behind.
+		fail := func(err error) (*mcp.CallToolResult, CreateCommentOutput, error) {
+			if len(created) == 0 {
+				return nil, CreateCommentOutput{Success: false, Message: err.Error()}, err
+			}
+			msg := fmt.Sprintf("Creating comments failed after %d of %d: %s\n\n%s", len(created), max(1, len(uploads)), err.Error(), renderCreatedComments(created))
+			return textResult(msg, true), CreateCommentOutput{Success: false, Message: msg}, nil
+		}
+		for i := 0; i < max(1, len(uploads)); i++ {
+			body := map[string]interface{}{"content": input.Content}
+			if input.TaskID != "" {
+				body["task_id"] = input.TaskID
+			}
+			if input.ProjectID != "" {
+				body["project_id"] = input.ProjectID
+			}
+			var a *models.FileAttachment
+			if i < len(uploads) {
+				u := uploads[i]
+				var err error
+				a, err = c.UploadFile(u.name, u.fileType, bytes.NewReader(u.data), input.ProjectID)
+				if err != nil {
+					return fail(err)
+				}
+				body["attachment"] = a
+				if i > 0 || input.Content == "" {
+					body["content"] = u.name
+				}
+			}
+
+			cm, err := c.CreateComment(body)
+			if err != nil {
+				// Don't leave an orphaned upload behind.
+				if a != nil {
+					_ = c.DeleteUpload(a.FileURL)
+				}
+				return fail(err)
+			}
+			created = append(created, *cm)
 		}
 
-		msg := fmt.Sprintf("Comment created (ID: %s): %s", cm.ID, cm.Content)
-		return textResult(msg, false), CreateCommentOutput{Success: true, Message: msg}, nil
+		msg := renderCreatedComments(created)
+		return withAttachmentLinks(textResult(msg, false), created), CreateCommentOutput{Success: true, Message: msg}, nil
 	})
 
 	mcp.AddTool(s, &mcp.Tool{
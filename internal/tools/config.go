@@ -0,0 +1,59 @@
+package tools
+
+import (
+	"os"
+	"strconv"
+	"strings"
+
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+// Workflow settings come from the environment so they can be set next to
+// TODOIST_API_TOKEN in the MCP client's server configuration. Tool inputs
+// override them per call.
+
+// envString returns the variable's value, or def if it is unset or blank.
+func envString(name, def string) string {
+	if v := strings.TrimSpace(os.Getenv(name)); v != "" {
+		return v
+	}
+	return def
+}
+
+// envInt returns the variable as a positive integer, or def if it is
+// unset or invalid.
+func envInt(name string, def int) int {
+	if n, err := strconv.Atoi(strings.TrimSpace(os.Getenv(name))); err == nil && n > 0 {
+		return n
+	}
+	return def
+}
+
+// envList returns a comma-separated variable as a list of labels, without
+// leading @ signs.
+func envList(name string) []string {
+	return splitLabels(os.Getenv(name))
+}
+
+// splitLabels splits a comma-separated list of labels, dropping blanks and
+// leading @ signs.
+func splitLabels(s string) []string {
+	var out []string
+	for _, l := range strings.Split(s, ",") {
+		if l = strings.TrimPrefix(strings.TrimSpace(l), "@"); l != "" {
+			out = append(out, l)
+		}
+	}
+	return out
+}
+
+// gtdConfig returns the GTD list labels and Someday project.
+func gtdConfig() todoist.GTDConfig {
+	return todoist.GTDConfig{
+		Contexts:       envList("TODOIST_CONTEXT_LABELS"),
+		NextLabel:      envString("TODOIST_NEXT_LABEL", "next"),
+		WaitingLabel:   envString("TODOIST_WAITING_LABEL", "waiting"),
+		SomedayLabel:   envString("TODOIST_SOMEDAY_LABEL", "someday"),
+		SomedayProject: envString("TODOIST_SOMEDAY_PROJECT", "Someday"),
+	}
+}
@@ -0,0 +1,127 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type ExportTasksInput struct {
+	ProjectID string   `json:"project_id,omitempty" jsonschema:"Only export tasks in this project (optional)"`
+	Filter    string   `json:"filter,omitempty" jsonschema:"Todoist filter query such as 'overdue' or 'p1 & #Work' (optional)"`
+	Label     string   `json:"label,omitempty" jsonschema:"Only export tasks with this label (optional)"`
+	Format    string   `json:"format,omitempty" jsonschema:"csv, jsonl (JSON lines) or markdown (default csv)"`
+	Columns   []string `json:"columns,omitempty" jsonschema:"Columns to include, in order (default content, project, section, priority, due, labels). Available: id, content, description, project, section, parent, priority, due, due_string, recurring, duration, deadline, labels, assignee_id, comment_count, created_at, url"`
+	FilePath  string   `json:"file_path,omitempty" jsonschema:"Write the export to this file (optional; otherwise it is returned as an embedded resource)"`
+}
+type ExportTasksOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// exportFormats maps export formats to file extensions and MIME types.
+var exportFormats = map[string][2]string{
+	"csv":      {"csv", "text/csv"},
+	"jsonl":    {"jsonl", "application/x-ndjson"},
+	"markdown": {"md", "text/markdown"},
+}
+
+func registerExportTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_export_tasks",
+		Description: "Export tasks selected by project, filter and/or label as CSV, JSON lines or a markdown report, " +
+			"with chosen columns and project, section and parent names instead of IDs",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ExportTasksInput) (*mcp.CallToolResult, ExportTasksOutput, error) {
+		format := strings.ToLower(input.Format)
+		if format == "" {
+			format = "csv"
+		}
+		if format == "json" || format == "ndjson" {
+			format = "jsonl"
+		}
+		if format == "md" {
+			format = "markdown"
+		}
+		kind, ok := exportFormats[format]
+		if !ok {
+			msg := fmt.Sprintf("Unknown format %q: use csv, jsonl or markdown", input.Format)
+			return textResult(msg, true), ExportTasksOutput{Success: false, Message: msg}, nil
+		}
+		if err := todoist.ValidateExportColumns(input.Columns); err != nil {
+			return textResult(err.Error(), true), ExportTasksOutput{Success: false, Message: err.Error()}, nil
+		}
+
+		tasks, err := c.GetTasks(input.ProjectID, input.Filter)
+		if err != nil {
+			return nil, ExportTasksOutput{Success: false, Message: err.Error()}, err
+		}
+		if input.Label != "" {
+			filtered := tasks[:0]
+			for _, t := range tasks {
+				if todoist.HasLabel(t.Labels, input.Label) {
+					filtered = append(filtered, t)
+				}
+			}
+			tasks = filtered
+		}
+
+		// Parents can fall outside the selection, so resolve their names
+		// from all active tasks.
+		names := todoist.ExportNames{Projects: map[string]string{}, Sections: map[string]string{}, Tasks: map[string]string{}}
+		for _, t := range tasks {
+			if t.ParentID != "" {
+				all, err := c.GetTasks("", "")
+				if err != nil {
+					return nil, ExportTasksOutput{Success: false, Message: err.Error()}, err
+				}
+				for _, t := range all {
+					names.Tasks[t.ID] = t.Content
+				}
+				break
+			}
+		}
+
+		projects, err := c.GetProjects()
+		if err != nil {
+			return nil, ExportTasksOutput{Success: false, Message: err.Error()}, err
+		}
+		for _, p := range projects {
+			names.Projects[p.ID] = p.Name
+		}
+		sections, err := c.GetSections(input.ProjectID)
+		if err != nil {
+			return nil, ExportTasksOutput{Success: false, Message: err.Error()}, err
+		}
+		for _, s := range sections {
+			names.Sections[s.ID] = s.Name
+		}
+
+		var buf bytes.Buffer
+		if err := todoist.ExportTasks(&buf, format, tasks, input.Columns, names); err != nil {
+			return nil, ExportTasksOutput{Success: false, Message: err.Error()}, err
+		}
+
+		summary := fmt.Sprintf("Exported %d tasks as %s", len(tasks), format)
+		if input.FilePath == "" {
+			result := textResult(summary, false)
+			result.Content = append(result.Content, &mcp.EmbeddedResource{Resource: &mcp.ResourceContents{
+				URI:      fmt.Sprintf("todoist://export/%s.%s", time.Now().UTC().Format("20060102T150405Z"), kind[0]),
+				MIMEType: kind[1],
+				Text:     buf.String(),
+			}})
+			return result, ExportTasksOutput{Success: true, Message: summary}, nil
+		}
+		if err := os.WriteFile(input.FilePath, buf.Bytes(), 0o644); err != nil {
+			msg := fmt.Sprintf("Failed to write export: %s", err.Error())
+			return textResult(msg, true), ExportTasksOutput{Success: false, Message: msg}, nil
+		}
+		msg := fmt.Sprintf("%s to %s", summary, input.FilePath)
+		return textResult(msg, false), ExportTasksOutput{Success: true, Message: msg}, nil
+	})
+}
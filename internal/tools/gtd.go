@@ -60,6 +60,72 @@ type BulkCreateTasksOutput struct {
 	Message string `json:"message"`
 }
 
+// --- Process Inbox Item ---
+
+type ProcessInboxItemInput struct {
+	TaskID         string   `json:"task_id,omitempty" jsonschema:"Task ID to process (preferred over task_name)"`
+	TaskName       string   `json:"task_name,omitempty" jsonschema:"Name of the task to search for and process"`
+	Action         string   `json:"action" jsonschema:"GTD decision: do (complete), delegate (assign and add the waiting label), defer (set a due date), file (move with labels), trash (delete) or project (convert to a project)"`
+	ProjectID      string   `json:"project_id,omitempty" jsonschema:"file, delegate, defer: project to move the task to (preferred over project_name)"`
+	ProjectName    string   `json:"project_name,omitempty" jsonschema:"file, delegate, defer: name of the project to move the task to"`
+	SectionID      string   `json:"section_id,omitempty" jsonschema:"file, delegate, defer: section to move the task to (optional)"`
+	Labels         []string `json:"labels,omitempty" jsonschema:"file, delegate, defer: labels to add (optional)"`
+	Assignee       string   `json:"assignee,omitempty" jsonschema:"delegate: collaborator name or email"`
+	AssigneeID     string   `json:"assignee_id,omitempty" jsonschema:"delegate: collaborator user ID (instead of assignee)"`
+	DueString      string   `json:"due_string,omitempty" jsonschema:"defer: due date in natural language; optional for file and delegate"`
+	Comment        string   `json:"comment,omitempty" jsonschema:"Comment to add to the task, or to the new project (optional)"`
+	NewProjectName string   `json:"new_project_name,omitempty" jsonschema:"project: name of the new project (default the task's content)"`
+	NextAction     string   `json:"next_action,omitempty" jsonschema:"project: first next action to create in the new project (optional)"`
+}
+type ProcessInboxItemOutput struct {
+	Success   bool   `json:"success"`
+	Message   string `json:"message"`
+	ProjectID string `json:"project_id,omitempty"`
+}
+
+// --- Next Actions ---
+
+type NextActionsInput struct {
+	Context  string   `json:"context,omitempty" jsonschema:"Only list next actions with this context label (optional)"`
+	Contexts []string `json:"contexts,omitempty" jsonschema:"Context labels to group by (default TODOIST_CONTEXT_LABELS, or every label that is not a GTD list label)"`
+}
+type NextActionsOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Total   int    `json:"total"`
+	Stalled int    `json:"stalled_projects"`
+}
+
+// --- Waiting For ---
+
+type WaitingForInput struct {
+	WaitingLabel    string `json:"waiting_label,omitempty" jsonschema:"Label marking delegated tasks (default TODOIST_WAITING_LABEL or 'waiting')"`
+	ProjectID       string `json:"project_id,omitempty" jsonschema:"Only list tasks in this project (optional)"`
+	MinDays         int    `json:"min_days,omitempty" jsonschema:"Only list tasks waiting at least this many days (optional)"`
+	FollowUpComment string `json:"follow_up_comment,omitempty" jsonschema:"Add this comment to every listed task (optional)"`
+	Remind          string `json:"remind,omitempty" jsonschema:"Add a reminder to every listed task, e.g. 'tomorrow at 9am' (optional)"`
+}
+type WaitingForOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+// waitingSources describes WaitingItem.SinceSource values.
+var waitingSources = map[string]string{"comment": "last comment", "updated": "last update", "created": "created"}
+
+// waitingAge renders how long ago a time was in whole days.
+func waitingAge(since, now time.Time) string {
+	switch days := int(now.Sub(since).Hours() / 24); days {
+	case 0:
+		return "today"
+	case 1:
+		return "1 day"
+	default:
+		return fmt.Sprintf("%d days", days)
+	}
+}
+
 func registerGTDTools(s *mcp.Server, c *todoist.Client) {
 	// --- todoist_inbox_review ---
 	mcp.AddTool(s, &mcp.Tool{
@@ -195,6 +261,9 @@ func registerGTDTools(s *mcp.Server, c *todoist.Client) {
 			}
 			fmt.Fprintf(&sb, "- %s (due: %s, ID: %s)\n", t.Content, due, t.ID)
 		}
+		if len(overdueTasks) > 0 {
+			sb.WriteString("Use todoist_reschedule_overdue to spread these over the coming days.\n")
+		}
 		sb.WriteString("\n")
 
 		// No due date.
@@ -291,4 +360,258 @@ func registerGTDTools(s *mcp.Server, c *todoist.Client) {
 		success := failed == 0
 		return textResult(msg, !success), BulkCreateTasksOutput{Success: success, Message: msg}, nil
 	})
+	// --- todoist_next_actions ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_next_actions",
+		Description: "GTD next-actions list grouped by @context: actionable tasks that are not waiting, not someday and not scheduled later, " +
+			"with only the first open subtask of a task; also reports projects without a next action",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input NextActionsInput) (*mcp.CallToolResult, NextActionsOutput, error) {
+		cfg := gtdConfig()
+		if len(input.Contexts) > 0 {
+			cfg.Contexts = splitLabels(strings.Join(input.Contexts, ","))
+		}
+		projects, err := c.GetProjects()
+		if err != nil {
+			return nil, NextActionsOutput{Success: false, Message: err.Error()}, err
+		}
+		tasks, err := c.GetTasks("", "")
+		if err != nil {
+			return nil, NextActionsOutput{Success: false, Message: err.Error()}, err
+		}
+		na := todoist.FindNextActions(projects, tasks, cfg, time.Time{})
+		names := map[string]string{}
+		for _, p := range projects {
+			names[p.ID] = p.Name
+		}
+
+		var sb strings.Builder
+		want := strings.TrimPrefix(input.Context, "@")
+		total := na.Total
+		if want != "" {
+			total = 0
+			for _, key := range na.Contexts {
+				if strings.EqualFold(key, want) {
+					total = len(na.ByContext[key])
+				}
+			}
+		}
+		fmt.Fprintf(&sb, "## Next Actions (%d)\n", total)
+		for _, key := range na.Contexts {
+			if want != "" && !strings.EqualFold(key, want) {
+				continue
+			}
+			title := "@" + key
+			if key == "" {
+				title = "No Context"
+			}
+			fmt.Fprintf(&sb, "\n### %s (%d)\n", title, len(na.ByContext[key]))
+			for _, t := range na.ByContext[key] {
+				fmt.Fprintf(&sb, "- %s (ID: %s) [%s]", t.Content, t.ID, names[t.ProjectID])
+				if t.Priority > 1 {
+					fmt.Fprintf(&sb, " [P%d]", t.Priority)
+				}
+				if t.Due != nil {
+					fmt.Fprintf(&sb, " due %s", t.Due.Date)
+				}
+				sb.WriteString("\n")
+			}
+		}
+		if total == 0 {
+			sb.WriteString("\n(none)\n")
+		}
+
+		if want == "" {
+			fmt.Fprintf(&sb, "\n## Projects Without a Next Action (%d)\n", len(na.Stalled))
+			for _, sp := range na.Stalled {
+				fmt.Fprintf(&sb, "- %s (ID: %s): ", sp.Project.Name, sp.Project.ID)
+				switch {
+				case sp.Open == 0:
+					sb.WriteString("no open tasks\n")
+				case sp.Waiting > 0:
+					fmt.Fprintf(&sb, "%d open, %d waiting\n", sp.Open, sp.Waiting)
+				default:
+					fmt.Fprintf(&sb, "%d open, none actionable\n", sp.Open)
+				}
+			}
+		}
+
+		msg := sb.String()
+		return textResult(msg, false), NextActionsOutput{Success: true, Message: msg, Total: total, Stalled: len(na.Stalled)}, nil
+	})
+	// --- todoist_waiting_for ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_waiting_for",
+		Description: "GTD waiting-for list: tasks with the waiting label or assigned to someone else, with how long since the last " +
+			"comment, update or creation; can add a follow-up comment or reminder to all of them",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input WaitingForInput) (*mcp.CallToolResult, WaitingForOutput, error) {
+		cfg := gtdConfig()
+		if input.WaitingLabel != "" {
+			cfg.WaitingLabel = strings.TrimPrefix(input.WaitingLabel, "@")
+		}
+		user, err := c.GetUser()
+		if err != nil {
+			return nil, WaitingForOutput{Success: false, Message: err.Error()}, err
+		}
+		items, err := c.GetWaitingFor(input.ProjectID, cfg, user.ID)
+		if err != nil {
+			return nil, WaitingForOutput{Success: false, Message: err.Error()}, err
+		}
+		now := time.Now()
+		if input.MinDays > 0 {
+			var kept []todoist.WaitingItem
+			for _, it := range items {
+				if now.Sub(it.Since) >= time.Duration(input.MinDays)*24*time.Hour {
+					kept = append(kept, it)
+				}
+			}
+			items = kept
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "## Waiting For (%d)\n", len(items))
+		if len(items) == 0 {
+			sb.WriteString("(none)\n")
+		}
+		for _, it := range items {
+			why := "@" + cfg.WaitingLabel
+			if it.Assigned {
+				why = "assigned to " + it.Task.AssigneeID
+			}
+			fmt.Fprintf(&sb, "- %s (ID: %s) [%s]: %s since %s\n", it.Task.Content, it.Task.ID, why, waitingAge(it.Since, now), waitingSources[it.SinceSource])
+		}
+
+		var commented, reminded int
+		var failed []string
+		for _, it := range items {
+			if input.FollowUpComment != "" {
+				if _, err := c.CreateComment(map[string]interface{}{"task_id": it.Task.ID, "content": input.FollowUpComment}); err != nil {
+					failed = append(failed, fmt.Sprintf("comment on %s (ID: %s): %v", it.Task.Content, it.Task.ID, err))
+				} else {
+					commented++
+				}
+			}
+			if input.Remind != "" {
+				body := parseReminderPhrase(input.Remind)
+				body["item_id"] = it.Task.ID
+				if _, err := c.CreateReminder(body); err != nil {
+					failed = append(failed, fmt.Sprintf("reminder on %s (ID: %s): %v", it.Task.Content, it.Task.ID, err))
+				} else {
+					reminded++
+				}
+			}
+		}
+		if input.FollowUpComment != "" {
+			fmt.Fprintf(&sb, "\nFollow-up comment added to %d tasks\n", commented)
+		}
+		if input.Remind != "" {
+			fmt.Fprintf(&sb, "\nReminder (%s) added to %d tasks\n", input.Remind, reminded)
+		}
+		if len(failed) > 0 {
+			sb.WriteString("\n### Failed\n- " + strings.Join(failed, "\n- ") + "\n")
+		}
+
+		msg := sb.String()
+		success := len(failed) == 0
+		return textResult(msg, !success), WaitingForOutput{Success: success, Message: msg, Count: len(items)}, nil
+	})
+	// --- todoist_process_inbox_item ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_process_inbox_item",
+		Description: "Apply a GTD decision to an inbox task: do (complete), delegate (assign + waiting label), " +
+			"defer (due date), file (move to project/section with labels), trash (delete) or project (convert to a project, moving the task in as its first action). " +
+			"If only part of the decision can be applied, reports the steps that were",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ProcessInboxItemInput) (*mcp.CallToolResult, ProcessInboxItemOutput, error) {
+		d := todoist.InboxDecision{
+			Action:         strings.ToLower(strings.TrimSpace(input.Action)),
+			ProjectID:      input.ProjectID,
+			SectionID:      input.SectionID,
+			Labels:         splitLabels(strings.Join(input.Labels, ",")),
+			AssigneeID:     input.AssigneeID,
+			DueString:      input.DueString,
+			Comment:        input.Comment,
+			NewProjectName: input.NewProjectName,
+			NextAction:     input.NextAction,
+		}
+		task, notFound, err := findTask(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, ProcessInboxItemOutput{Success: false, Message: err.Error()}, err
+		}
+		if task == nil {
+			return textResult(notFound, true), ProcessInboxItemOutput{Success: false, Message: notFound}, nil
+		}
+		if d.ProjectID == "" && input.ProjectName != "" {
+			d.ProjectID, _, err = resolveProjectID(c, "", input.ProjectName)
+			if err != nil {
+				return nil, ProcessInboxItemOutput{Success: false, Message: err.Error()}, err
+			}
+			if d.ProjectID == "" {
+				msg := fmt.Sprintf("Could not find a project matching \"%s\"", input.ProjectName)
+				return textResult(msg, true), ProcessInboxItemOutput{Success: false, Message: msg}, nil
+			}
+		}
+		if d.Action == "delegate" && d.AssigneeID == "" && input.Assignee != "" {
+			// Assignees must be collaborators of the project the task ends up in.
+			projectID := d.ProjectID
+			if projectID == "" {
+				projectID = task.ProjectID
+			}
+			if d.AssigneeID, err = resolveAssignee(c, projectID, input.Assignee); err != nil {
+				return textResult(err.Error(), true), ProcessInboxItemOutput{Success: false, Message: err.Error()}, nil
+			}
+		}
+		if err := d.Validate(); err != nil {
+			return textResult(err.Error(), true), ProcessInboxItemOutput{Success: false, Message: err.Error()}, nil
+		}
+
+		cfg := gtdConfig()
+		result, err := c.ProcessInboxItem(*task, d, cfg)
+		if err != nil && result != nil {
+			msg := fmt.Sprintf("Inbox item \"%s\" (ID: %s) was only partly processed: %s\nApplied: %s",
+				task.Content, task.ID, err.Error(), strings.Join(result.Applied, "; "))
+			return textResult(msg, true), ProcessInboxItemOutput{Success: false, Message: msg, ProjectID: result.ProjectID}, nil
+		}
+		if err != nil {
+			return nil, ProcessInboxItemOutput{Success: false, Message: err.Error()}, err
+		}
+
+		var what string
+		switch d.Action {
+		case "do":
+			what = "completed"
+		case "trash":
+			what = "deleted"
+		case "delegate":
+			what = fmt.Sprintf("delegated to %s and labelled @%s", d.AssigneeID, cfg.WaitingLabel)
+		case "defer":
+			what = "deferred to " + d.DueString
+		case "file":
+			what = "filed"
+		case "project":
+			what = fmt.Sprintf("moved into new project (ID: %s) as its first action", result.ProjectID)
+			if result.Moved > 0 {
+				what += fmt.Sprintf(", with %d subtasks", result.Moved)
+			}
+			if result.NextID != "" {
+				what += fmt.Sprintf("; next action \"%s\" (ID: %s)", d.NextAction, result.NextID)
+			}
+		}
+		if d.Action != "project" {
+			if d.SectionID != "" {
+				what += " into section " + d.SectionID
+			} else if d.ProjectID != "" && d.ProjectID != task.ProjectID {
+				what += " into project " + d.ProjectID
+			}
+			if len(d.Labels) > 0 {
+				what += " with @" + strings.Join(d.Labels, ", @")
+			}
+			if d.DueString != "" && d.Action != "defer" {
+				what += ", due " + d.DueString
+			}
+		}
+		msg := fmt.Sprintf("Inbox item \"%s\" (ID: %s) %s", task.Content, task.ID, what)
+		if d.Comment != "" && d.Action != "trash" {
+			msg += "; comment added"
+		}
+		return textResult(msg, false), ProcessInboxItemOutput{Success: true, Message: msg, ProjectID: result.ProjectID}, nil
+	})
 }
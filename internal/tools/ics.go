@@ -0,0 +1,76 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type ExportICSInput struct {
+	ProjectID string `json:"project_id,omitempty" jsonschema:"Only export tasks in this project (optional)"`
+	Filter    string `json:"filter,omitempty" jsonschema:"Todoist filter query such as 'today | overdue' or '@work' (optional)"`
+	Events    bool   `json:"events,omitempty" jsonschema:"Export dated tasks as calendar events (VEVENT) instead of to-dos (VTODO)"`
+	FilePath  string `json:"file_path,omitempty" jsonschema:"Write the .ics file here (optional; otherwise it is returned as an embedded text/calendar resource)"`
+}
+type ExportICSOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// describeICSResult summarises an export, noting what could not be
+// represented.
+func describeICSResult(r todoist.ICSResult, events bool) string {
+	kind := "to-dos"
+	if events {
+		kind = "events"
+	}
+	s := fmt.Sprintf("Exported %d tasks as %s", r.Exported, kind)
+	if r.Undated > 0 {
+		s += fmt.Sprintf(", skipped %d without a due date", r.Undated)
+	}
+	if r.NoRRule > 0 {
+		s += fmt.Sprintf(" (%d recurring patterns have no iCalendar equivalent and were exported as single occurrences)", r.NoRRule)
+	}
+	return s
+}
+
+func registerICSTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_export_ics",
+		Description: "Export tasks to an iCalendar (.ics) file as VTODO to-dos or VEVENT events, keeping due times, timezones, durations " +
+			"and recurrence (as RRULE where expressible), for import into calendar apps",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ExportICSInput) (*mcp.CallToolResult, ExportICSOutput, error) {
+		tasks, err := c.GetTasks(input.ProjectID, input.Filter)
+		if err != nil {
+			return nil, ExportICSOutput{Success: false, Message: err.Error()}, err
+		}
+
+		var buf bytes.Buffer
+		result, err := todoist.WriteICS(&buf, tasks, todoist.ICSOptions{Events: input.Events, Name: "Todoist"})
+		if err != nil {
+			return nil, ExportICSOutput{Success: false, Message: err.Error()}, err
+		}
+		summary := describeICSResult(result, input.Events)
+
+		if input.FilePath == "" {
+			res := textResult(summary, false)
+			res.Content = append(res.Content, &mcp.EmbeddedResource{Resource: &mcp.ResourceContents{
+				URI:      fmt.Sprintf("todoist://export/%s.ics", time.Now().UTC().Format("20060102T150405Z")),
+				MIMEType: "text/calendar",
+				Text:     buf.String(),
+			}})
+			return res, ExportICSOutput{Success: true, Message: summary}, nil
+		}
+		if err := os.WriteFile(input.FilePath, buf.Bytes(), 0o644); err != nil {
+			msg := fmt.Sprintf("Failed to write calendar: %s", err.Error())
+			return textResult(msg, true), ExportICSOutput{Success: false, Message: msg}, nil
+		}
+		msg := fmt.Sprintf("%s to %s", summary, input.FilePath)
+		return textResult(msg, false), ExportICSOutput{Success: true, Message: msg}, nil
+	})
+}
@@ -9,7 +9,9 @@ import (
 	"github.com/nsega/mcp-todoist/internal/todoist"
 )
 
-type GetLabelsInput struct{}
+type GetLabelsInput struct {
+	IncludeShared bool `json:"include_shared,omitempty" jsonschema:"Also list shared labels used in shared projects (optional)"`
+}
 type GetLabelsOutput struct {
 	Success bool   `json:"success"`
 	Message string `json:"message"`
@@ -43,6 +45,33 @@ type DeleteLabelOutput struct {
 	Message string `json:"message"`
 }
 
+type RenameSharedLabelInput struct {
+	Name    string `json:"name" jsonschema:"Current name of the shared label"`
+	NewName string `json:"new_name" jsonschema:"New name for the shared label"`
+}
+type RenameSharedLabelOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type RemoveSharedLabelInput struct {
+	Name string `json:"name" jsonschema:"Name of the shared label to remove from all tasks"`
+}
+type RemoveSharedLabelOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type MergeLabelsInput struct {
+	Source     string `json:"source" jsonschema:"Name of the label to fold into the target"`
+	Target     string `json:"target" jsonschema:"Name of the label to keep"`
+	KeepSource bool   `json:"keep_source,omitempty" jsonschema:"Keep the source personal label after merging instead of deleting it (optional)"`
+}
+type MergeLabelsOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
 func registerLabelTools(s *mcp.Server, c *todoist.Client) {
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "todoist_get_labels",
@@ -53,7 +82,15 @@ func registerLabelTools(s *mcp.Server, c *todoist.Client) {
 			return nil, GetLabelsOutput{}, err
 		}
 
-		if len(labels) == 0 {
+		var shared []string
+		if input.IncludeShared {
+			shared, err = c.GetSharedLabels(true)
+			if err != nil {
+				return nil, GetLabelsOutput{}, err
+			}
+		}
+
+		if len(labels) == 0 && len(shared) == 0 {
 			msg := "No labels found"
 			return textResult(msg, false), GetLabelsOutput{Success: true, Message: msg}, nil
 		}
@@ -66,6 +103,9 @@ func registerLabelTools(s *mcp.Server, c *todoist.Client) {
 			}
 			lines = append(lines, line)
 		}
+		for _, name := range shared {
+			lines = append(lines, fmt.Sprintf("- %s [Shared]", name))
+		}
 		msg := strings.Join(lines, "\n")
 		return textResult(msg, false), GetLabelsOutput{Success: true, Message: msg}, nil
 	})
@@ -93,8 +133,22 @@ func registerLabelTools(s *mcp.Server, c *todoist.Client) {
 
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "todoist_update_label",
-		Description: "Update an existing label",
+		Description: "Update an existing label. Renaming also updates every active task that uses the old name",
 	}, func(ctx context.Context, req *mcp.CallToolRequest, input UpdateLabelInput) (*mcp.CallToolResult, UpdateLabelOutput, error) {
+		// Look up the current name so tasks can be relabelled after a rename.
+		var oldName string
+		if input.Name != "" {
+			labels, err := c.GetLabels()
+			if err != nil {
+				return nil, UpdateLabelOutput{Success: false, Message: err.Error()}, err
+			}
+			for _, l := range labels {
+				if l.ID == input.LabelID {
+					oldName = l.Name
+				}
+			}
+		}
+
 		body := map[string]interface{}{}
 		if input.Name != "" {
 			body["name"] = input.Name
@@ -109,6 +163,14 @@ func registerLabelTools(s *mcp.Server, c *todoist.Client) {
 		}
 
 		msg := fmt.Sprintf("Label updated: %s (ID: %s)", l.Name, l.ID)
+		if oldName != "" && oldName != l.Name {
+			n, err := c.ReplaceLabelOnTasks(oldName, l.Name)
+			if err != nil {
+				msg += fmt.Sprintf("\nFailed to relabel tasks after %d updates: %s", n, err.Error())
+				return textResult(msg, true), UpdateLabelOutput{Success: false, Message: msg}, nil
+			}
+			msg += fmt.Sprintf("\nRelabelled %d tasks from \"%s\" to \"%s\"", n, oldName, l.Name)
+		}
 		return textResult(msg, false), UpdateLabelOutput{Success: true, Message: msg}, nil
 	})
 
@@ -122,4 +184,64 @@ func registerLabelTools(s *mcp.Server, c *todoist.Client) {
 		msg := fmt.Sprintf("Successfully deleted label: %s", input.LabelID)
 		return textResult(msg, false), DeleteLabelOutput{Success: true, Message: msg}, nil
 	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_rename_shared_label",
+		Description: "Rename a shared label on all tasks that use it",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RenameSharedLabelInput) (*mcp.CallToolResult, RenameSharedLabelOutput, error) {
+		if err := c.RenameSharedLabel(input.Name, input.NewName); err != nil {
+			return nil, RenameSharedLabelOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Shared label renamed: %s -> %s", input.Name, input.NewName)
+		return textResult(msg, false), RenameSharedLabelOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_remove_shared_label",
+		Description: "Remove a shared label from all tasks that use it",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RemoveSharedLabelInput) (*mcp.CallToolResult, RemoveSharedLabelOutput, error) {
+		if err := c.RemoveSharedLabel(input.Name); err != nil {
+			return nil, RemoveSharedLabelOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Successfully removed shared label: %s", input.Name)
+		return textResult(msg, false), RemoveSharedLabelOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_merge_labels",
+		Description: "Fold one label into another: every task with the source label gets the target label instead, then the source label is deleted",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input MergeLabelsInput) (*mcp.CallToolResult, MergeLabelsOutput, error) {
+		if input.Source == "" || input.Target == "" {
+			msg := "Both source and target label names are required"
+			return textResult(msg, true), MergeLabelsOutput{Success: false, Message: msg}, nil
+		}
+		if strings.EqualFold(input.Source, input.Target) {
+			msg := "Source and target labels are the same"
+			return textResult(msg, true), MergeLabelsOutput{Success: false, Message: msg}, nil
+		}
+
+		n, err := c.ReplaceLabelOnTasks(input.Source, input.Target)
+		if err != nil {
+			msg := fmt.Sprintf("Merge stopped after relabelling %d tasks: %s", n, err.Error())
+			return textResult(msg, true), MergeLabelsOutput{Success: false, Message: msg}, nil
+		}
+		msg := fmt.Sprintf("Merged label \"%s\" into \"%s\" on %d tasks", input.Source, input.Target, n)
+
+		if !input.KeepSource {
+			labels, err := c.GetLabels()
+			if err != nil {
+				return nil, MergeLabelsOutput{Success: false, Message: err.Error()}, err
+			}
+			for _, l := range labels {
+				if strings.EqualFold(l.Name, input.Source) {
+					if err := c.DeleteLabel(l.ID); err != nil {
+						return nil, MergeLabelsOutput{Success: false, Message: err.Error()}, err
+					}
+					msg += fmt.Sprintf("\nDeleted label \"%s\" (ID: %s)", l.Name, l.ID)
+				}
+			}
+		}
+
+		return textResult(msg, false), MergeLabelsOutput{Success: true, Message: msg}, nil
+	})
 }
@@ -0,0 +1,139 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type ImportMarkdownInput struct {
+	Markdown  string `json:"markdown,omitempty" jsonschema:"Markdown text with - [ ] checklists (provide markdown or file_path)"`
+	FilePath  string `json:"file_path,omitempty" jsonschema:"Path of a markdown or plain-text file (provide markdown or file_path)"`
+	ProjectID string `json:"project_id,omitempty" jsonschema:"Project for items without a #project (default: Inbox)"`
+	DryRun    bool   `json:"dry_run,omitempty" jsonschema:"Only show how the text was parsed without creating anything"`
+}
+type ImportMarkdownOutput struct {
+	Success bool                   `json:"success"`
+	Message string                 `json:"message"`
+	Tasks   []ImportedMarkdownLine `json:"tasks,omitempty"`
+}
+
+// ImportedMarkdownLine maps a source line to the task created for it.
+type ImportedMarkdownLine struct {
+	Line   int    `json:"line"`
+	TaskID string `json:"task_id"`
+}
+
+// describeMarkdownTask renders a parsed item with its extracted attributes.
+func describeMarkdownTask(t todoist.MarkdownTask) string {
+	var attrs []string
+	if t.Priority > 1 {
+		attrs = append(attrs, fmt.Sprintf("p%d", 5-t.Priority))
+	}
+	for _, l := range t.Labels {
+		attrs = append(attrs, "@"+l)
+	}
+	if t.Due != "" {
+		attrs = append(attrs, "due "+t.Due)
+	}
+	if t.Parent < 0 && t.Project != "" {
+		attrs = append(attrs, "#"+t.Project)
+	}
+	if t.Checked {
+		attrs = append(attrs, "done")
+	}
+	s := t.Content
+	if len(attrs) > 0 {
+		s += " (" + strings.Join(attrs, ", ") + ")"
+	}
+	return s
+}
+
+// renderMarkdownPlan lists parsed items under their sections, indented
+// by depth, with created task IDs when ids is not nil.
+func renderMarkdownPlan(doc *todoist.MarkdownDoc, ids map[int]string) string {
+	var sb strings.Builder
+	depth := make([]int, len(doc.Tasks))
+	section := ""
+	for i, t := range doc.Tasks {
+		if t.Parent >= 0 {
+			depth[i] = depth[t.Parent] + 1
+		} else if t.Section != section {
+			section = t.Section
+			fmt.Fprintf(&sb, "\n### %s\n", section)
+		}
+		fmt.Fprintf(&sb, "%sL%d: %s", strings.Repeat("  ", depth[i]), t.Line, describeMarkdownTask(t))
+		if id, ok := ids[t.Line]; ok {
+			fmt.Fprintf(&sb, " → %s", id)
+		}
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+func registerMarkdownTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_import_markdown",
+		Description: "Create tasks from markdown checklists or meeting notes. Nested list items become subtasks and headings become sections; " +
+			"@label, #project (existing projects only), p1-p4 and date phrases (\"tomorrow\", \"next fri\", \"every monday at 9am\", \"2026-11-02\") are extracted from each item. " +
+			"Reports the task ID created for each line",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ImportMarkdownInput) (*mcp.CallToolResult, ImportMarkdownOutput, error) {
+		text := input.Markdown
+		switch {
+		case input.Markdown != "" && input.FilePath != "":
+			msg := "Provide markdown or file_path, not both"
+			return textResult(msg, true), ImportMarkdownOutput{Success: false, Message: msg}, nil
+		case input.FilePath != "":
+			data, err := os.ReadFile(input.FilePath)
+			if err != nil {
+				msg := fmt.Sprintf("Failed to read file: %s", err.Error())
+				return textResult(msg, true), ImportMarkdownOutput{Success: false, Message: msg}, nil
+			}
+			text = string(data)
+		case input.Markdown == "":
+			msg := "Provide text via markdown or file_path"
+			return textResult(msg, true), ImportMarkdownOutput{Success: false, Message: msg}, nil
+		}
+
+		projects, err := c.GetProjects()
+		if err != nil {
+			return nil, ImportMarkdownOutput{Success: false, Message: err.Error()}, err
+		}
+		doc := todoist.ParseMarkdown(text, projects)
+		if len(doc.Tasks) == 0 {
+			msg := "No list items found"
+			return textResult(msg, true), ImportMarkdownOutput{Success: false, Message: msg}, nil
+		}
+
+		if input.DryRun {
+			msg := fmt.Sprintf("## Markdown Import Preview (dry run)\n\n%d tasks parsed, %d lines skipped\n%s",
+				len(doc.Tasks), doc.Skipped, renderMarkdownPlan(doc, nil))
+			return textResult(msg, false), ImportMarkdownOutput{Success: true, Message: msg}, nil
+		}
+
+		result, err := c.ImportMarkdown(doc, input.ProjectID, projects)
+		ids := map[int]string{}
+		var tasks []ImportedMarkdownLine
+		if result != nil {
+			for _, l := range result.Lines {
+				ids[l.Line] = l.TaskID
+				tasks = append(tasks, ImportedMarkdownLine{Line: l.Line, TaskID: l.TaskID})
+			}
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Markdown import failed: %s", err.Error())
+			if len(tasks) > 0 {
+				msg += fmt.Sprintf("\n\n%d tasks were created:\n%s", len(tasks), renderMarkdownPlan(doc, ids))
+			}
+			return textResult(msg, true), ImportMarkdownOutput{Success: false, Message: msg, Tasks: tasks}, nil
+		}
+
+		msg := fmt.Sprintf("## Markdown Imported\n\n%d tasks created, %d sections created, %d sections reused, %d lines skipped\n%s",
+			len(result.Lines), result.SectionsCreated, result.SectionsReused, doc.Skipped, renderMarkdownPlan(doc, ids))
+		return textResult(msg, false), ImportMarkdownOutput{Success: true, Message: msg, Tasks: tasks}, nil
+	})
+}
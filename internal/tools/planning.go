@@ -0,0 +1,388 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+const (
+	defaultDailyCapacity = 360 // minutes
+	defaultTaskMinutes   = 30
+	defaultWorkStart     = "09:00"
+	defaultWorkEnd       = "17:00"
+)
+
+type DailyPlanInput struct {
+	CapacityMinutes int    `json:"capacity_minutes,omitempty" jsonschema:"Minutes of work available today (default TODOIST_DAILY_CAPACITY or 360)"`
+	NextLabel       string `json:"next_label,omitempty" jsonschema:"Label marking next actions to include (default TODOIST_NEXT_LABEL or 'next')"`
+	DefaultMinutes  int    `json:"default_minutes,omitempty" jsonschema:"Estimate for tasks without a duration (default 30)"`
+	ProjectID       string `json:"project_id,omitempty" jsonschema:"Only plan tasks in this project (optional)"`
+}
+type DailyPlanOutput struct {
+	Success       bool   `json:"success"`
+	Message       string `json:"message"`
+	TotalMinutes  int    `json:"total_minutes"`
+	Overcommitted bool   `json:"overcommitted"`
+}
+
+type ScheduleDayInput struct {
+	Date           string `json:"date,omitempty" jsonschema:"Day to schedule as YYYY-MM-DD (default today)"`
+	WorkStart      string `json:"work_start,omitempty" jsonschema:"Start of working hours as HH:MM (default TODOIST_WORK_START or 09:00)"`
+	WorkEnd        string `json:"work_end,omitempty" jsonschema:"End of working hours as HH:MM (default TODOIST_WORK_END or 17:00)"`
+	NextLabel      string `json:"next_label,omitempty" jsonschema:"Label marking next actions to include (default TODOIST_NEXT_LABEL or 'next')"`
+	DefaultMinutes int    `json:"default_minutes,omitempty" jsonschema:"Estimate for tasks without a duration (default 30)"`
+	ProjectID      string `json:"project_id,omitempty" jsonschema:"Only schedule tasks in this project (optional)"`
+	WriteBack      bool   `json:"write_back,omitempty" jsonschema:"Set each scheduled task's due_datetime (and duration if estimated); without it the schedule is only a dry-run preview"`
+}
+type ScheduleDayOutput struct {
+	Success     bool   `json:"success"`
+	Message     string `json:"message"`
+	Scheduled   int    `json:"scheduled"`
+	Unscheduled int    `json:"unscheduled"`
+	Updated     int    `json:"updated"`
+}
+
+type RescheduleOverdueInput struct {
+	Days         int                      `json:"days,omitempty" jsonschema:"Number of days, starting today, to spread tasks over (default 7)"`
+	PerDay       int                      `json:"per_day,omitempty" jsonschema:"Maximum tasks due per day, counting tasks already due then (default no cap)"`
+	SkipWeekends bool                     `json:"skip_weekends,omitempty" jsonschema:"Do not move tasks to Saturdays or Sundays"`
+	ProjectID    string                   `json:"project_id,omitempty" jsonschema:"Only reschedule overdue tasks in this project (optional)"`
+	DryRun       bool                     `json:"dry_run,omitempty" jsonschema:"Show the proposed dates without changing anything"`
+	Undo         []todoist.RescheduleMove `json:"undo,omitempty" jsonschema:"Moves from an earlier result to revert to their previous due dates; other options are ignored"`
+}
+type RescheduleOverdueOutput struct {
+	Success  bool                     `json:"success"`
+	Message  string                   `json:"message"`
+	Moves    []todoist.RescheduleMove `json:"moves,omitempty"`
+	Unplaced int                      `json:"unplaced"`
+}
+
+// rescheduleFailed reports a reschedule that stopped partway, returning
+// the moves that were applied so they can be passed back as undo.
+func rescheduleFailed(err error, applied []todoist.RescheduleMove, total int) (*mcp.CallToolResult, RescheduleOverdueOutput, error) {
+	msg := fmt.Sprintf("Updating due dates failed after %d of %d tasks: %s", len(applied), total, err.Error())
+	if len(applied) > 0 {
+		msg += "\n\nApplied (pass these moves as undo to revert them):"
+		for _, m := range applied {
+			msg += fmt.Sprintf("\n- %s (ID: %s): %s -> %s", m.Content, m.TaskID, dueText(m.From), dueText(m.To))
+		}
+	}
+	return textResult(msg, true), RescheduleOverdueOutput{Success: false, Message: msg, Moves: applied}, nil
+}
+
+// dueText describes a due date for display.
+func dueText(due models.DueDate) string {
+	if due.Datetime != "" {
+		return due.Datetime
+	}
+	return due.Date
+}
+
+// parseClock combines a date with an "HH:MM" (or "H") time of day.
+func parseClock(day time.Time, clock string) (time.Time, error) {
+	var h, m int
+	if _, err := fmt.Sscanf(clock, "%d:%d", &h, &m); err != nil {
+		m = 0
+		if _, err := fmt.Sscanf(clock, "%d", &h); err != nil || strings.Contains(clock, ":") {
+			return time.Time{}, fmt.Errorf("invalid time %q (use HH:MM)", clock)
+		}
+	}
+	if h < 0 || h > 24 || m < 0 || m > 59 || (h == 24 && m > 0) {
+		return time.Time{}, fmt.Errorf("invalid time %q (use HH:MM)", clock)
+	}
+	return time.Date(day.Year(), day.Month(), day.Day(), h, m, 0, 0, day.Location()), nil
+}
+
+// formatMinutes renders minutes as "1h 30m".
+func formatMinutes(m int) string {
+	switch {
+	case m < 60:
+		return fmt.Sprintf("%dm", m)
+	case m%60 == 0:
+		return fmt.Sprintf("%dh", m/60)
+	}
+	return fmt.Sprintf("%dh %dm", m/60, m%60)
+}
+
+// describePlanItem renders a planned task with its reasons and duration.
+func describePlanItem(it todoist.PlanItem) string {
+	est := formatMinutes(it.Minutes)
+	if it.Estimated {
+		est = "~" + est
+	}
+	return fmt.Sprintf("%s (ID: %s) [%s] %s", it.Task.Content, it.Task.ID, strings.Join(it.Reasons, ", "), est)
+}
+
+func registerPlanningTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_daily_plan",
+		Description: "Plan the day: combines overdue tasks, tasks due today, p1 tasks and tasks with the \"next\" label, " +
+			"totals their durations against a daily capacity, flags overcommitment and proposes an ordered schedule",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input DailyPlanInput) (*mcp.CallToolResult, DailyPlanOutput, error) {
+		opts := todoist.PlanOptions{
+			CapacityMinutes: input.CapacityMinutes,
+			NextLabel:       input.NextLabel,
+			DefaultMinutes:  input.DefaultMinutes,
+		}
+		if opts.CapacityMinutes <= 0 {
+			opts.CapacityMinutes = envInt("TODOIST_DAILY_CAPACITY", defaultDailyCapacity)
+		}
+		if opts.NextLabel == "" {
+			opts.NextLabel = envString("TODOIST_NEXT_LABEL", "next")
+		}
+		if opts.DefaultMinutes <= 0 {
+			opts.DefaultMinutes = defaultTaskMinutes
+		}
+
+		tasks, err := c.GetTasks(input.ProjectID, "")
+		if err != nil {
+			return nil, DailyPlanOutput{Success: false, Message: err.Error()}, err
+		}
+		plan := todoist.BuildDailyPlan(tasks, opts)
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "## Daily Plan for %s\n\n", plan.Date.Format("Monday, January 2"))
+		n := len(plan.Fixed) + len(plan.Flexible)
+		if n == 0 {
+			sb.WriteString("Nothing overdue, due today, p1 or labelled @" + opts.NextLabel + ". Enjoy the free day!\n")
+			msg := sb.String()
+			return textResult(msg, false), DailyPlanOutput{Success: true, Message: msg}, nil
+		}
+
+		fmt.Fprintf(&sb, "%d tasks, %s planned of %s capacity", n, formatMinutes(plan.TotalMinutes), formatMinutes(plan.Capacity))
+		if plan.Estimated > 0 {
+			fmt.Fprintf(&sb, " (%d without a duration estimated at %s each)", plan.Estimated, formatMinutes(opts.DefaultMinutes))
+		}
+		sb.WriteString("\n")
+		if plan.Overcommitted {
+			fmt.Fprintf(&sb, "\n**Overcommitted by %s.** Consider deferring the tasks below the capacity line.\n", formatMinutes(plan.TotalMinutes-plan.Capacity))
+		}
+
+		if len(plan.Fixed) > 0 {
+			sb.WriteString("\n### Fixed Times\n")
+			for _, it := range plan.Fixed {
+				fmt.Fprintf(&sb, "- %s %s\n", it.At.Format("15:04"), describePlanItem(it))
+			}
+		}
+		if len(plan.Flexible) > 0 {
+			sb.WriteString("\n### Proposed Order\n")
+			for i, it := range plan.Flexible {
+				if i == plan.FitsCapacity && plan.Overcommitted {
+					sb.WriteString("--- capacity reached ---\n")
+				}
+				fmt.Fprintf(&sb, "%d. %s\n", i+1, describePlanItem(it))
+			}
+		}
+
+		msg := sb.String()
+		return textResult(msg, false), DailyPlanOutput{Success: true, Message: msg, TotalMinutes: plan.TotalMinutes, Overcommitted: plan.Overcommitted}, nil
+	})
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_schedule_day",
+		Description: "Time-block a day: assigns start times within working hours to untimed overdue, due-today, p1 and \"next\" tasks " +
+			"by priority and duration, avoiding tasks already timed that day. Previews by default; write_back sets due_datetime",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ScheduleDayInput) (*mcp.CallToolResult, ScheduleDayOutput, error) {
+		day := time.Now()
+		if input.Date != "" {
+			d, err := time.ParseInLocation("2006-01-02", input.Date, time.Local)
+			if err != nil {
+				msg := fmt.Sprintf("Invalid date %q (use YYYY-MM-DD)", input.Date)
+				return textResult(msg, true), ScheduleDayOutput{Success: false, Message: msg}, nil
+			}
+			day = d
+		}
+		if input.WorkStart == "" {
+			input.WorkStart = envString("TODOIST_WORK_START", defaultWorkStart)
+		}
+		if input.WorkEnd == "" {
+			input.WorkEnd = envString("TODOIST_WORK_END", defaultWorkEnd)
+		}
+		opts := todoist.ScheduleOptions{NextLabel: input.NextLabel, DefaultMinutes: input.DefaultMinutes}
+		var err error
+		if opts.Start, err = parseClock(day, input.WorkStart); err == nil {
+			opts.End, err = parseClock(day, input.WorkEnd)
+		}
+		if err != nil {
+			return textResult(err.Error(), true), ScheduleDayOutput{Success: false, Message: err.Error()}, nil
+		}
+		if opts.NextLabel == "" {
+			opts.NextLabel = envString("TODOIST_NEXT_LABEL", "next")
+		}
+		if opts.DefaultMinutes <= 0 {
+			opts.DefaultMinutes = defaultTaskMinutes
+		}
+
+		tasks, err := c.GetTasks(input.ProjectID, "")
+		if err != nil {
+			return nil, ScheduleDayOutput{Success: false, Message: err.Error()}, err
+		}
+		sched, err := todoist.ScheduleDay(tasks, opts)
+		if err != nil {
+			return textResult(err.Error(), true), ScheduleDayOutput{Success: false, Message: err.Error()}, nil
+		}
+
+		out := ScheduleDayOutput{Success: true, Scheduled: len(sched.Blocks), Unscheduled: len(sched.Unscheduled)}
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "## Schedule for %s (%s-%s)\n", sched.Start.Format("Monday, January 2"), sched.Start.Format("15:04"), sched.End.Format("15:04"))
+		if !input.WriteBack {
+			sb.WriteString("\nDry run: nothing was changed. Pass write_back=true to set these due times.\n")
+		}
+
+		type entry struct {
+			block todoist.ScheduleBlock
+			note  string
+		}
+		var entries []entry
+		for _, b := range sched.Busy {
+			entries = append(entries, entry{b, "already scheduled"})
+		}
+		var failed []string
+		for _, b := range sched.Blocks {
+			e := entry{block: b}
+			switch {
+			case b.Item.Task.Due != nil && b.Item.Task.Due.Recurring:
+				// A due_datetime would replace the recurrence.
+				e.note = "recurring, not written"
+			case input.WriteBack:
+				body := map[string]interface{}{"due_datetime": b.Start.UTC().Format(time.RFC3339)}
+				if b.Item.Estimated {
+					body["duration"] = b.Item.Minutes
+					body["duration_unit"] = "minute"
+				}
+				if _, err := c.UpdateTask(b.Item.Task.ID, body); err != nil {
+					failed = append(failed, fmt.Sprintf("%s (ID: %s): %v", b.Item.Task.Content, b.Item.Task.ID, err))
+					e.note = "update failed"
+				} else {
+					out.Updated++
+					e.note = "updated"
+				}
+			}
+			entries = append(entries, e)
+		}
+
+		if len(entries) > 0 {
+			sort.SliceStable(entries, func(i, j int) bool { return entries[i].block.Start.Before(entries[j].block.Start) })
+			sb.WriteString("\n### Blocks\n")
+			for _, e := range entries {
+				fmt.Fprintf(&sb, "- %s-%s %s", e.block.Start.Format("15:04"), e.block.End.Format("15:04"), describePlanItem(e.block.Item))
+				if e.note != "" {
+					fmt.Fprintf(&sb, " (%s)", e.note)
+				}
+				sb.WriteString("\n")
+			}
+		} else {
+			sb.WriteString("\nNothing to schedule.\n")
+		}
+		if len(sched.Unscheduled) > 0 {
+			fmt.Fprintf(&sb, "\n### Did Not Fit (%d)\n", len(sched.Unscheduled))
+			for _, it := range sched.Unscheduled {
+				sb.WriteString("- " + describePlanItem(it) + "\n")
+			}
+		}
+		fmt.Fprintf(&sb, "\n%d tasks scheduled, %s free", len(sched.Blocks), formatMinutes(sched.FreeMinutes))
+		if input.WriteBack {
+			fmt.Fprintf(&sb, ", %d updated", out.Updated)
+		}
+		sb.WriteString("\n")
+		if len(failed) > 0 {
+			sb.WriteString("\n### Failed\n- " + strings.Join(failed, "\n- ") + "\n")
+		}
+
+		out.Message = sb.String()
+		out.Success = len(failed) == 0
+		return textResult(out.Message, !out.Success), out, nil
+	})
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_reschedule_overdue",
+		Description: "Spread overdue tasks across the next days with an optional per-day cap, keeping times of day and " +
+			"recurring patterns; supports dry runs and returns the moves so they can be undone",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input RescheduleOverdueInput) (*mcp.CallToolResult, RescheduleOverdueOutput, error) {
+		if len(input.Undo) > 0 {
+			var moves []todoist.RescheduleMove
+			for _, m := range input.Undo {
+				moves = append(moves, m.Undo())
+			}
+			if input.DryRun {
+				var sb strings.Builder
+				fmt.Fprintf(&sb, "Dry run: would restore %d due dates:\n", len(moves))
+				for _, m := range moves {
+					fmt.Fprintf(&sb, "- %s (ID: %s): %s -> %s\n", m.Content, m.TaskID, dueText(m.From), dueText(m.To))
+				}
+				msg := sb.String()
+				return textResult(msg, false), RescheduleOverdueOutput{Success: true, Message: msg, Moves: moves}, nil
+			}
+			if applied, err := c.ApplyReschedule(moves); err != nil {
+				return rescheduleFailed(err, applied, len(moves))
+			}
+			msg := fmt.Sprintf("Restored the previous due dates of %d tasks", len(moves))
+			return textResult(msg, false), RescheduleOverdueOutput{Success: true, Message: msg, Moves: moves}, nil
+		}
+
+		if input.Days <= 0 {
+			input.Days = 7
+		}
+		tasks, err := c.GetTasks(input.ProjectID, "")
+		if err != nil {
+			return nil, RescheduleOverdueOutput{Success: false, Message: err.Error()}, err
+		}
+		plan := todoist.PlanReschedule(tasks, todoist.RescheduleOptions{
+			Days:         input.Days,
+			PerDay:       input.PerDay,
+			SkipWeekends: input.SkipWeekends,
+		})
+		out := RescheduleOverdueOutput{Success: true, Moves: plan.Moves, Unplaced: len(plan.Unplaced)}
+		if len(plan.Moves) == 0 && len(plan.Unplaced) == 0 {
+			out.Message = "No overdue tasks"
+			return textResult(out.Message, false), out, nil
+		}
+		if !input.DryRun && len(plan.Moves) > 0 {
+			if applied, err := c.ApplyReschedule(plan.Moves); err != nil {
+				return rescheduleFailed(err, applied, len(plan.Moves))
+			}
+		}
+
+		var sb strings.Builder
+		if input.DryRun {
+			fmt.Fprintf(&sb, "Dry run: would reschedule %d overdue tasks\n", len(plan.Moves))
+		} else {
+			fmt.Fprintf(&sb, "Rescheduled %d overdue tasks\n", len(plan.Moves))
+		}
+		for _, day := range plan.Days {
+			key := day.Format("2006-01-02")
+			var lines []string
+			for _, m := range plan.Moves {
+				if m.To.Date != key {
+					continue
+				}
+				line := fmt.Sprintf("- %s (ID: %s): %s -> %s", m.Content, m.TaskID, dueText(m.From), dueText(m.To))
+				if m.Recurring {
+					line += fmt.Sprintf(" (keeps \"%s\")", m.To.String)
+				}
+				lines = append(lines, line)
+			}
+			if len(lines) == 0 {
+				continue
+			}
+			fmt.Fprintf(&sb, "\n### %s (%d due)\n%s\n", day.Format("Mon Jan 2"), plan.Load[key], strings.Join(lines, "\n"))
+		}
+		if len(plan.Unplaced) > 0 {
+			fmt.Fprintf(&sb, "\n### No Room (%d)\nEvery day is at the cap of %d; raise per_day or days:\n", len(plan.Unplaced), input.PerDay)
+			for _, t := range plan.Unplaced {
+				fmt.Fprintf(&sb, "- %s (ID: %s)\n", t.Content, t.ID)
+			}
+		}
+		if !input.DryRun && len(plan.Moves) > 0 {
+			sb.WriteString("\nTo undo, call todoist_reschedule_overdue with undo set to the moves in this result.\n")
+		}
+
+		out.Message = sb.String()
+		return textResult(out.Message, false), out, nil
+	})
+}
@@ -1,16 +1,73 @@
 package tools
 
 import (
+	"context"
+	"encoding/json"
+
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/nsega/mcp-todoist/internal/todoist"
 )
 
+// RejectLocalFiles makes the server refuse tool calls that name a
+// file_path, at any depth of the arguments, so that a server reachable
+// over the network cannot read or write files on the host. Every tool
+// stays available with inline data.
+func RejectLocalFiles(s *mcp.Server) {
+	s.AddReceivingMiddleware(func(next mcp.MethodHandler) mcp.MethodHandler {
+		return func(ctx context.Context, method string, req mcp.Request) (mcp.Result, error) {
+			if call, ok := req.(*mcp.CallToolRequest); ok && call.Params != nil {
+				var args interface{}
+				if json.Unmarshal(call.Params.Arguments, &args) == nil && namesFilePath(args) {
+					return textResult("file_path is not available over HTTP; pass the contents inline or omit it to get them back as a resource", true), nil
+				}
+			}
+			return next(ctx, method, req)
+		}
+	})
+}
+
+// namesFilePath reports whether v holds a non-empty "file_path" field.
+func namesFilePath(v interface{}) bool {
+	switch v := v.(type) {
+	case map[string]interface{}:
+		for key, val := range v {
+			if path, ok := val.(string); key == "file_path" && ok && path != "" {
+				return true
+			}
+			if namesFilePath(val) {
+				return true
+			}
+		}
+	case []interface{}:
+		for _, val := range v {
+			if namesFilePath(val) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
 // RegisterAll registers all MCP tools on the server.
 func RegisterAll(s *mcp.Server, c *todoist.Client) {
 	registerTaskTools(s, c)
+	registerSubtaskTools(s, c)
 	registerProjectTools(s, c)
+	registerCollaboratorTools(s, c)
 	registerSectionTools(s, c)
 	registerLabelTools(s, c)
 	registerCommentTools(s, c)
+	registerTemplateTools(s, c)
+	registerBlueprintTools(s, c)
+	registerMarkdownTools(s, c)
+	registerICSTools(s, c)
+	registerTaskImportTools(s, c)
+	registerExportTools(s, c)
+	registerBackupTools(s, c)
+	registerReminderTools(s, c)
+	registerActivityTools(s, c)
+	registerStatsTools(s, c)
 	registerGTDTools(s, c)
+	registerSomedayTools(s, c)
+	registerPlanningTools(s, c)
 }
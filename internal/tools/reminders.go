@@ -0,0 +1,208 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type AddReminderInput struct {
+	TaskID        string  `json:"task_id,omitempty" jsonschema:"Task ID to add the reminder to (preferred over task_name)"`
+	TaskName      string  `json:"task_name,omitempty" jsonschema:"Name of the task to search for"`
+	When          string  `json:"when,omitempty" jsonschema:"Natural language reminder time: '30 minutes before', '1 hour before', 'at due time', or an absolute time like 'tomorrow at 9am' (optional)"`
+	MinutesBefore *int    `json:"minutes_before,omitempty" jsonschema:"Relative reminder: minutes before the task's due time; the task must have a due time (optional)"`
+	DueDatetime   string  `json:"due_datetime,omitempty" jsonschema:"Absolute reminder time in RFC 3339, e.g. 2026-10-20T09:00:00Z (optional)"`
+	LocationName  string  `json:"location_name,omitempty" jsonschema:"Location reminder: name of the place (optional)"`
+	Latitude      float64 `json:"latitude,omitempty" jsonschema:"Location reminder: latitude (optional)"`
+	Longitude     float64 `json:"longitude,omitempty" jsonschema:"Location reminder: longitude (optional)"`
+	Trigger       string  `json:"trigger,omitempty" jsonschema:"Location reminder trigger: on_enter (default) or on_leave (optional)"`
+	Radius        int     `json:"radius,omitempty" jsonschema:"Location reminder radius in meters (optional)"`
+}
+type AddReminderOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type GetRemindersInput struct {
+	TaskID   string `json:"task_id,omitempty" jsonschema:"Only list reminders for this task ID (optional)"`
+	TaskName string `json:"task_name,omitempty" jsonschema:"Only list reminders for the task matching this name (optional)"`
+}
+type GetRemindersOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type DeleteReminderInput struct {
+	ReminderID string `json:"reminder_id" jsonschema:"The reminder ID to delete"`
+}
+type DeleteReminderOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+var relativeReminderRe = regexp.MustCompile(`^(\d+)\s*(m|mins?|minutes?|h|hrs?|hours?|d|days?)\s+before(\s+due)?$`)
+
+// parseReminderPhrase maps a natural language phrase to reminder fields.
+// "N minutes/hours/days before" and "at due time" become relative
+// reminders; anything else is passed to Todoist as an absolute due string.
+func parseReminderPhrase(phrase string) map[string]interface{} {
+	p := strings.Join(strings.Fields(strings.ToLower(phrase)), " ")
+	p = strings.TrimPrefix(p, "remind me ")
+	switch p {
+	case "at due time", "on time", "when due", "at time of task":
+		return map[string]interface{}{"type": "relative", "minute_offset": 0}
+	}
+	if m := relativeReminderRe.FindStringSubmatch(p); m != nil {
+		n, _ := strconv.Atoi(m[1])
+		switch m[2][0] {
+		case 'h':
+			n *= 60
+		case 'd':
+			n *= 24 * 60
+		}
+		return map[string]interface{}{"type": "relative", "minute_offset": n}
+	}
+	abs := strings.TrimSpace(phrase)
+	for _, prefix := range []string{"remind me ", "at ", "on "} {
+		if strings.HasPrefix(strings.ToLower(abs), prefix) {
+			abs = strings.TrimSpace(abs[len(prefix):])
+		}
+	}
+	return map[string]interface{}{"type": "absolute", "due": map[string]interface{}{"string": abs}}
+}
+
+// describeReminder renders a reminder in plain words.
+func describeReminder(r models.Reminder) string {
+	switch r.Type {
+	case "relative":
+		return fmt.Sprintf("%d minutes before due", r.MinuteOffset)
+	case "absolute":
+		if r.Due != nil {
+			switch {
+			case r.Due.Datetime != "":
+				return "at " + r.Due.Datetime
+			case r.Due.Date != "":
+				return "at " + r.Due.Date
+			case r.Due.String != "":
+				return "at " + r.Due.String
+			}
+		}
+		return "at a fixed time"
+	case "location":
+		trigger := "arriving at"
+		if r.LocTrigger == "on_leave" {
+			trigger = "leaving"
+		}
+		return fmt.Sprintf("when %s %s", trigger, r.Name)
+	}
+	return r.Type
+}
+
+func registerReminderTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_add_reminder",
+		Description: "Add a reminder to a task: relative (e.g. '30 minutes before'), absolute (a fixed time) or location-based",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input AddReminderInput) (*mcp.CallToolResult, AddReminderOutput, error) {
+		id, originalName, err := resolveTaskID(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, AddReminderOutput{Success: false, Message: err.Error()}, err
+		}
+		if id == "" {
+			msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+			return textResult(msg, true), AddReminderOutput{Success: false, Message: msg}, nil
+		}
+
+		var body map[string]interface{}
+		switch {
+		case input.MinutesBefore != nil:
+			body = map[string]interface{}{"type": "relative", "minute_offset": *input.MinutesBefore}
+		case input.DueDatetime != "":
+			body = map[string]interface{}{"type": "absolute", "due": map[string]interface{}{"date": input.DueDatetime}}
+		case input.LocationName != "":
+			trigger := input.Trigger
+			if trigger == "" {
+				trigger = "on_enter"
+			}
+			body = map[string]interface{}{
+				"type":        "location",
+				"name":        input.LocationName,
+				"loc_lat":     strconv.FormatFloat(input.Latitude, 'f', -1, 64),
+				"loc_long":    strconv.FormatFloat(input.Longitude, 'f', -1, 64),
+				"loc_trigger": trigger,
+			}
+			if input.Radius > 0 {
+				body["radius"] = input.Radius
+			}
+		case input.When != "":
+			body = parseReminderPhrase(input.When)
+		default:
+			msg := "Specify when to remind: when, minutes_before, due_datetime or location_name"
+			return textResult(msg, true), AddReminderOutput{Success: false, Message: msg}, nil
+		}
+		body["item_id"] = id
+
+		r, err := c.CreateReminder(body)
+		if err != nil {
+			return nil, AddReminderOutput{Success: false, Message: err.Error()}, err
+		}
+
+		label := originalName
+		if label == "" {
+			label = id
+		}
+		msg := fmt.Sprintf("Reminder added to \"%s\": %s (ID: %s)", label, describeReminder(*r), r.ID)
+		return textResult(msg, false), AddReminderOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_reminders",
+		Description: "List reminders, optionally for a single task",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetRemindersInput) (*mcp.CallToolResult, GetRemindersOutput, error) {
+		taskID := ""
+		if input.TaskID != "" || input.TaskName != "" {
+			id, _, err := resolveTaskID(c, input.TaskID, input.TaskName)
+			if err != nil {
+				return nil, GetRemindersOutput{Success: false, Message: err.Error()}, err
+			}
+			if id == "" {
+				msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+				return textResult(msg, true), GetRemindersOutput{Success: false, Message: msg}, nil
+			}
+			taskID = id
+		}
+
+		reminders, err := c.GetReminders(taskID)
+		if err != nil {
+			return nil, GetRemindersOutput{}, err
+		}
+
+		if len(reminders) == 0 {
+			msg := "No reminders found"
+			return textResult(msg, false), GetRemindersOutput{Success: true, Message: msg}, nil
+		}
+
+		var lines []string
+		for _, r := range reminders {
+			lines = append(lines, fmt.Sprintf("- %s (ID: %s, Task: %s)", describeReminder(r), r.ID, r.TaskID))
+		}
+		msg := strings.Join(lines, "\n")
+		return textResult(msg, false), GetRemindersOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_delete_reminder",
+		Description: "Delete a reminder",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input DeleteReminderInput) (*mcp.CallToolResult, DeleteReminderOutput, error) {
+		if err := c.DeleteReminder(input.ReminderID); err != nil {
+			return nil, DeleteReminderOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Successfully deleted reminder: %s", input.ReminderID)
+		return textResult(msg, false), DeleteReminderOutput{Success: true, Message: msg}, nil
+	})
+}
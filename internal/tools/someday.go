@@ -0,0 +1,195 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type SomedayAddInput struct {
+	TaskID   string `json:"task_id,omitempty" jsonschema:"Task ID to defer (preferred over task_name)"`
+	TaskName string `json:"task_name,omitempty" jsonschema:"Name of the task to search for and defer"`
+	Mode     string `json:"mode,omitempty" jsonschema:"'project' to move the task to the Someday project (created if missing) or 'label' to add the someday label (default TODOIST_SOMEDAY_MODE or 'project')"`
+}
+type SomedayAddOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type SomedayListInput struct {
+	MinDays int `json:"min_days,omitempty" jsonschema:"Only list items at least this many days old (optional)"`
+}
+type SomedayListOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+	Count   int    `json:"count"`
+}
+
+type SomedayActivateInput struct {
+	TaskID      string `json:"task_id,omitempty" jsonschema:"Task ID to activate (preferred over task_name)"`
+	TaskName    string `json:"task_name,omitempty" jsonschema:"Name of the task to search for and activate"`
+	ProjectID   string `json:"project_id,omitempty" jsonschema:"Project to move the task to (preferred over project_name)"`
+	ProjectName string `json:"project_name,omitempty" jsonschema:"Name of the project to move the task to; tasks in the Someday project default to the inbox"`
+	DueString   string `json:"due_string,omitempty" jsonschema:"Due date in natural language, e.g. 'next monday' (optional)"`
+}
+type SomedayActivateOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// findTask resolves a task by ID or name and fetches it. It returns nil
+// and a message if no task matches.
+func findTask(c *todoist.Client, id, name string) (*models.Task, string, error) {
+	id, _, err := resolveTaskID(c, id, name)
+	if err != nil {
+		return nil, "", err
+	}
+	if id == "" {
+		return nil, fmt.Sprintf("Could not find a task matching \"%s\"", name), nil
+	}
+	task, err := c.GetTask(id)
+	if err != nil {
+		return nil, "", err
+	}
+	return task, "", nil
+}
+
+// somedayAge describes how long ago an item was added, in whole days.
+func somedayAge(added, now time.Time) string {
+	switch days := int(now.Sub(added).Hours() / 24); days {
+	case 0:
+		return "added today"
+	case 1:
+		return "1 day old"
+	default:
+		return fmt.Sprintf("%d days old", days)
+	}
+}
+
+func registerSomedayTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_someday_add",
+		Description: "Defer a task to the someday/maybe list: move it to the Someday project or add the someday label, clearing its due date",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input SomedayAddInput) (*mcp.CallToolResult, SomedayAddOutput, error) {
+		mode := strings.ToLower(input.Mode)
+		if mode == "" {
+			mode = strings.ToLower(envString("TODOIST_SOMEDAY_MODE", "project"))
+		}
+		if mode != "project" && mode != "label" {
+			msg := fmt.Sprintf("Invalid mode %q (use project or label)", mode)
+			return textResult(msg, true), SomedayAddOutput{Success: false, Message: msg}, nil
+		}
+		task, notFound, err := findTask(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, SomedayAddOutput{Success: false, Message: err.Error()}, err
+		}
+		if task == nil {
+			return textResult(notFound, true), SomedayAddOutput{Success: false, Message: notFound}, nil
+		}
+
+		cfg := gtdConfig()
+		if _, err := c.DeferToSomeday(*task, cfg, mode == "label"); err != nil {
+			return nil, SomedayAddOutput{Success: false, Message: err.Error()}, err
+		}
+		where := "the " + cfg.SomedayProject + " project"
+		if mode == "label" {
+			where = "someday (@" + cfg.SomedayLabel + ")"
+		}
+		msg := fmt.Sprintf("Deferred \"%s\" (ID: %s) to %s", task.Content, task.ID, where)
+		if task.Due != nil {
+			msg += fmt.Sprintf("; removed due date %s", task.Due.Date)
+		}
+		return textResult(msg, false), SomedayAddOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_someday_list",
+		Description: "List someday/maybe items (Someday project or someday label), oldest first with their age",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input SomedayListInput) (*mcp.CallToolResult, SomedayListOutput, error) {
+		cfg := gtdConfig()
+		tasks, err := c.GetSomeday(cfg)
+		if err != nil {
+			return nil, SomedayListOutput{Success: false, Message: err.Error()}, err
+		}
+		now := time.Now()
+		var sb strings.Builder
+		var n int
+		for _, t := range tasks {
+			if input.MinDays > 0 && now.Sub(t.CreatedAt) < time.Duration(input.MinDays)*24*time.Hour {
+				continue
+			}
+			n++
+			fmt.Fprintf(&sb, "- %s (ID: %s): %s", t.Content, t.ID, somedayAge(t.CreatedAt, now))
+			if cfg.SomedayLabel != "" && todoist.HasLabel(t.Labels, cfg.SomedayLabel) {
+				fmt.Fprintf(&sb, " [@%s]", strings.TrimPrefix(cfg.SomedayLabel, "@"))
+			}
+			sb.WriteString("\n")
+		}
+		msg := fmt.Sprintf("## Someday/Maybe (%d)\n", n)
+		if n == 0 {
+			msg += "(none)\n"
+		}
+		msg += sb.String()
+		return textResult(msg, false), SomedayListOutput{Success: true, Message: msg, Count: n}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_someday_activate",
+		Description: "Activate a someday/maybe item: move it into a project, remove the someday label and optionally give it a due date",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input SomedayActivateInput) (*mcp.CallToolResult, SomedayActivateOutput, error) {
+		task, notFound, err := findTask(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, SomedayActivateOutput{Success: false, Message: err.Error()}, err
+		}
+		if task == nil {
+			return textResult(notFound, true), SomedayActivateOutput{Success: false, Message: notFound}, nil
+		}
+
+		cfg := gtdConfig()
+		projectID := ""
+		if input.ProjectID != "" || input.ProjectName != "" {
+			projectID, _, err = resolveProjectID(c, input.ProjectID, input.ProjectName)
+			if err != nil {
+				return nil, SomedayActivateOutput{Success: false, Message: err.Error()}, err
+			}
+			if projectID == "" {
+				msg := fmt.Sprintf("Could not find a project matching \"%s\"", input.ProjectName)
+				return textResult(msg, true), SomedayActivateOutput{Success: false, Message: msg}, nil
+			}
+		} else {
+			projects, err := c.GetProjects()
+			if err != nil {
+				return nil, SomedayActivateOutput{Success: false, Message: err.Error()}, err
+			}
+			byID := map[string]models.Project{}
+			for _, p := range projects {
+				byID[p.ID] = p
+			}
+			if cfg.InSomedayProject(task.ProjectID, byID) {
+				user, err := c.GetUser()
+				if err != nil {
+					return nil, SomedayActivateOutput{Success: false, Message: err.Error()}, err
+				}
+				projectID = user.InboxProjectID
+			}
+		}
+
+		active, err := c.ActivateSomeday(*task, cfg, projectID, input.DueString)
+		if err != nil {
+			return nil, SomedayActivateOutput{Success: false, Message: err.Error()}, err
+		}
+		msg := fmt.Sprintf("Activated \"%s\" (ID: %s)", active.Content, active.ID)
+		if projectID != "" && projectID != task.ProjectID {
+			msg += fmt.Sprintf(" (moved to project %s)", projectID)
+		}
+		if active.Due != nil {
+			msg += fmt.Sprintf(", due %s", active.Due.Date)
+		}
+		return textResult(msg, false), SomedayActivateOutput{Success: true, Message: msg}, nil
+	})
+}
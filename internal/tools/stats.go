@@ -0,0 +1,125 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+// maxKarmaUpdates bounds how many karma changes the summary lists.
+const maxKarmaUpdates = 7
+
+type GetProductivityStatsInput struct {
+	IncludeProjects bool `json:"include_projects,omitempty" jsonschema:"Break down each week's completions by project (optional)"`
+}
+type GetProductivityStatsOutput struct {
+	Success bool                      `json:"success"`
+	Message string                    `json:"message"`
+	Stats   *models.ProductivityStats `json:"stats,omitempty"`
+}
+
+// goalMark reports whether count reaches a goal, or nothing if no goal is set.
+func goalMark(count, goal int) string {
+	if goal <= 0 {
+		return ""
+	}
+	if count >= goal {
+		return fmt.Sprintf(" / %d ✓", goal)
+	}
+	return fmt.Sprintf(" / %d", goal)
+}
+
+// renderProductivityStats formats stats as a markdown summary. Project
+// names are used for the per-project breakdown when includeProjects is set.
+func renderProductivityStats(stats *models.ProductivityStats, projectNames map[string]string, includeProjects bool) string {
+	goals := stats.Goals
+
+	var sb strings.Builder
+	sb.WriteString("## Productivity Stats\n\n")
+	fmt.Fprintf(&sb, "Completed (all time): %d\n", stats.CompletedCount)
+	if goals.KarmaDisabled == 0 {
+		fmt.Fprintf(&sb, "Karma: %.0f", stats.Karma)
+		if stats.KarmaTrend != "" {
+			fmt.Fprintf(&sb, " (trend: %s)", stats.KarmaTrend)
+		}
+		sb.WriteString("\n")
+	}
+	fmt.Fprintf(&sb, "Goals: %d per day, %d per week", goals.DailyGoal, goals.WeeklyGoal)
+	if goals.VacationMode != 0 {
+		sb.WriteString(" (vacation mode)")
+	}
+	sb.WriteString("\n")
+	fmt.Fprintf(&sb, "Daily streak: %d (best %d)\n", goals.CurrentDailyStreak.Count, goals.MaxDailyStreak.Count)
+	fmt.Fprintf(&sb, "Weekly streak: %d (best %d)\n", goals.CurrentWeeklyStreak.Count, goals.MaxWeeklyStreak.Count)
+
+	days := append([]models.DayCompletion(nil), stats.DaysItems...)
+	sort.Slice(days, func(i, j int) bool { return days[i].Date < days[j].Date })
+	fmt.Fprintf(&sb, "\n### Completed per Day (%d days)\n", len(days))
+	if len(days) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, d := range days {
+		fmt.Fprintf(&sb, "- %s: %d%s\n", d.Date, d.TotalCompleted, goalMark(d.TotalCompleted, goals.DailyGoal))
+	}
+
+	weeks := append([]models.WeekCompletion(nil), stats.WeekItems...)
+	sort.Slice(weeks, func(i, j int) bool { return weeks[i].From < weeks[j].From })
+	fmt.Fprintf(&sb, "\n### Completed per Week (%d weeks)\n", len(weeks))
+	if len(weeks) == 0 {
+		sb.WriteString("(none)\n")
+	}
+	for _, w := range weeks {
+		fmt.Fprintf(&sb, "- %s to %s: %d%s\n", w.From, w.To, w.TotalCompleted, goalMark(w.TotalCompleted, goals.WeeklyGoal))
+		if !includeProjects {
+			continue
+		}
+		for _, p := range w.Items {
+			name, ok := projectNames[p.ProjectID]
+			if !ok {
+				name = p.ProjectID
+			}
+			fmt.Fprintf(&sb, "  - %s: %d\n", name, p.Completed)
+		}
+	}
+
+	if goals.KarmaDisabled == 0 && len(stats.KarmaUpdates) > 0 {
+		updates := stats.KarmaUpdates
+		if len(updates) > maxKarmaUpdates {
+			updates = updates[:maxKarmaUpdates]
+		}
+		sb.WriteString("\n### Recent Karma Changes\n")
+		for _, u := range updates {
+			fmt.Fprintf(&sb, "- %s: %.0f (%+.0f)\n", u.Time, u.NewKarma, u.PositiveKarma-u.NegativeKarma)
+		}
+	}
+	return sb.String()
+}
+
+func registerStatsTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_productivity_stats",
+		Description: "Get productivity stats: tasks completed per day and week, daily/weekly goals, streaks and karma trend",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetProductivityStatsInput) (*mcp.CallToolResult, GetProductivityStatsOutput, error) {
+		stats, err := c.GetProductivityStats()
+		if err != nil {
+			return nil, GetProductivityStatsOutput{Success: false, Message: err.Error()}, err
+		}
+
+		projectNames := map[string]string{}
+		if input.IncludeProjects {
+			if projects, err := c.GetProjects(); err == nil {
+				for _, p := range projects {
+					projectNames[p.ID] = p.Name
+				}
+			}
+		}
+
+		msg := renderProductivityStats(stats, projectNames, input.IncludeProjects)
+		return textResult(msg, false), GetProductivityStatsOutput{Success: true, Message: msg, Stats: stats}, nil
+	})
+}
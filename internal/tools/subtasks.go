@@ -0,0 +1,422 @@
+package tools
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+// completedLookback bounds how far back completed subtasks are fetched;
+// the completed-tasks endpoint rejects windows longer than three months.
+const completedLookback = 12 * 7 * 24 * time.Hour
+
+// --- Get Task Tree ---
+
+type GetTaskTreeInput struct {
+	TaskID           string `json:"task_id,omitempty" jsonschema:"Root task ID to show the subtree of (optional)"`
+	TaskName         string `json:"task_name,omitempty" jsonschema:"Name of the root task to search for (optional)"`
+	ProjectID        string `json:"project_id,omitempty" jsonschema:"Show all task trees in a project (optional)"`
+	IncludeCompleted bool   `json:"include_completed,omitempty" jsonschema:"Include subtasks completed in the last 12 weeks in the tree and roll-up (optional)"`
+}
+type GetTaskTreeOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// --- Indent / Outdent ---
+
+type IndentTaskInput struct {
+	TaskID   string `json:"task_id,omitempty" jsonschema:"Task ID to indent (preferred over task_name)"`
+	TaskName string `json:"task_name,omitempty" jsonschema:"Name of the task to search for and indent"`
+}
+type IndentTaskOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type OutdentTaskInput struct {
+	TaskID   string `json:"task_id,omitempty" jsonschema:"Task ID to outdent (preferred over task_name)"`
+	TaskName string `json:"task_name,omitempty" jsonschema:"Name of the task to search for and outdent"`
+}
+type OutdentTaskOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// --- Reparent ---
+
+type ReparentTaskInput struct {
+	TaskID     string `json:"task_id,omitempty" jsonschema:"Task ID to move (preferred over task_name)"`
+	TaskName   string `json:"task_name,omitempty" jsonschema:"Name of the task to search for and move"`
+	ParentID   string `json:"parent_id,omitempty" jsonschema:"New parent task ID (preferred over parent_name)"`
+	ParentName string `json:"parent_name,omitempty" jsonschema:"Name of the new parent task to search for"`
+	TopLevel   bool   `json:"top_level,omitempty" jsonschema:"Make the task a top-level task in its current project and section instead of giving it a parent"`
+}
+type ReparentTaskOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// --- Create Task Tree ---
+
+type SubtaskItem struct {
+	Content     string   `json:"content" jsonschema:"Subtask content/title"`
+	Description string   `json:"description,omitempty" jsonschema:"Subtask description (optional)"`
+	DueString   string   `json:"due_string,omitempty" jsonschema:"Due date in natural language (optional)"`
+	Priority    int      `json:"priority,omitempty" jsonschema:"Priority 1-4 (optional)"`
+	Labels      []string `json:"labels,omitempty" jsonschema:"Labels (optional)"`
+	Indent      int      `json:"indent,omitempty" jsonschema:"Nesting level below the root task: 1 for a direct subtask, 2 for a subtask of the previous level-1 item, and so on (default 1)"`
+}
+
+type CreateTaskTreeInput struct {
+	Content     string        `json:"content" jsonschema:"The content/title of the root task"`
+	Description string        `json:"description,omitempty" jsonschema:"Description of the root task (optional)"`
+	DueString   string        `json:"due_string,omitempty" jsonschema:"Due date of the root task in natural language (optional)"`
+	Priority    int           `json:"priority,omitempty" jsonschema:"Priority of the root task from 1 (normal) to 4 (urgent) (optional)"`
+	ProjectID   string        `json:"project_id,omitempty" jsonschema:"Project ID to create the tasks in (optional)"`
+	SectionID   string        `json:"section_id,omitempty" jsonschema:"Section ID to create the tasks in (optional)"`
+	ParentID    string        `json:"parent_id,omitempty" jsonschema:"Existing task ID to nest the root task under (optional)"`
+	Labels      []string      `json:"labels,omitempty" jsonschema:"Labels for the root task (optional)"`
+	Subtasks    []SubtaskItem `json:"subtasks,omitempty" jsonschema:"Subtasks in display order; nesting is given by each item's indent"`
+}
+type CreateTaskTreeOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// --- helpers ---
+
+// loadTaskTree builds the tree of active tasks in a project, optionally
+// including recently completed ones.
+func loadTaskTree(c *todoist.Client, projectID string, includeCompleted bool) (*todoist.TaskTree, error) {
+	tasks, err := c.GetTasks(projectID, "")
+	if err != nil {
+		return nil, err
+	}
+	if includeCompleted {
+		now := time.Now()
+		completed, err := c.GetCompletedTasks(projectID, now.Add(-completedLookback), now)
+		if err != nil {
+			return nil, err
+		}
+		tasks = append(tasks, completed...)
+	}
+	return todoist.BuildTaskTree(tasks), nil
+}
+
+// writeTaskTree renders n and its subtasks as an indented checklist with
+// completion roll-up on every task that has subtasks.
+func writeTaskTree(sb *strings.Builder, n *todoist.TaskNode) {
+	n.Walk(func(node *todoist.TaskNode, depth int) {
+		check := " "
+		if node.Task.IsCompleted {
+			check = "x"
+		}
+		fmt.Fprintf(sb, "%s- [%s] %s (ID: %s)", strings.Repeat("  ", depth), check, node.Task.Content, node.Task.ID)
+		if node.Task.Priority > 1 {
+			fmt.Fprintf(sb, " [P%d]", node.Task.Priority)
+		}
+		if done, total := node.Progress(); total > 0 {
+			fmt.Fprintf(sb, " — %d/%d subtasks done", done, total)
+		}
+		sb.WriteString("\n")
+	})
+}
+
+// locateTaskNode resolves a task by ID or name and finds it in the tree
+// of its project.
+func locateTaskNode(c *todoist.Client, id, name string) (*todoist.TaskTree, *todoist.TaskNode, error) {
+	id, _, err := resolveTaskID(c, id, name)
+	if err != nil || id == "" {
+		return nil, nil, err
+	}
+	task, err := c.GetTask(id)
+	if err != nil {
+		return nil, nil, err
+	}
+	tree, err := loadTaskTree(c, task.ProjectID, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	node := tree.Find(id)
+	if node == nil {
+		return nil, nil, fmt.Errorf("task %s is not an active task in project %s", id, task.ProjectID)
+	}
+	return tree, node, nil
+}
+
+// topLevelMove returns the move body that places a task at the top level
+// of the given task's project and section.
+func topLevelMove(t models.Task) map[string]interface{} {
+	if t.SectionID != "" {
+		return map[string]interface{}{"section_id": t.SectionID}
+	}
+	return map[string]interface{}{"project_id": t.ProjectID}
+}
+
+func subtaskBody(content, description, dueString string, priority int, labels []string) map[string]interface{} {
+	body := map[string]interface{}{"content": content}
+	if description != "" {
+		body["description"] = description
+	}
+	if dueString != "" {
+		body["due_string"] = dueString
+	}
+	if priority > 0 && priority <= 4 {
+		body["priority"] = priority
+	}
+	if len(labels) > 0 {
+		body["labels"] = labels
+	}
+	return body
+}
+
+func registerSubtaskTools(s *mcp.Server, c *todoist.Client) {
+	// --- todoist_get_task_tree ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_task_tree",
+		Description: "Show tasks with their nested subtasks and completion roll-up, for one task or a whole project",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetTaskTreeInput) (*mcp.CallToolResult, GetTaskTreeOutput, error) {
+		projectID := input.ProjectID
+		var root *models.Task
+		if input.TaskID != "" || input.TaskName != "" {
+			id, _, err := resolveTaskID(c, input.TaskID, input.TaskName)
+			if err != nil {
+				return nil, GetTaskTreeOutput{Success: false, Message: err.Error()}, err
+			}
+			if id == "" {
+				msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+				return textResult(msg, true), GetTaskTreeOutput{Success: false, Message: msg}, nil
+			}
+			root, err = c.GetTask(id)
+			if err != nil {
+				return nil, GetTaskTreeOutput{Success: false, Message: err.Error()}, err
+			}
+			projectID = root.ProjectID
+		}
+
+		tree, err := loadTaskTree(c, projectID, input.IncludeCompleted)
+		if err != nil {
+			return nil, GetTaskTreeOutput{Success: false, Message: err.Error()}, err
+		}
+
+		var sb strings.Builder
+		if root != nil {
+			node := tree.Find(root.ID)
+			if node == nil {
+				// The root itself is not active (e.g. completed); show it alone.
+				node = &todoist.TaskNode{Task: *root}
+			}
+			writeTaskTree(&sb, node)
+		} else {
+			if len(tree.Roots) == 0 {
+				msg := "No tasks found"
+				return textResult(msg, false), GetTaskTreeOutput{Success: true, Message: msg}, nil
+			}
+			for _, r := range tree.Roots {
+				writeTaskTree(&sb, r)
+			}
+		}
+
+		msg := strings.TrimRight(sb.String(), "\n")
+		return textResult(msg, false), GetTaskTreeOutput{Success: true, Message: msg}, nil
+	})
+
+	// --- todoist_indent_task ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_indent_task",
+		Description: "Indent a task, making it a subtask of the task directly above it",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input IndentTaskInput) (*mcp.CallToolResult, IndentTaskOutput, error) {
+		tree, node, err := locateTaskNode(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, IndentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		if node == nil {
+			msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+			return textResult(msg, true), IndentTaskOutput{Success: false, Message: msg}, nil
+		}
+
+		siblings := tree.Siblings(node)
+		var prev *todoist.TaskNode
+		for i, sib := range siblings {
+			if sib == node && i > 0 {
+				prev = siblings[i-1]
+			}
+		}
+		if prev == nil {
+			msg := fmt.Sprintf("Cannot indent \"%s\": there is no task above it at the same level", node.Task.Content)
+			return textResult(msg, true), IndentTaskOutput{Success: false, Message: msg}, nil
+		}
+
+		if _, err := c.MoveTask(node.Task.ID, map[string]interface{}{"parent_id": prev.Task.ID}); err != nil {
+			return nil, IndentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+
+		msg := fmt.Sprintf("Indented \"%s\" under \"%s\"", node.Task.Content, prev.Task.Content)
+		return textResult(msg, false), IndentTaskOutput{Success: true, Message: msg}, nil
+	})
+
+	// --- todoist_outdent_task ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_outdent_task",
+		Description: "Outdent a subtask one level, making it a sibling of its current parent",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input OutdentTaskInput) (*mcp.CallToolResult, OutdentTaskOutput, error) {
+		_, node, err := locateTaskNode(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, OutdentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		if node == nil {
+			msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+			return textResult(msg, true), OutdentTaskOutput{Success: false, Message: msg}, nil
+		}
+		if node.Parent == nil {
+			msg := fmt.Sprintf("Cannot outdent \"%s\": it is already a top-level task", node.Task.Content)
+			return textResult(msg, true), OutdentTaskOutput{Success: false, Message: msg}, nil
+		}
+
+		body := topLevelMove(node.Parent.Task)
+		dest := "the top level"
+		if gp := node.Parent.Parent; gp != nil {
+			body = map[string]interface{}{"parent_id": gp.Task.ID}
+			dest = fmt.Sprintf("\"%s\"", gp.Task.Content)
+		}
+		if _, err := c.MoveTask(node.Task.ID, body); err != nil {
+			return nil, OutdentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+
+		msg := fmt.Sprintf("Outdented \"%s\" to %s", node.Task.Content, dest)
+		return textResult(msg, false), OutdentTaskOutput{Success: true, Message: msg}, nil
+	})
+
+	// --- todoist_reparent_task ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_reparent_task",
+		Description: "Move a task (with its subtasks) under a different parent task, or to the top level",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ReparentTaskInput) (*mcp.CallToolResult, ReparentTaskOutput, error) {
+		tree, node, err := locateTaskNode(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, ReparentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		if node == nil {
+			msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+			return textResult(msg, true), ReparentTaskOutput{Success: false, Message: msg}, nil
+		}
+
+		if input.TopLevel {
+			if node.Parent == nil {
+				msg := fmt.Sprintf("\"%s\" is already a top-level task", node.Task.Content)
+				return textResult(msg, false), ReparentTaskOutput{Success: true, Message: msg}, nil
+			}
+			if _, err := c.MoveTask(node.Task.ID, topLevelMove(node.Task)); err != nil {
+				return nil, ReparentTaskOutput{Success: false, Message: err.Error()}, err
+			}
+			msg := fmt.Sprintf("Moved \"%s\" to the top level", node.Task.Content)
+			return textResult(msg, false), ReparentTaskOutput{Success: true, Message: msg}, nil
+		}
+
+		parentID, parentName, err := resolveTaskID(c, input.ParentID, input.ParentName)
+		if err != nil {
+			return nil, ReparentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		if parentID == "" {
+			msg := fmt.Sprintf("Could not find a parent task matching \"%s\"", input.ParentName)
+			return textResult(msg, true), ReparentTaskOutput{Success: false, Message: msg}, nil
+		}
+		if parentID == node.Task.ID {
+			msg := "A task cannot be its own parent"
+			return textResult(msg, true), ReparentTaskOutput{Success: false, Message: msg}, nil
+		}
+		if p := tree.Find(parentID); p != nil && node.IsAncestorOf(p) {
+			msg := fmt.Sprintf("Cannot move \"%s\" under its own subtask \"%s\"", node.Task.Content, p.Task.Content)
+			return textResult(msg, true), ReparentTaskOutput{Success: false, Message: msg}, nil
+		}
+
+		if _, err := c.MoveTask(node.Task.ID, map[string]interface{}{"parent_id": parentID}); err != nil {
+			return nil, ReparentTaskOutput{Success: false, Message: err.Error()}, err
+		}
+
+		label := parentName
+		if label == "" {
+			label = parentID
+		}
+		msg := fmt.Sprintf("Moved \"%s\" under \"%s\"", node.Task.Content, label)
+		return textResult(msg, false), ReparentTaskOutput{Success: true, Message: msg}, nil
+	})
+
+	// --- todoist_create_task_tree ---
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_create_task_tree",
+		Description: "Create a task together with nested subtasks in one call",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input CreateTaskTreeInput) (*mcp.CallToolResult, CreateTaskTreeOutput, error) {
+		// Validate nesting up front so nothing is created for a bad tree.
+		depth := 0
+		for i, item := range input.Subtasks {
+			indent := item.Indent
+			if indent == 0 {
+				indent = 1
+			}
+			if indent < 1 || indent > depth+1 {
+				msg := fmt.Sprintf("Subtask %d (\"%s\") has indent %d but the previous item is at level %d", i+1, item.Content, indent, depth)
+				return textResult(msg, true), CreateTaskTreeOutput{Success: false, Message: msg}, nil
+			}
+			depth = indent
+		}
+
+		body := subtaskBody(input.Content, input.Description, input.DueString, input.Priority, input.Labels)
+		if input.ProjectID != "" {
+			body["project_id"] = input.ProjectID
+		}
+		if input.SectionID != "" {
+			body["section_id"] = input.SectionID
+		}
+		if input.ParentID != "" {
+			body["parent_id"] = input.ParentID
+		}
+		root, err := c.CreateTask(body)
+		if err != nil {
+			return nil, CreateTaskTreeOutput{Success: false, Message: err.Error()}, err
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Created \"%s\" (ID: %s)", root.Content, root.ID)
+
+		// stack[i] is the ID of the most recent task at level i.
+		stack := []string{root.ID}
+		var created, failed int
+		for _, item := range input.Subtasks {
+			indent := item.Indent
+			if indent == 0 {
+				indent = 1
+			}
+			prefix := strings.Repeat("  ", indent)
+			if indent > len(stack) {
+				// The parent of this item failed to be created.
+				failed++
+				fmt.Fprintf(&sb, "\n%s- SKIPPED: %s (parent was not created)", prefix, item.Content)
+				continue
+			}
+			stack = stack[:indent]
+
+			b := subtaskBody(item.Content, item.Description, item.DueString, item.Priority, item.Labels)
+			b["parent_id"] = stack[indent-1]
+			task, err := c.CreateTask(b)
+			if err != nil {
+				failed++
+				fmt.Fprintf(&sb, "\n%s- FAILED: %s — %s", prefix, item.Content, err.Error())
+				continue
+			}
+			created++
+			stack = append(stack, task.ID)
+			fmt.Fprintf(&sb, "\n%s- %s (ID: %s)", prefix, task.Content, task.ID)
+		}
+
+		msg := fmt.Sprintf("%d subtasks created, %d failed\n\n%s", created, failed, sb.String())
+		success := failed == 0
+		return textResult(msg, !success), CreateTaskTreeOutput{Success: success, Message: msg}, nil
+	})
+}
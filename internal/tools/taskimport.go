@@ -0,0 +1,143 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type ImportTasksInput struct {
+	FilePath  string            `json:"file_path,omitempty" jsonschema:"Path of an .ics or .csv file (provide file_path or data)"`
+	Data      string            `json:"data,omitempty" jsonschema:"iCalendar or CSV contents (provide file_path or data)"`
+	Format    string            `json:"format,omitempty" jsonschema:"ics or csv (optional; detected from the file extension or contents)"`
+	Columns   map[string]string `json:"columns,omitempty" jsonschema:"CSV column mapping from field (content, description, due, priority, labels, duration) to header name, e.g. {\"content\": \"Title\", \"due\": \"Due Date\"} (optional; common header names are detected)"`
+	ProjectID string            `json:"project_id,omitempty" jsonschema:"Project to create tasks in (default: Inbox)"`
+	DryRun    bool              `json:"dry_run,omitempty" jsonschema:"Only list what would be created and which rows are duplicates"`
+}
+type ImportTasksOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+// describeDraft renders a draft as "content (due ..., p1, @label)".
+func describeDraft(d todoist.TaskDraft) string {
+	var attrs []string
+	if due := d.DueText(); due != "" {
+		attrs = append(attrs, "due "+due)
+	}
+	if d.Priority > 1 {
+		attrs = append(attrs, fmt.Sprintf("p%d", 5-d.Priority))
+	}
+	for _, l := range d.Labels {
+		attrs = append(attrs, "@"+l)
+	}
+	if len(attrs) == 0 {
+		return d.Content
+	}
+	return fmt.Sprintf("%s (%s)", d.Content, strings.Join(attrs, ", "))
+}
+
+func registerTaskImportTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name: "todoist_import_tasks",
+		Description: "Import tasks from an iCalendar file's VTODOs or from CSV rows (with an optional column mapping), keeping due dates, " +
+			"priorities and labels. Tasks whose content and due date match an existing task are skipped as duplicates",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ImportTasksInput) (*mcp.CallToolResult, ImportTasksOutput, error) {
+		var data []byte
+		switch {
+		case input.FilePath != "" && input.Data != "":
+			msg := "Provide file_path or data, not both"
+			return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+		case input.FilePath != "":
+			var err error
+			if data, err = os.ReadFile(input.FilePath); err != nil {
+				msg := fmt.Sprintf("Failed to read file: %s", err.Error())
+				return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+			}
+		case input.Data != "":
+			data = []byte(input.Data)
+		default:
+			msg := "Provide tasks via file_path or data"
+			return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+		}
+
+		format := strings.ToLower(input.Format)
+		if format == "" {
+			switch ext := strings.ToLower(filepath.Ext(input.FilePath)); {
+			case ext == ".ics" || ext == ".ical" || bytes.Contains(data[:min(len(data), 64)], []byte("BEGIN:VCALENDAR")):
+				format = "ics"
+			default:
+				format = "csv"
+			}
+		}
+
+		var drafts []todoist.TaskDraft
+		var skipped int
+		var err error
+		switch format {
+		case "ics":
+			drafts, skipped, err = todoist.ParseICS(bytes.NewReader(data))
+		case "csv":
+			drafts, err = todoist.ParseTaskCSV(bytes.NewReader(data), input.Columns)
+		default:
+			msg := fmt.Sprintf("Unknown format %q: use ics or csv", input.Format)
+			return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+		}
+		if err != nil {
+			msg := fmt.Sprintf("Invalid %s: %s", strings.ToUpper(format), err.Error())
+			return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+		}
+		if len(drafts) == 0 {
+			msg := "No tasks found to import"
+			return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+		}
+
+		result, err := c.ImportTasks(drafts, input.ProjectID, input.DryRun)
+		if err != nil {
+			msg := fmt.Sprintf("Import failed: %s", err.Error())
+			if result != nil && len(result.Created) > 0 {
+				msg += fmt.Sprintf(" (created %d tasks before the error)", len(result.Created))
+			}
+			return textResult(msg, true), ImportTasksOutput{Success: false, Message: msg}, nil
+		}
+
+		var sb strings.Builder
+		if input.DryRun {
+			fmt.Fprintf(&sb, "## Task Import Preview (dry run)\n\n%d to create, %d duplicates", len(result.Created), len(result.Duplicates))
+		} else {
+			fmt.Fprintf(&sb, "## Tasks Imported\n\n%d created, %d duplicates skipped", len(result.Created), len(result.Duplicates))
+		}
+		if skipped > 0 {
+			fmt.Fprintf(&sb, ", %d completed or non-task entries ignored", skipped)
+		}
+		sb.WriteString("\n")
+		if len(result.Created) > 0 {
+			sb.WriteString("\n")
+			for _, t := range result.Created {
+				fmt.Fprintf(&sb, "- %s: %s", t.Draft.Source, describeDraft(t.Draft))
+				if t.TaskID != "" {
+					fmt.Fprintf(&sb, " → %s", t.TaskID)
+				}
+				sb.WriteString("\n")
+			}
+		}
+		if len(result.Duplicates) > 0 {
+			sb.WriteString("\n### Duplicates\n\n")
+			for _, t := range result.Duplicates {
+				if t.TaskID != "" {
+					fmt.Fprintf(&sb, "- %s: %s matches existing task %s\n", t.Draft.Source, describeDraft(t.Draft), t.TaskID)
+				} else {
+					fmt.Fprintf(&sb, "- %s: %s repeats an earlier entry\n", t.Draft.Source, describeDraft(t.Draft))
+				}
+			}
+		}
+		msg := sb.String()
+		return textResult(msg, false), ImportTasksOutput{Success: true, Message: msg}, nil
+	})
+}
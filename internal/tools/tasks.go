@@ -4,23 +4,32 @@ import (
 	"context"
 	"fmt"
 	"strings"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/models"
 	"github.com/nsega/mcp-todoist/internal/todoist"
 )
 
 // --- Input / Output types ---
 
 type CreateTaskInput struct {
-	Content     string   `json:"content" jsonschema:"The content/title of the task"`
-	Description string   `json:"description,omitempty" jsonschema:"Detailed description of the task (optional)"`
-	DueString   string   `json:"due_string,omitempty" jsonschema:"Natural language due date like 'tomorrow', 'next Monday', 'Jan 23' (optional)"`
-	Priority    int      `json:"priority,omitempty" jsonschema:"Task priority from 1 (normal) to 4 (urgent) (optional)"`
-	ProjectID   string   `json:"project_id,omitempty" jsonschema:"Project ID to create the task in (optional)"`
-	SectionID   string   `json:"section_id,omitempty" jsonschema:"Section ID to create the task in (optional)"`
-	ParentID    string   `json:"parent_id,omitempty" jsonschema:"Parent task ID for sub-tasks (optional)"`
-	Labels      []string `json:"labels,omitempty" jsonschema:"Labels to apply to the task (optional)"`
-	AssigneeID  string   `json:"assignee_id,omitempty" jsonschema:"User ID to assign the task to (optional)"`
+	Content      string   `json:"content" jsonschema:"The content/title of the task"`
+	Description  string   `json:"description,omitempty" jsonschema:"Detailed description of the task (optional)"`
+	DueString    string   `json:"due_string,omitempty" jsonschema:"Natural language due date like 'tomorrow', 'next Monday', 'Jan 23' (optional)"`
+	DueDate      string   `json:"due_date,omitempty" jsonschema:"Exact due date as YYYY-MM-DD; use instead of due_string (optional)"`
+	DueDatetime  string   `json:"due_datetime,omitempty" jsonschema:"Exact due date and time in RFC 3339, e.g. 2026-10-20T09:00:00Z; use instead of due_string (optional)"`
+	DueLang      string   `json:"due_lang,omitempty" jsonschema:"Two-letter language code used to parse due_string, e.g. 'en', 'de' (optional)"`
+	Duration     int      `json:"duration,omitempty" jsonschema:"Estimated duration amount; requires a due date (optional)"`
+	DurationUnit string   `json:"duration_unit,omitempty" jsonschema:"Unit for duration: minute (default) or day (optional)"`
+	DeadlineDate string   `json:"deadline_date,omitempty" jsonschema:"Deadline as YYYY-MM-DD (optional)"`
+	Priority     int      `json:"priority,omitempty" jsonschema:"Task priority from 1 (normal) to 4 (urgent) (optional)"`
+	ProjectID    string   `json:"project_id,omitempty" jsonschema:"Project ID to create the task in (optional)"`
+	SectionID    string   `json:"section_id,omitempty" jsonschema:"Section ID to create the task in (optional)"`
+	ParentID     string   `json:"parent_id,omitempty" jsonschema:"Parent task ID for sub-tasks (optional)"`
+	Labels       []string `json:"labels,omitempty" jsonschema:"Labels to apply to the task (optional)"`
+	AssigneeID   string   `json:"assignee_id,omitempty" jsonschema:"User ID to assign the task to (optional)"`
+	Assignee     string   `json:"assignee,omitempty" jsonschema:"Name or email of a project collaborator to assign the task to; requires project_id (optional)"`
 }
 
 type CreateTaskOutput struct {
@@ -40,15 +49,36 @@ type GetTasksOutput struct {
 	Message string `json:"message"`
 }
 
+type GetTaskInput struct {
+	TaskID   string `json:"task_id,omitempty" jsonschema:"Task ID to retrieve (preferred over task_name)"`
+	TaskName string `json:"task_name,omitempty" jsonschema:"Name/content of the task to search for"`
+}
+
+type GetTaskOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
 type UpdateTaskInput struct {
-	TaskID      string   `json:"task_id,omitempty" jsonschema:"Task ID to update (preferred over task_name)"`
-	TaskName    string   `json:"task_name,omitempty" jsonschema:"Name/content of the task to search for and update"`
-	Content     string   `json:"content,omitempty" jsonschema:"New content/title for the task (optional)"`
-	Description string   `json:"description,omitempty" jsonschema:"New description for the task (optional)"`
-	DueString   string   `json:"due_string,omitempty" jsonschema:"New due date in natural language (optional)"`
-	Priority    int      `json:"priority,omitempty" jsonschema:"New priority level from 1 (normal) to 4 (urgent) (optional)"`
-	Labels      []string `json:"labels,omitempty" jsonschema:"New labels for the task (optional)"`
-	AssigneeID  string   `json:"assignee_id,omitempty" jsonschema:"User ID to assign the task to (optional)"`
+	TaskID        string   `json:"task_id,omitempty" jsonschema:"Task ID to update (preferred over task_name)"`
+	TaskName      string   `json:"task_name,omitempty" jsonschema:"Name/content of the task to search for and update"`
+	Content       string   `json:"content,omitempty" jsonschema:"New content/title for the task (optional)"`
+	Description   string   `json:"description,omitempty" jsonschema:"New description for the task (optional)"`
+	DueString     string   `json:"due_string,omitempty" jsonschema:"New due date in natural language (optional)"`
+	DueDate       string   `json:"due_date,omitempty" jsonschema:"New exact due date as YYYY-MM-DD (optional)"`
+	DueDatetime   string   `json:"due_datetime,omitempty" jsonschema:"New exact due date and time in RFC 3339 (optional)"`
+	DueLang       string   `json:"due_lang,omitempty" jsonschema:"Two-letter language code used to parse due_string (optional)"`
+	Duration      int      `json:"duration,omitempty" jsonschema:"New estimated duration amount (optional)"`
+	DurationUnit  string   `json:"duration_unit,omitempty" jsonschema:"Unit for duration: minute (default) or day (optional)"`
+	DeadlineDate  string   `json:"deadline_date,omitempty" jsonschema:"New deadline as YYYY-MM-DD (optional)"`
+	Priority      int      `json:"priority,omitempty" jsonschema:"New priority level from 1 (normal) to 4 (urgent) (optional)"`
+	Labels        []string `json:"labels,omitempty" jsonschema:"New labels for the task (optional)"`
+	AssigneeID    string   `json:"assignee_id,omitempty" jsonschema:"User ID to assign the task to (optional)"`
+	Assignee      string   `json:"assignee,omitempty" jsonschema:"Name or email of a project collaborator to assign the task to (optional)"`
+	ClearDue      bool     `json:"clear_due,omitempty" jsonschema:"Remove the task's due date (optional)"`
+	ClearDeadline bool     `json:"clear_deadline,omitempty" jsonschema:"Remove the task's deadline (optional)"`
+	ClearDuration bool     `json:"clear_duration,omitempty" jsonschema:"Remove the task's duration (optional)"`
+	ClearLabels   bool     `json:"clear_labels,omitempty" jsonschema:"Remove all labels from the task (optional)"`
 }
 
 type UpdateTaskOutput struct {
@@ -105,6 +135,117 @@ func resolveTaskID(c *todoist.Client, id, name string) (string, string, error) {
 	return task.ID, task.Content, nil
 }
 
+// resolveProjectID returns the project ID, looking it up by name if no ID
+// is given. It returns "" if no project matches the name.
+func resolveProjectID(c *todoist.Client, id, name string) (string, string, error) {
+	if id != "" {
+		return id, "", nil
+	}
+	if name == "" {
+		return "", "", fmt.Errorf("either project_id or project_name is required")
+	}
+	p, err := c.FindProjectByName(name)
+	if err != nil {
+		return "", "", err
+	}
+	if p == nil {
+		return "", "", nil // not found
+	}
+	return p.ID, p.Name, nil
+}
+
+// dueFields groups the scheduling inputs shared by task create and update.
+type dueFields struct {
+	DueString    string
+	DueDate      string
+	DueDatetime  string
+	DueLang      string
+	Duration     int
+	DurationUnit string
+	DeadlineDate string
+}
+
+// apply copies the non-empty fields into an API request body. At most one
+// of due_string, due_date and due_datetime may be given.
+func (f dueFields) apply(body map[string]interface{}) error {
+	set := 0
+	for _, v := range []string{f.DueString, f.DueDate, f.DueDatetime} {
+		if v != "" {
+			set++
+		}
+	}
+	if set > 1 {
+		return fmt.Errorf("only one of due_string, due_date and due_datetime may be set")
+	}
+	if f.DueString != "" {
+		body["due_string"] = f.DueString
+	}
+	if f.DueDate != "" {
+		if _, err := time.Parse("2006-01-02", f.DueDate); err != nil {
+			return fmt.Errorf("due_date must be YYYY-MM-DD, got %q", f.DueDate)
+		}
+		body["due_date"] = f.DueDate
+	}
+	if f.DueDatetime != "" {
+		if _, err := time.Parse(time.RFC3339, f.DueDatetime); err != nil {
+			return fmt.Errorf("due_datetime must be RFC 3339, got %q", f.DueDatetime)
+		}
+		body["due_datetime"] = f.DueDatetime
+	}
+	if f.DueLang != "" {
+		body["due_lang"] = f.DueLang
+	}
+	if f.Duration > 0 {
+		unit := f.DurationUnit
+		if unit == "" {
+			unit = "minute"
+		}
+		if unit != "minute" && unit != "day" {
+			return fmt.Errorf("duration_unit must be minute or day, got %q", unit)
+		}
+		body["duration"] = f.Duration
+		body["duration_unit"] = unit
+	}
+	if f.DeadlineDate != "" {
+		if _, err := time.Parse("2006-01-02", f.DeadlineDate); err != nil {
+			return fmt.Errorf("deadline_date must be YYYY-MM-DD, got %q", f.DeadlineDate)
+		}
+		body["deadline_date"] = f.DeadlineDate
+	}
+	return nil
+}
+
+// describeExtras summarizes deadline and duration for confirmation
+// messages, each line prefixed with prefix.
+func describeExtras(t *models.Task, prefix string) string {
+	var s string
+	if t.Deadline != nil && t.Deadline.Date != "" {
+		s += fmt.Sprintf("\n%sDeadline: %s", prefix, t.Deadline.Date)
+	}
+	if t.Duration != nil && t.Duration.Amount > 0 {
+		s += fmt.Sprintf("\n%sDuration: %d %s", prefix, t.Duration.Amount, t.Duration.Unit)
+	}
+	return s
+}
+
+// maxParentDepth bounds the parent-chain walk; Todoist nests at most a
+// few levels, so anything deeper indicates inconsistent data.
+const maxParentDepth = 10
+
+// parentChain returns the ancestors of a task, outermost first.
+func parentChain(c *todoist.Client, t *models.Task) ([]models.Task, error) {
+	var chain []models.Task
+	for id := t.ParentID; id != "" && len(chain) < maxParentDepth; {
+		parent, err := c.GetTask(id)
+		if err != nil {
+			return nil, err
+		}
+		chain = append([]models.Task{*parent}, chain...)
+		id = parent.ParentID
+	}
+	return chain, nil
+}
+
 func textResult(msg string, isError bool) *mcp.CallToolResult {
 	return &mcp.CallToolResult{
 		Content: []mcp.Content{&mcp.TextContent{Text: msg}},
@@ -123,8 +264,10 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		if input.Description != "" {
 			body["description"] = input.Description
 		}
-		if input.DueString != "" {
-			body["due_string"] = input.DueString
+		due := dueFields{input.DueString, input.DueDate, input.DueDatetime, input.DueLang, input.Duration, input.DurationUnit, input.DeadlineDate}
+		if err := due.apply(body); err != nil {
+			msg := err.Error()
+			return textResult(msg, true), CreateTaskOutput{Success: false, Message: msg}, nil
 		}
 		if input.Priority > 0 && input.Priority <= 4 {
 			body["priority"] = input.Priority
@@ -144,6 +287,14 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		if input.AssigneeID != "" {
 			body["assignee_id"] = input.AssigneeID
 		}
+		if input.Assignee != "" {
+			uid, err := resolveAssignee(c, input.ProjectID, input.Assignee)
+			if err != nil {
+				msg := err.Error()
+				return textResult(msg, true), CreateTaskOutput{Success: false, Message: msg}, nil
+			}
+			body["assignee_id"] = uid
+		}
 
 		task, err := c.CreateTask(body)
 		if err != nil {
@@ -157,6 +308,7 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		if task.Due != nil && task.Due.String != "" {
 			msg += fmt.Sprintf("\nDue: %s", task.Due.String)
 		}
+		msg += describeExtras(task, "")
 		if task.Priority > 0 {
 			msg += fmt.Sprintf("\nPriority: %d", task.Priority)
 		}
@@ -217,6 +369,170 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		return textResult(msg, false), GetTasksOutput{Success: true, Message: msg}, nil
 	})
 
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_get_task",
+		Description: "Get full details of one task by task_id or name: all fields, parent chain, subtasks, comments, attachments, project/section names and reminders",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input GetTaskInput) (*mcp.CallToolResult, GetTaskOutput, error) {
+		id, _, err := resolveTaskID(c, input.TaskID, input.TaskName)
+		if err != nil {
+			return nil, GetTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		if id == "" {
+			msg := fmt.Sprintf("Could not find a task matching \"%s\"", input.TaskName)
+			return textResult(msg, true), GetTaskOutput{Success: false, Message: msg}, nil
+		}
+
+		t, err := c.GetTask(id)
+		if err != nil {
+			return nil, GetTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		project, err := c.GetProject(t.ProjectID)
+		if err != nil {
+			return nil, GetTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		chain, err := parentChain(c, t)
+		if err != nil {
+			return nil, GetTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		tree, err := loadTaskTree(c, t.ProjectID, false)
+		if err != nil {
+			return nil, GetTaskOutput{Success: false, Message: err.Error()}, err
+		}
+		comments, err := c.GetComments(t.ID, "")
+		if err != nil {
+			return nil, GetTaskOutput{Success: false, Message: err.Error()}, err
+		}
+
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "Task: %s\nID: %s\n", t.Content, t.ID)
+		fmt.Fprintf(&sb, "Project: %s (ID: %s)\n", project.Name, project.ID)
+		if t.SectionID != "" {
+			sectionName := t.SectionID
+			if sections, err := c.GetSections(t.ProjectID); err == nil {
+				for _, sec := range sections {
+					if sec.ID == t.SectionID {
+						sectionName = sec.Name
+					}
+				}
+			}
+			fmt.Fprintf(&sb, "Section: %s (ID: %s)\n", sectionName, t.SectionID)
+		}
+		if len(chain) > 0 {
+			names := make([]string, len(chain))
+			for i, p := range chain {
+				names[i] = fmt.Sprintf("%s (ID: %s)", p.Content, p.ID)
+			}
+			fmt.Fprintf(&sb, "Parent chain: %s\n", strings.Join(names, " > "))
+		}
+		if t.Description != "" {
+			fmt.Fprintf(&sb, "Description: %s\n", t.Description)
+		}
+		fmt.Fprintf(&sb, "Priority: %d\n", t.Priority)
+		if t.Due != nil {
+			due := t.Due.Date
+			if t.Due.Datetime != "" {
+				due = t.Due.Datetime
+			}
+			if t.Due.String != "" {
+				due = fmt.Sprintf("%s (%s)", t.Due.String, due)
+			}
+			if t.Due.Timezone != "" {
+				due += " " + t.Due.Timezone
+			}
+			if t.Due.Recurring {
+				due += " [recurring]"
+			}
+			fmt.Fprintf(&sb, "Due: %s\n", due)
+		}
+		if t.Deadline != nil && t.Deadline.Date != "" {
+			fmt.Fprintf(&sb, "Deadline: %s\n", t.Deadline.Date)
+		}
+		if t.Duration != nil {
+			fmt.Fprintf(&sb, "Duration: %d %s\n", t.Duration.Amount, t.Duration.Unit)
+		}
+		if len(t.Labels) > 0 {
+			fmt.Fprintf(&sb, "Labels: %s\n", strings.Join(t.Labels, ", "))
+		}
+		if t.AssigneeID != "" {
+			assignee := t.AssigneeID
+			if people, err := c.GetProjectCollaborators(t.ProjectID); err == nil {
+				for _, p := range people {
+					if p.ID == t.AssigneeID {
+						assignee = fmt.Sprintf("%s <%s> (ID: %s)", p.Name, p.Email, p.ID)
+					}
+				}
+			}
+			fmt.Fprintf(&sb, "Assignee: %s\n", assignee)
+		}
+		if t.AssignedByUID != "" {
+			fmt.Fprintf(&sb, "Assigned by: %s\n", t.AssignedByUID)
+		}
+		if t.CreatorID != "" {
+			fmt.Fprintf(&sb, "Creator: %s\n", t.CreatorID)
+		}
+		if !t.CreatedAt.IsZero() {
+			fmt.Fprintf(&sb, "Created: %s\n", t.CreatedAt.Format("2006-01-02 15:04"))
+		}
+		if t.UpdatedAt != "" {
+			fmt.Fprintf(&sb, "Updated: %s\n", t.UpdatedAt)
+		}
+		fmt.Fprintf(&sb, "Completed: %v\n", t.IsCompleted)
+		if t.CompletedAt != "" {
+			fmt.Fprintf(&sb, "Completed at: %s\n", t.CompletedAt)
+		}
+		if t.URL != "" {
+			fmt.Fprintf(&sb, "URL: %s\n", t.URL)
+		}
+
+		if node := tree.Find(t.ID); node != nil && len(node.Children) > 0 {
+			done, total := node.Progress()
+			fmt.Fprintf(&sb, "\n### Subtasks (%d/%d done)\n", done, total)
+			for _, child := range node.Children {
+				writeTaskTree(&sb, child)
+			}
+		}
+
+		fmt.Fprintf(&sb, "\n### Comments (%d)\n", len(comments))
+		var attachments []models.FileAttachment
+		for _, cm := range comments {
+			fmt.Fprintf(&sb, "- [%s] %s (ID: %s)\n", cm.PostedAt.Format("2006-01-02 15:04"), cm.Content, cm.ID)
+			if cm.Attachment != nil {
+				attachments = append(attachments, *cm.Attachment)
+			}
+		}
+
+		if len(attachments) > 0 {
+			fmt.Fprintf(&sb, "\n### Attachments (%d)\n", len(attachments))
+			for _, a := range attachments {
+				name, link := a.FileName, a.FileURL
+				if name == "" {
+					name = a.Title
+				}
+				if link == "" {
+					link = a.URL
+				}
+				line := "- " + name
+				if a.FileType != "" {
+					line += fmt.Sprintf(" (%s)", a.FileType)
+				}
+				fmt.Fprintf(&sb, "%s: %s\n", line, link)
+			}
+		}
+
+		// Reminders come from the Sync API; treat failures as non-fatal.
+		if reminders, err := c.GetReminders(t.ID); err != nil {
+			fmt.Fprintf(&sb, "\n### Reminders\n(unavailable: %s)\n", err.Error())
+		} else {
+			fmt.Fprintf(&sb, "\n### Reminders (%d)\n", len(reminders))
+			for _, r := range reminders {
+				fmt.Fprintf(&sb, "- %s (ID: %s)\n", describeReminder(r), r.ID)
+			}
+		}
+
+		msg := strings.TrimRight(sb.String(), "\n")
+		return withAttachmentLinks(textResult(msg, false), comments), GetTaskOutput{Success: true, Message: msg}, nil
+	})
+
 	mcp.AddTool(s, &mcp.Tool{
 		Name:        "todoist_update_task",
 		Description: "Update an existing task in Todoist by task_id or by searching by name",
@@ -237,8 +553,10 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		if input.Description != "" {
 			body["description"] = input.Description
 		}
-		if input.DueString != "" {
-			body["due_string"] = input.DueString
+		due := dueFields{input.DueString, input.DueDate, input.DueDatetime, input.DueLang, input.Duration, input.DurationUnit, input.DeadlineDate}
+		if err := due.apply(body); err != nil {
+			msg := err.Error()
+			return textResult(msg, true), UpdateTaskOutput{Success: false, Message: msg}, nil
 		}
 		if input.Priority > 0 && input.Priority <= 4 {
 			body["priority"] = input.Priority
@@ -249,6 +567,49 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		if input.AssigneeID != "" {
 			body["assignee_id"] = input.AssigneeID
 		}
+		if input.Assignee != "" {
+			current, err := c.GetTask(id)
+			if err != nil {
+				return nil, UpdateTaskOutput{Success: false, Message: err.Error()}, err
+			}
+			uid, err := resolveAssignee(c, current.ProjectID, input.Assignee)
+			if err != nil {
+				msg := err.Error()
+				return textResult(msg, true), UpdateTaskOutput{Success: false, Message: msg}, nil
+			}
+			body["assignee_id"] = uid
+		}
+
+		// Explicit clears send the API's "unset" values, which empty inputs
+		// cannot express.
+		conflicts := []struct {
+			what     string
+			conflict bool
+		}{
+			{"clear_due and a new due date", input.ClearDue && (input.DueString != "" || input.DueDate != "" || input.DueDatetime != "")},
+			{"clear_deadline and deadline_date", input.ClearDeadline && input.DeadlineDate != ""},
+			{"clear_duration and duration", input.ClearDuration && input.Duration > 0},
+			{"clear_labels and labels", input.ClearLabels && len(input.Labels) > 0},
+		}
+		for _, cf := range conflicts {
+			if cf.conflict {
+				msg := fmt.Sprintf("Cannot combine %s", cf.what)
+				return textResult(msg, true), UpdateTaskOutput{Success: false, Message: msg}, nil
+			}
+		}
+		if input.ClearDue {
+			body["due_string"] = "no date"
+		}
+		if input.ClearDeadline {
+			body["deadline_date"] = nil
+		}
+		if input.ClearDuration {
+			body["duration"] = nil
+			body["duration_unit"] = nil
+		}
+		if input.ClearLabels {
+			body["labels"] = []string{}
+		}
 
 		updated, err := c.UpdateTask(id, body)
 		if err != nil {
@@ -266,6 +627,7 @@ func registerTaskTools(s *mcp.Server, c *todoist.Client) {
 		if updated.Due != nil && updated.Due.String != "" {
 			msg += fmt.Sprintf("\nNew Due Date: %s", updated.Due.String)
 		}
+		msg += describeExtras(updated, "New ")
 		if updated.Priority > 0 {
 			msg += fmt.Sprintf("\nNew Priority: %d", updated.Priority)
 		}
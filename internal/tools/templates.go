@@ -0,0 +1,128 @@
+package tools
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+type ExportProjectTemplateInput struct {
+	ProjectID string `json:"project_id" jsonschema:"The project ID to export"`
+	FilePath  string `json:"file_path,omitempty" jsonschema:"Write the CSV to this file instead of returning it (optional)"`
+}
+type ExportProjectTemplateOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+type ImportProjectTemplateInput struct {
+	FilePath       string `json:"file_path,omitempty" jsonschema:"Path of a Todoist CSV template file (provide file_path or csv)"`
+	CSV            string `json:"csv,omitempty" jsonschema:"Template contents in Todoist CSV format (provide file_path or csv)"`
+	ProjectID      string `json:"project_id,omitempty" jsonschema:"Existing project ID to import into (provide project_id or new_project_name)"`
+	NewProjectName string `json:"new_project_name,omitempty" jsonschema:"Create a new project with this name and import into it"`
+}
+type ImportProjectTemplateOutput struct {
+	Success bool   `json:"success"`
+	Message string `json:"message"`
+}
+
+func registerTemplateTools(s *mcp.Server, c *todoist.Client) {
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_export_project_template",
+		Description: "Export a project's sections, tasks, subtasks, labels and descriptions as a Todoist CSV template",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ExportProjectTemplateInput) (*mcp.CallToolResult, ExportProjectTemplateOutput, error) {
+		sections, err := c.GetSections(input.ProjectID)
+		if err != nil {
+			return nil, ExportProjectTemplateOutput{Success: false, Message: err.Error()}, err
+		}
+		tasks, err := c.GetTasks(input.ProjectID, "")
+		if err != nil {
+			return nil, ExportProjectTemplateOutput{Success: false, Message: err.Error()}, err
+		}
+
+		tmpl := todoist.BuildTemplate(sections, tasks)
+		var buf bytes.Buffer
+		if err := tmpl.WriteCSV(&buf); err != nil {
+			return nil, ExportProjectTemplateOutput{Success: false, Message: err.Error()}, err
+		}
+
+		summary := fmt.Sprintf("Exported %d sections and %d tasks from project %s", len(sections), len(tasks), input.ProjectID)
+		if input.FilePath == "" {
+			msg := summary + ":\n\n" + buf.String()
+			return textResult(msg, false), ExportProjectTemplateOutput{Success: true, Message: msg}, nil
+		}
+		if err := os.WriteFile(input.FilePath, buf.Bytes(), 0o644); err != nil {
+			msg := fmt.Sprintf("Failed to write template: %s", err.Error())
+			return textResult(msg, true), ExportProjectTemplateOutput{Success: false, Message: msg}, nil
+		}
+		msg := fmt.Sprintf("%s to %s", summary, input.FilePath)
+		return textResult(msg, false), ExportProjectTemplateOutput{Success: true, Message: msg}, nil
+	})
+
+	mcp.AddTool(s, &mcp.Tool{
+		Name:        "todoist_import_project_template",
+		Description: "Import a Todoist CSV template into an existing project or a new one, creating its sections, tasks and subtasks",
+	}, func(ctx context.Context, req *mcp.CallToolRequest, input ImportProjectTemplateInput) (*mcp.CallToolResult, ImportProjectTemplateOutput, error) {
+		var src io.Reader
+		switch {
+		case input.FilePath != "" && input.CSV != "":
+			msg := "Provide file_path or csv, not both"
+			return textResult(msg, true), ImportProjectTemplateOutput{Success: false, Message: msg}, nil
+		case input.FilePath != "":
+			f, err := os.Open(input.FilePath)
+			if err != nil {
+				msg := fmt.Sprintf("Failed to open template: %s", err.Error())
+				return textResult(msg, true), ImportProjectTemplateOutput{Success: false, Message: msg}, nil
+			}
+			defer func() { _ = f.Close() }()
+			src = f
+		case input.CSV != "":
+			src = strings.NewReader(input.CSV)
+		default:
+			msg := "Provide a template via file_path or csv"
+			return textResult(msg, true), ImportProjectTemplateOutput{Success: false, Message: msg}, nil
+		}
+		if (input.ProjectID == "") == (input.NewProjectName == "") {
+			msg := "Provide exactly one of project_id or new_project_name"
+			return textResult(msg, true), ImportProjectTemplateOutput{Success: false, Message: msg}, nil
+		}
+
+		tmpl, err := todoist.ParseTemplate(src)
+		if err != nil {
+			msg := fmt.Sprintf("Invalid template: %s", err.Error())
+			return textResult(msg, true), ImportProjectTemplateOutput{Success: false, Message: msg}, nil
+		}
+
+		projectID, projectLabel := input.ProjectID, input.ProjectID
+		if input.NewProjectName != "" {
+			p, err := c.CreateProject(map[string]interface{}{"name": input.NewProjectName})
+			if err != nil {
+				return nil, ImportProjectTemplateOutput{Success: false, Message: err.Error()}, err
+			}
+			projectID = p.ID
+			projectLabel = fmt.Sprintf("\"%s\" (ID: %s)", p.Name, p.ID)
+		}
+
+		result, err := c.ImportTemplate(tmpl, projectID)
+		if err != nil {
+			msg := fmt.Sprintf("Template import into project %s failed: %s", projectLabel, err.Error())
+			if result != nil {
+				msg += fmt.Sprintf(" (created %d sections and %d tasks before the error)", result.SectionsCreated, len(result.TaskIDs))
+			}
+			return textResult(msg, true), ImportProjectTemplateOutput{Success: false, Message: msg}, nil
+		}
+
+		msg := fmt.Sprintf("Imported template into project %s: %d sections created, %d reused, %d tasks created",
+			projectLabel, result.SectionsCreated, result.SectionsReused, len(result.TaskIDs))
+		if tmpl.Skipped > 0 {
+			msg += fmt.Sprintf(" (%d comment/meta rows skipped)", tmpl.Skipped)
+		}
+		return textResult(msg, false), ImportProjectTemplateOutput{Success: true, Message: msg}, nil
+	})
+}
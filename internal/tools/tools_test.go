@@ -2,11 +2,17 @@ package tools
 
 import (
 	"context"
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"net/http/httptest"
+	"os"
+	"path/filepath"
 	"strings"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/modelcontextprotocol/go-sdk/mcp"
 	"github.com/nsega/mcp-todoist/internal/todoist"
@@ -108,6 +114,45 @@ func resultText(r *mcp.CallToolResult) string {
 	return ""
 }
 
+func TestRejectLocalFiles(t *testing.T) {
+	rt := newRouter()
+	rt.handle("POST", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"c1","content":"Hi"}`))
+	})
+	apiSrv := httptest.NewServer(rt)
+	defer apiSrv.Close()
+	server := mcp.NewServer(&mcp.Implementation{Name: "test", Version: "0.0.1"}, nil)
+	RegisterAll(server, todoist.NewClient("test-token", todoist.WithBaseURL(apiSrv.URL)))
+	RejectLocalFiles(server)
+
+	ct, st := mcp.NewInMemoryTransports()
+	ss, err := server.Connect(context.Background(), st, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ss.Close()
+	cs, err := mcp.NewClient(&mcp.Implementation{Name: "test-client", Version: "0.0.1"}, nil).Connect(context.Background(), ct, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer cs.Close()
+
+	result := callTool(t, cs, "todoist_create_comment", map[string]interface{}{"task_id": "1", "content": "Hi"})
+	if result.IsError || resultText(result) != "Comment created (ID: c1): Hi" {
+		t.Errorf("plain comment: %s", resultText(result))
+	}
+	result = callTool(t, cs, "todoist_create_comment", map[string]interface{}{
+		"task_id": "1", "attachments": []map[string]interface{}{{"file_path": "/etc/passwd"}},
+	})
+	if !result.IsError || !strings.HasPrefix(resultText(result), "file_path is not available over HTTP") {
+		t.Errorf("attachment by path: %s", resultText(result))
+	}
+	result = callTool(t, cs, "todoist_export_tasks", map[string]interface{}{"file_path": "/tmp/out.csv"})
+	if !result.IsError {
+		t.Errorf("export to path: %s", resultText(result))
+	}
+}
+
 // --- Task tool tests ---
 
 func TestCreateTaskTool(t *testing.T) {
@@ -424,3 +469,1533 @@ func TestMoveTaskTool(t *testing.T) {
 		t.Errorf("unexpected result: %s", text)
 	}
 }
+
+// --- Subtask tool tests ---
+
+const treeTasksJSON = `{"results":[
+	{"id":"1","content":"Launch","project_id":"p1","child_order":1},
+	{"id":"2","content":"Design","project_id":"p1","parent_id":"1","child_order":1},
+	{"id":"3","content":"Build","project_id":"p1","parent_id":"1","child_order":2},
+	{"id":"4","content":"Backend","project_id":"p1","parent_id":"3","child_order":1}
+],"next_cursor":""}`
+
+func treeRouter(onMove http.HandlerFunc) *router {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(treeTasksJSON))
+	})
+	rt.handle("GET", "/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		id := strings.TrimPrefix(r.URL.Path, "/tasks/")
+		_, _ = w.Write([]byte(`{"id":"` + id + `","content":"task","project_id":"p1"}`))
+	})
+	rt.handle("GET", "/tasks/completed", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"items":[{"id":"5","content":"Research","project_id":"p1","parent_id":"1"}]}`))
+	})
+	if onMove != nil {
+		rt.handle("POST", "/tasks/", onMove)
+	}
+	return rt
+}
+
+func TestGetTaskTreeTool(t *testing.T) {
+	cs, cleanup := setupTest(t, treeRouter(nil))
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_task_tree", map[string]interface{}{
+		"task_id":           "1",
+		"include_completed": true,
+	})
+	text := resultText(result)
+	for _, want := range []string{
+		"- [ ] Launch (ID: 1) — 1/4 subtasks done",
+		"  - [x] Research (ID: 5)",
+		"    - [ ] Backend (ID: 4)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+func TestIndentTaskTool(t *testing.T) {
+	var moved string
+	cs, cleanup := setupTest(t, treeRouter(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		moved = r.URL.Path + " " + string(body)
+		_, _ = w.Write([]byte(`{"id":"3","content":"Build"}`))
+	}))
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_indent_task", map[string]interface{}{"task_id": "3"})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+	if moved != `/tasks/3/move {"parent_id":"2"}` {
+		t.Errorf("move request = %s", moved)
+	}
+
+	result = callTool(t, cs, "todoist_indent_task", map[string]interface{}{"task_id": "2"})
+	if !result.IsError || !strings.Contains(resultText(result), "no task above") {
+		t.Errorf("expected indent of first sibling to fail, got: %s", resultText(result))
+	}
+}
+
+func TestOutdentTaskTool(t *testing.T) {
+	var moved string
+	cs, cleanup := setupTest(t, treeRouter(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		moved = r.URL.Path + " " + string(body)
+		_, _ = w.Write([]byte(`{"id":"2","content":"Design"}`))
+	}))
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_outdent_task", map[string]interface{}{"task_id": "4"})
+	if !strings.Contains(resultText(result), "Outdented") || moved != `/tasks/4/move {"parent_id":"1"}` {
+		t.Errorf("result=%s move=%s", resultText(result), moved)
+	}
+
+	callTool(t, cs, "todoist_outdent_task", map[string]interface{}{"task_id": "2"})
+	if moved != `/tasks/2/move {"project_id":"p1"}` {
+		t.Errorf("move request = %s", moved)
+	}
+}
+
+func TestReparentTaskTool_rejectsCycle(t *testing.T) {
+	cs, cleanup := setupTest(t, treeRouter(func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected move request")
+	}))
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_reparent_task", map[string]interface{}{
+		"task_id":   "1",
+		"parent_id": "4",
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "own subtask") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+func TestCreateTaskTreeTool(t *testing.T) {
+	rt := newRouter()
+	var mu sync.Mutex
+	var parents []string
+	n := 0
+	rt.handle("POST", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		n++
+		id := fmt.Sprintf("t%d", n)
+		parent, _ := body["parent_id"].(string)
+		parents = append(parents, parent)
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"id":%q,"content":%q}`, id, body["content"])
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_create_task_tree", map[string]interface{}{
+		"content": "Trip",
+		"subtasks": []map[string]interface{}{
+			{"content": "Book flights"},
+			{"content": "Compare prices", "indent": 2},
+			{"content": "Pack"},
+		},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+	if got := strings.Join(parents, ","); got != ",t1,t2,t1" {
+		t.Errorf("parents = %s", got)
+	}
+
+	result = callTool(t, cs, "todoist_create_task_tree", map[string]interface{}{
+		"content":  "Bad",
+		"subtasks": []map[string]interface{}{{"content": "Too deep", "indent": 2}},
+	})
+	if !result.IsError {
+		t.Error("expected invalid indent to be rejected")
+	}
+}
+
+func TestGetTaskTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/tasks/3":
+			_, _ = w.Write([]byte(`{"id":"3","content":"Build","project_id":"p1","section_id":"s1","parent_id":"1","labels":["dev"],"due":{"date":"2026-10-20","string":"Oct 20"},"duration":{"amount":45,"unit":"minute"}}`))
+		case "/tasks/1":
+			_, _ = w.Write([]byte(`{"id":"1","content":"Launch","project_id":"p1"}`))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(treeTasksJSON))
+	})
+	rt.handle("GET", "/projects/p1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"p1","name":"Work"}`))
+	})
+	rt.handle("GET", "/sections", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"s1","name":"Doing","project_id":"p1"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"c1","content":"Spec attached","posted_at":"2026-10-01T10:00:00Z","file_attachment":{"file_name":"spec.pdf","file_type":"application/pdf","file_url":"https://files.example/spec.pdf"}}],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"reminders":[{"id":"r1","item_id":"3","type":"relative","minute_offset":30}]}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_task", map[string]interface{}{"task_id": "3"})
+	text := resultText(result)
+	for _, want := range []string{
+		"Project: Work (ID: p1)",
+		"Section: Doing (ID: s1)",
+		"Parent chain: Launch (ID: 1)",
+		"Duration: 45 minute",
+		"### Subtasks (0/1 done)",
+		"- [ ] Backend (ID: 4)",
+		"Spec attached",
+		"- spec.pdf (application/pdf): https://files.example/spec.pdf",
+		"- 30 minutes before due (ID: r1)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("missing %q in:\n%s", want, text)
+		}
+	}
+}
+
+// --- Reminder tool tests ---
+
+func TestParseReminderPhrase(t *testing.T) {
+	tests := []struct {
+		phrase string
+		want   string
+	}{
+		{"30 minutes before", `{"minute_offset":30,"type":"relative"}`},
+		{"remind me 30 minutes before", `{"minute_offset":30,"type":"relative"}`},
+		{"1 hour before", `{"minute_offset":60,"type":"relative"}`},
+		{"2 days before due", `{"minute_offset":2880,"type":"relative"}`},
+		{"at due time", `{"minute_offset":0,"type":"relative"}`},
+		{"at tomorrow 9am", `{"due":{"string":"tomorrow 9am"},"type":"absolute"}`},
+	}
+	for _, tt := range tests {
+		data, _ := json.Marshal(parseReminderPhrase(tt.phrase))
+		if string(data) != tt.want {
+			t.Errorf("parseReminderPhrase(%q) = %s, want %s", tt.phrase, data, tt.want)
+		}
+	}
+}
+
+func TestAddReminderTool(t *testing.T) {
+	rt := newRouter()
+	var args map[string]interface{}
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []struct {
+			UUID   string                 `json:"uuid"`
+			TempID string                 `json:"temp_id"`
+			Args   map[string]interface{} `json:"args"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		args = cmds[0].Args
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"},"temp_id_mapping":{%q:"r1"}}`, cmds[0].UUID, cmds[0].TempID)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_add_reminder", map[string]interface{}{
+		"task_id": "42",
+		"when":    "remind me 30 minutes before",
+	})
+	text := resultText(result)
+	if !strings.Contains(text, "30 minutes before due (ID: r1)") {
+		t.Errorf("unexpected result: %s", text)
+	}
+	if args["item_id"] != "42" || args["type"] != "relative" {
+		t.Errorf("unexpected args: %v", args)
+	}
+}
+
+func TestGetRemindersTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"reminders":[{"id":"r1","item_id":"42","type":"location","name":"Office","loc_trigger":"on_leave"}]}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_reminders", map[string]interface{}{})
+	if !strings.Contains(resultText(result), "when leaving Office (ID: r1, Task: 42)") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+// --- Scheduling field tests ---
+
+func TestCreateTaskTool_durationAndDeadline(t *testing.T) {
+	rt := newRouter()
+	var body map[string]interface{}
+	rt.handle("POST", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id":"1","content":"Review","deadline":{"date":"2026-10-30"},"duration":{"amount":45,"unit":"minute"}}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_create_task", map[string]interface{}{
+		"content":       "Review",
+		"due_datetime":  "2026-10-20T09:00:00Z",
+		"duration":      45,
+		"deadline_date": "2026-10-30",
+	})
+	text := resultText(result)
+	if !strings.Contains(text, "Deadline: 2026-10-30") || !strings.Contains(text, "Duration: 45 minute") {
+		t.Errorf("unexpected result: %s", text)
+	}
+	if body["due_datetime"] != "2026-10-20T09:00:00Z" || body["duration_unit"] != "minute" || body["deadline_date"] != "2026-10-30" {
+		t.Errorf("unexpected body: %v", body)
+	}
+}
+
+func TestCreateTaskTool_conflictingDueFields(t *testing.T) {
+	rt := newRouter()
+	rt.handle("POST", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		t.Error("unexpected create request")
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_create_task", map[string]interface{}{
+		"content":    "Review",
+		"due_string": "tomorrow",
+		"due_date":   "2026-10-20",
+	})
+	if !result.IsError {
+		t.Errorf("expected error, got: %s", resultText(result))
+	}
+}
+
+func TestUpdateTaskTool_clearFields(t *testing.T) {
+	rt := newRouter()
+	var raw string
+	rt.handle("POST", "/tasks/42", func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		raw = string(data)
+		_, _ = w.Write([]byte(`{"id":"42","content":"Task"}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_update_task", map[string]interface{}{
+		"task_id":        "42",
+		"clear_due":      true,
+		"clear_deadline": true,
+		"clear_labels":   true,
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+	for _, want := range []string{`"due_string":"no date"`, `"deadline_date":null`, `"labels":[]`} {
+		if !strings.Contains(raw, want) {
+			t.Errorf("body %s missing %s", raw, want)
+		}
+	}
+
+	result = callTool(t, cs, "todoist_update_task", map[string]interface{}{
+		"task_id":    "42",
+		"clear_due":  true,
+		"due_string": "today",
+	})
+	if !result.IsError {
+		t.Error("expected conflicting clear_due and due_string to fail")
+	}
+}
+
+// --- Label propagation tests ---
+
+func TestUpdateLabelTool_propagatesRename(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/labels", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"errand"}],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/labels/l1", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"l1","name":"errands"}`))
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"a","labels":["errand"]},{"id":"2","content":"b"}],"next_cursor":""}`))
+	})
+	var relabelled []string
+	rt.handle("POST", "/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		relabelled = append(relabelled, r.URL.Path)
+		_, _ = w.Write([]byte(`{"id":"1","content":"a"}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_update_label", map[string]interface{}{
+		"label_id": "l1",
+		"name":     "errands",
+	})
+	if !strings.Contains(resultText(result), "Relabelled 1 tasks") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+	if len(relabelled) != 1 || relabelled[0] != "/tasks/1" {
+		t.Errorf("relabelled = %v", relabelled)
+	}
+}
+
+func TestMergeLabelsTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"a","labels":["todo","next"]}],"next_cursor":""}`))
+	})
+	var labels []interface{}
+	rt.handle("POST", "/tasks/1", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		labels = body["labels"].([]interface{})
+		_, _ = w.Write([]byte(`{"id":"1","content":"a"}`))
+	})
+	rt.handle("GET", "/labels", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"todo"},{"id":"l2","name":"next"}],"next_cursor":""}`))
+	})
+	deleted := ""
+	rt.handle("DELETE", "/labels/", func(w http.ResponseWriter, r *http.Request) {
+		deleted = r.URL.Path
+		w.WriteHeader(http.StatusNoContent)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_merge_labels", map[string]interface{}{
+		"source": "todo",
+		"target": "next",
+	})
+	if !strings.Contains(resultText(result), "on 1 tasks") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+	if fmt.Sprint(labels) != "[next]" {
+		t.Errorf("labels = %v", labels)
+	}
+	if deleted != "/labels/l1" {
+		t.Errorf("deleted = %q", deleted)
+	}
+}
+
+func TestGetLabelsTool_includeShared(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/labels", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"mine"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/labels/shared", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":["team"],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_labels", map[string]interface{}{"include_shared": true})
+	if !strings.Contains(resultText(result), "- team [Shared]") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+// --- Collaborator tool tests ---
+
+func TestGetCollaboratorsTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects/p1/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"u1","name":"Ada Lovelace","email":"ada@example.com"}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_collaborators", map[string]interface{}{"project_id": "p1"})
+	if !strings.Contains(resultText(result), "Ada Lovelace <ada@example.com> (ID: u1)") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+func TestCreateTaskTool_assigneeByName(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects/p1/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"u1","name":"Ada Lovelace","email":"ada@example.com"},{"id":"u2","name":"Alan Turing","email":"alan@example.com"}],"next_cursor":""}`))
+	})
+	var body map[string]interface{}
+	rt.handle("POST", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		_, _ = w.Write([]byte(`{"id":"1","content":"Review"}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_create_task", map[string]interface{}{
+		"content":    "Review",
+		"project_id": "p1",
+		"assignee":   "alan@example.com",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+	if body["assignee_id"] != "u2" {
+		t.Errorf("assignee_id = %v", body["assignee_id"])
+	}
+
+	result = callTool(t, cs, "todoist_create_task", map[string]interface{}{
+		"content":    "Review",
+		"project_id": "p1",
+		"assignee":   "grace",
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "no collaborator") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+func TestChangeCollaboratorRoleTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects/p1/collaborators", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"u1","name":"Ada Lovelace","email":"ada@example.com"},{"id":"u2","name":"Alan Turing","email":"alan@example.com"}],"next_cursor":""}`))
+	})
+	var args map[string]interface{}
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []struct {
+			Type string                 `json:"type"`
+			UUID string                 `json:"uuid"`
+			Args map[string]interface{} `json:"args"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		if cmds[0].Type == "change_collaborator_role" {
+			args = cmds[0].Args
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"}}`, cmds[0].UUID)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_change_collaborator_role", map[string]interface{}{
+		"project_id":   "p1",
+		"collaborator": "Alan",
+		"role":         "read_only",
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+	if args["user_id"] != "u2" || args["role"] != "READ_ONLY" {
+		t.Errorf("args = %v", args)
+	}
+
+	result = callTool(t, cs, "todoist_change_collaborator_role", map[string]interface{}{
+		"project_id":   "p1",
+		"collaborator": "grace",
+		"role":         "ADMIN",
+	})
+	if !result.IsError {
+		t.Errorf("expected error for unknown collaborator: %s", resultText(result))
+	}
+}
+
+func TestGetInvitationsTool_empty(t *testing.T) {
+	rt := newRouter()
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"live_notifications":[]}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_invitations", map[string]interface{}{})
+	if resultText(result) != "No pending invitations" {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+func TestAcceptInvitationTool(t *testing.T) {
+	rt := newRouter()
+	var accepted map[string]interface{}
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("commands") == "" {
+			_, _ = w.Write([]byte(`{"live_notifications":[{"notification_type":"share_invitation_sent","invitation_id":"i1","invitation_secret":"s1","project_id":"p1","project_name":"Trip","state":"invited"}]}`))
+			return
+		}
+		var cmds []struct {
+			Type string                 `json:"type"`
+			UUID string                 `json:"uuid"`
+			Args map[string]interface{} `json:"args"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		if cmds[0].Type == "accept_invitation" {
+			accepted = cmds[0].Args
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"}}`, cmds[0].UUID)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_accept_invitation", map[string]interface{}{"invitation_id": "i1"})
+	if result.IsError || resultText(result) != "Accepted invitation to Trip" {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+	if accepted["invitation_secret"] != "s1" {
+		t.Errorf("accept args = %v", accepted)
+	}
+
+	result = callTool(t, cs, "todoist_reject_invitation", map[string]interface{}{"invitation_id": "nope"})
+	if !result.IsError {
+		t.Errorf("expected error for unknown invitation: %s", resultText(result))
+	}
+}
+
+func TestSentInvitationsTools(t *testing.T) {
+	rt := newRouter()
+	var removed map[string]interface{}
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		if r.PostForm.Get("commands") == "" {
+			_, _ = w.Write([]byte(`{"live_notifications":[],"projects":[{"id":"p5","name":"Garden"}],
+				"collaborators":[{"id":"u2","email":"bo@example.com"}],
+				"collaborator_states":[{"project_id":"p5","user_id":"u2","state":"invited"}]}`))
+			return
+		}
+		var cmds []struct {
+			Type string                 `json:"type"`
+			UUID string                 `json:"uuid"`
+			Args map[string]interface{} `json:"args"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		if cmds[0].Type == "delete_collaborator" {
+			removed = cmds[0].Args
+		}
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:"ok"}}`, cmds[0].UUID)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	text := resultText(callTool(t, cs, "todoist_get_invitations", map[string]interface{}{}))
+	if want := "- Garden: sent to bo@example.com (revoke with project_id p5 and this email)"; text != want {
+		t.Errorf("result = %q, want %q", text, want)
+	}
+
+	result := callTool(t, cs, "todoist_revoke_invitation", map[string]interface{}{"project_id": "p5", "email": "bo@example.com"})
+	if result.IsError || removed["project_id"] != "p5" || removed["email"] != "bo@example.com" {
+		t.Errorf("result = %s, args = %v", resultText(result), removed)
+	}
+
+	result = callTool(t, cs, "todoist_revoke_invitation", map[string]interface{}{"project_id": "p5"})
+	if !result.IsError {
+		t.Errorf("expected error without an email: %s", resultText(result))
+	}
+}
+
+// --- Activity tool tests ---
+
+func TestGetActivityTool(t *testing.T) {
+	rt := newRouter()
+	var query string
+	rt.handle("GET", "/activities", func(w http.ResponseWriter, r *http.Request) {
+		query = r.URL.RawQuery
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"e1","object_type":"item","object_id":"1","event_type":"completed","event_date":"2026-10-18T09:00:00Z","parent_project_id":"p1","initiator_id":"u1","extra_data":{"content":"Write report"}}
+		],"next_cursor":"c2"}`))
+	})
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Work"}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_activity", map[string]interface{}{
+		"object_type": "task",
+		"project_id":  "p1",
+	})
+	text := resultText(result)
+	if !strings.Contains(text, `task completed: "Write report" (ID: 1) in Work by u1`) {
+		t.Errorf("unexpected result: %s", text)
+	}
+	if !strings.Contains(text, `cursor "c2"`) {
+		t.Errorf("missing cursor: %s", text)
+	}
+	if !strings.Contains(query, "object_type=item") || !strings.Contains(query, "limit=50") {
+		t.Errorf("query = %s", query)
+	}
+
+	// A page emptied by local date filtering still hands back the cursor.
+	result = callTool(t, cs, "todoist_get_activity", map[string]interface{}{"until": "2026-10-01"})
+	if text := resultText(result); result.IsError || !strings.Contains(text, `cursor "c2"`) {
+		t.Errorf("expected cursor on filtered-out page: %s", text)
+	}
+
+	result = callTool(t, cs, "todoist_get_activity", map[string]interface{}{"object_type": "label"})
+	if !result.IsError {
+		t.Errorf("expected error for unknown object type: %s", resultText(result))
+	}
+
+	result = callTool(t, cs, "todoist_get_activity", map[string]interface{}{"since": "last week"})
+	if !result.IsError {
+		t.Errorf("expected error for invalid date: %s", resultText(result))
+	}
+}
+
+// --- Stats tool tests ---
+
+func TestGetProductivityStatsTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks/completed/stats", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{
+			"completed_count": 120,
+			"karma": 5230,
+			"karma_trend": "up",
+			"days_items": [
+				{"date":"2026-10-18","total_completed":6},
+				{"date":"2026-10-17","total_completed":2}
+			],
+			"week_items": [{"from":"2026-10-12","to":"2026-10-18","total_completed":21,"items":[{"id":"p1","completed":21}]}],
+			"karma_update_reasons": [{"time":"2026-10-18","new_karma":5230,"positive_karma":12,"negative_karma":2}],
+			"goals": {"daily_goal":5,"weekly_goal":25,"current_daily_streak":{"count":1},"max_daily_streak":{"count":9}}
+		}`))
+	})
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Work"}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_productivity_stats", map[string]interface{}{"include_projects": true})
+	text := resultText(result)
+	for _, want := range []string{
+		"Karma: 5230 (trend: up)",
+		"Daily streak: 1 (best 9)",
+		"- 2026-10-17: 2 / 5\n- 2026-10-18: 6 / 5 ✓",
+		"- 2026-10-12 to 2026-10-18: 21 / 25\n  - Work: 21",
+		"- 2026-10-18: 5230 (+10)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if result.StructuredContent == nil {
+		t.Error("expected structured output")
+	}
+}
+
+// --- Attachment tests ---
+
+func TestCreateCommentTool_attachments(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "agenda.md")
+	if err := os.WriteFile(path, []byte("# Agenda"), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	rt := newRouter()
+	var uploaded []string
+	rt.handle("POST", "/uploads", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		f, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, _ := io.ReadAll(f)
+		_ = f.Close()
+		uploaded = append(uploaded, hdr.Filename+"="+string(data))
+		_, _ = fmt.Fprintf(w, `{"file_name":%q,"file_type":%q,"file_size":%d,"file_url":"https://files.example.com/%s"}`,
+			hdr.Filename, hdr.Header.Get("Content-Type"), len(data), hdr.Filename)
+	})
+	var bodies []map[string]interface{}
+	rt.handle("POST", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		out, _ := json.Marshal(map[string]interface{}{
+			"id":              fmt.Sprintf("c%d", len(bodies)),
+			"content":         body["content"],
+			"file_attachment": body["attachment"],
+		})
+		_, _ = w.Write(out)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_create_comment", map[string]interface{}{
+		"task_id": "1",
+		"content": "Files for the meeting",
+		"attachments": []map[string]interface{}{
+			{"file_path": path},
+			{"data": "aGVsbG8=", "file_name": "hello.txt"},
+		},
+	})
+	if result.IsError {
+		t.Fatalf("unexpected error: %s", resultText(result))
+	}
+	if strings.Join(uploaded, ",") != "agenda.md=# Agenda,hello.txt=hello" {
+		t.Errorf("uploaded = %v", uploaded)
+	}
+	if len(bodies) != 2 || bodies[0]["content"] != "Files for the meeting" || bodies[1]["content"] != "hello.txt" {
+		t.Fatalf("unexpected comment bodies: %v", bodies)
+	}
+	if a, _ := bodies[1]["attachment"].(map[string]interface{}); a["file_type"] != "text/plain; charset=utf-8" {
+		t.Errorf("attachment = %v", bodies[1]["attachment"])
+	}
+
+	var links []string
+	for _, c := range result.Content {
+		if l, ok := c.(*mcp.ResourceLink); ok {
+			links = append(links, l.URI)
+		}
+	}
+	if strings.Join(links, ",") != "https://files.example.com/agenda.md,https://files.example.com/hello.txt" {
+		t.Errorf("resource links = %v", links)
+	}
+
+	result = callTool(t, cs, "todoist_create_comment", map[string]interface{}{
+		"task_id":     "1",
+		"attachments": []map[string]interface{}{{"data": "not base64!"}},
+	})
+	if !result.IsError {
+		t.Errorf("expected error: %s", resultText(result))
+	}
+	if len(bodies) != 2 {
+		t.Errorf("no comment should be posted on invalid input, got %d", len(bodies))
+	}
+}
+
+func TestCreateCommentTool_attachmentFailsPartway(t *testing.T) {
+	rt := newRouter()
+	rt.handle("POST", "/uploads", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseMultipartForm(1 << 20)
+		_, hdr, err := r.FormFile("file")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if hdr.Filename == "b.txt" {
+			http.Error(w, "storage full", http.StatusInsufficientStorage)
+			return
+		}
+		_, _ = fmt.Fprintf(w, `{"file_name":%q,"file_url":"https://files.example.com/%s"}`, hdr.Filename, hdr.Filename)
+	})
+	rt.handle("POST", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"c1","content":"Notes","file_attachment":{"file_name":"a.txt"}}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_create_comment", map[string]interface{}{
+		"task_id": "1",
+		"content": "Notes",
+		"attachments": []map[string]interface{}{
+			{"data": "YQ==", "file_name": "a.txt"},
+			{"data": "Yg==", "file_name": "b.txt"},
+		},
+	})
+	text := resultText(result)
+	if !result.IsError || !strings.HasPrefix(text, "Creating comments failed after 1 of 2: ") ||
+		!strings.HasSuffix(text, "\n\nComment created (ID: c1): Notes [Attachment: a.txt]") {
+		t.Errorf("unexpected result: %s", text)
+	}
+}
+
+func TestGetCommentsTool_attachmentLinks(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"c1","content":"See file","posted_at":"2026-10-18T09:00:00Z","file_attachment":{"file_name":"plan.pdf","file_type":"application/pdf","file_size":2048,"file_url":"https://files.example.com/plan.pdf"}}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_get_comments", map[string]interface{}{"task_id": "1"})
+	if !strings.Contains(resultText(result), "[Attachment: plan.pdf]") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+	if len(result.Content) != 2 {
+		t.Fatalf("content = %d items, want text and resource link", len(result.Content))
+	}
+	link, ok := result.Content[1].(*mcp.ResourceLink)
+	if !ok || link.URI != "https://files.example.com/plan.pdf" || link.MIMEType != "application/pdf" || link.Size == nil || *link.Size != 2048 {
+		t.Errorf("unexpected link: %+v", result.Content[1])
+	}
+}
+
+// --- Template tool tests ---
+
+func TestProjectTemplateTools(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/sections", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"Pack","priority":4,"labels":["trip"]},{"id":"2","content":"Passport","parent_id":"1"}],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"p9","name":"Trip copy"}`))
+	})
+	var bodies []map[string]interface{}
+	rt.handle("POST", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		_, _ = fmt.Fprintf(w, `{"id":"n%d","content":%q}`, len(bodies), body["content"])
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "trip.csv")
+	result := callTool(t, cs, "todoist_export_project_template", map[string]interface{}{
+		"project_id": "p1",
+		"file_path":  path,
+	})
+	if result.IsError {
+		t.Fatalf("export failed: %s", resultText(result))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !strings.Contains(string(data), "task,Pack @trip,,1,1") || !strings.Contains(string(data), "task,Passport,,4,2") {
+		t.Errorf("unexpected CSV:\n%s", data)
+	}
+
+	result = callTool(t, cs, "todoist_import_project_template", map[string]interface{}{
+		"file_path":        path,
+		"new_project_name": "Trip copy",
+	})
+	if result.IsError {
+		t.Fatalf("import failed: %s", resultText(result))
+	}
+	if !strings.Contains(resultText(result), `"Trip copy" (ID: p9): 0 sections created, 0 reused, 2 tasks created`) {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+	if len(bodies) != 2 || bodies[0]["project_id"] != "p9" || bodies[1]["parent_id"] != "n1" {
+		t.Errorf("unexpected task bodies: %v", bodies)
+	}
+
+	result = callTool(t, cs, "todoist_import_project_template", map[string]interface{}{
+		"csv":        "TYPE,CONTENT\nbogus,x\n",
+		"project_id": "p1",
+	})
+	if !result.IsError || !strings.Contains(resultText(result), "Invalid template") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+// --- Blueprint tool tests ---
+
+func TestApplyBlueprintTool_dryRun(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Launch"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/sections", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"Kickoff","project_id":"p1","priority":1,"due":{"date":"2026-11-02"}}],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run wrote to %s", r.URL.Path)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_apply_blueprint", map[string]interface{}{
+		"blueprint":  "projects:\n  - name: Launch\n    tasks:\n      - {content: Kickoff, due: start, priority: p1}\n      - {content: Retro, due: +2w}\n",
+		"start_date": "2026-11-02",
+		"dry_run":    true,
+	})
+	text := resultText(result)
+	for _, want := range []string{
+		"1 to create, 1 to update, 1 unchanged",
+		"~ task Launch / Kickoff: priority p4 → p1",
+		"+ task Launch / Retro (due 2026-11-16)",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+
+	result = callTool(t, cs, "todoist_apply_blueprint", map[string]interface{}{"blueprint": "projects: [{name: X, tasks: [{content: A, depends_on: [b]}]}]"})
+	if !result.IsError {
+		t.Errorf("expected error: %s", resultText(result))
+	}
+}
+
+// --- Backup tool tests ---
+
+func TestBackupTools(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Home"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/sections", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"Water plants","project_id":"p1"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/labels", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"l1","name":"home"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("unexpected write to %s", r.URL.Path)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_backup", map[string]interface{}{"completed_days": 0})
+	if result.IsError {
+		t.Fatalf("backup failed: %s", resultText(result))
+	}
+	var archive string
+	for _, c := range result.Content {
+		if er, ok := c.(*mcp.EmbeddedResource); ok {
+			archive = er.Resource.Text
+		}
+	}
+	if !strings.Contains(archive, `"content": "Water plants"`) {
+		t.Fatalf("embedded archive missing task:\n%s", archive)
+	}
+
+	path := filepath.Join(t.TempDir(), "backup.json")
+	if err := os.WriteFile(path, []byte(archive), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	result = callTool(t, cs, "todoist_restore_backup", map[string]interface{}{"file_path": path, "dry_run": true})
+	if result.IsError || !strings.Contains(resultText(result), "1 projects, 0 sections, 1 active tasks, 0 completed tasks, 0 comments, 1 labels") {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+}
+
+// --- Markdown import tool tests ---
+
+func TestImportMarkdownTool_dryRun(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"w","name":"Work"}],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/", func(w http.ResponseWriter, r *http.Request) {
+		t.Errorf("dry run called %s %s", r.Method, r.URL.Path)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_import_markdown", map[string]interface{}{
+		"markdown": "## Follow-ups\n- [ ] Email Sam @email p2 tomorrow #work\n  - [ ] Attach slides for #123\n",
+		"dry_run":  true,
+	})
+	text := resultText(result)
+	for _, want := range []string{
+		"2 tasks parsed, 0 lines skipped",
+		"### Follow-ups\nL2: Email Sam (p2, @email, due tomorrow, #work)\n  L3: Attach slides for #123\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+
+	result = callTool(t, cs, "todoist_import_markdown", map[string]interface{}{"markdown": "just prose"})
+	if !result.IsError {
+		t.Errorf("expected error: %s", resultText(result))
+	}
+}
+
+func TestImportMarkdownTool_partial(t *testing.T) {
+	rt := newRouter()
+	var projectReads int
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		projectReads++
+		_, _ = w.Write([]byte(`{"results":[{"id":"in","name":"Inbox","inbox_project":true}],"next_cursor":""}`))
+	})
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []struct {
+			UUID   string `json:"uuid"`
+			TempID string `json:"temp_id"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%q:{"error":"Invalid date","error_code":22},%q:"ok"},"temp_id_mapping":{%q:"t2"}}`,
+			cmds[0].UUID, cmds[1].UUID, cmds[1].TempID)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_import_markdown", map[string]interface{}{"markdown": "- [ ] One\n- [ ] Two\n"})
+	text := resultText(result)
+	if !result.IsError || !strings.Contains(text, "1 tasks were created:\nL1: One\nL2: Two → t2\n") {
+		t.Errorf("unexpected result:\n%s", text)
+	}
+	if projectReads != 1 {
+		t.Errorf("projects read %d times, want 1", projectReads)
+	}
+}
+
+// --- iCalendar export tool tests ---
+
+func TestExportICSTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("filter") != "today" {
+			t.Errorf("filter = %q", r.URL.Query().Get("filter"))
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"Dentist","due":{"date":"2026-11-03","string":"every 6 months","recurring":true}},{"id":"2","content":"Inbox zero"}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_export_ics", map[string]interface{}{"filter": "today", "events": true})
+	if result.IsError || resultText(result) != "Exported 1 tasks as events, skipped 1 without a due date" {
+		t.Errorf("unexpected result: %s", resultText(result))
+	}
+	var calendar string
+	for _, c := range result.Content {
+		if er, ok := c.(*mcp.EmbeddedResource); ok && er.Resource.MIMEType == "text/calendar" {
+			calendar = er.Resource.Text
+		}
+	}
+	if !strings.Contains(calendar, "DTSTART;VALUE=DATE:20261103\r\nDTEND;VALUE=DATE:20261104\r\nRRULE:FREQ=MONTHLY;INTERVAL=6\r\n") {
+		t.Errorf("unexpected calendar:\n%s", calendar)
+	}
+}
+
+// --- Task import tool tests ---
+
+func TestImportTasksTool_csv(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"7","content":"Buy milk","due":{"date":"2026-10-20"}}],"next_cursor":""}`))
+	})
+	var bodies []map[string]interface{}
+	rt.handle("POST", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		bodies = append(bodies, body)
+		_, _ = fmt.Fprintf(w, `{"id":"n%d","content":%q}`, len(bodies), body["content"])
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_import_tasks", map[string]interface{}{
+		"data":    "Item,When,Prio\nBuy milk,2026-10-20,\nBook flights,2026-10-22,p2\n",
+		"columns": map[string]string{"content": "Item", "due": "When", "priority": "Prio"},
+	})
+	text := resultText(result)
+	for _, want := range []string{
+		"1 created, 1 duplicates skipped",
+		"- row 3: Book flights (due 2026-10-22, p2) → n1",
+		"- row 2: Buy milk (due 2026-10-20) matches existing task 7",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if len(bodies) != 1 || bodies[0]["priority"] != float64(3) {
+		t.Errorf("unexpected task bodies: %v", bodies)
+	}
+}
+
+// --- Task export tool tests ---
+
+func TestExportTasksTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"Call mom","project_id":"p1","section_id":"s1","labels":["Calls"]},{"id":"2","content":"Fix bike","project_id":"p1"},{"id":"3","content":"Call shop","project_id":"p1","parent_id":"2","labels":["calls"]}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"p1","name":"Home"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/sections", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"s1","name":"Family"}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	path := filepath.Join(t.TempDir(), "calls.csv")
+	result := callTool(t, cs, "todoist_export_tasks", map[string]interface{}{
+		"label":     "@calls",
+		"columns":   []string{"content", "project", "section", "parent"},
+		"file_path": path,
+	})
+	if result.IsError || !strings.Contains(resultText(result), "Exported 2 tasks as csv to ") {
+		t.Fatalf("unexpected result: %s", resultText(result))
+	}
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if string(data) != "content,project,section,parent\nCall mom,Home,Family,\nCall shop,Home,,Fix bike\n" {
+		t.Errorf("unexpected CSV:\n%s", data)
+	}
+
+	result = callTool(t, cs, "todoist_export_tasks", map[string]interface{}{"format": "md"})
+	var report *mcp.ResourceContents
+	for _, c := range result.Content {
+		if er, ok := c.(*mcp.EmbeddedResource); ok {
+			report = er.Resource
+		}
+	}
+	if report == nil || report.MIMEType != "text/markdown" || !strings.Contains(report.Text, "## Home (3)") {
+		t.Errorf("unexpected report: %+v", report)
+	}
+
+	result = callTool(t, cs, "todoist_export_tasks", map[string]interface{}{"columns": []string{"owner"}})
+	if !result.IsError {
+		t.Errorf("expected error: %s", resultText(result))
+	}
+}
+
+// --- Planning tool tests ---
+
+func TestDailyPlanTool(t *testing.T) {
+	t.Setenv("TODOIST_DAILY_CAPACITY", "60")
+	today := time.Now().Format("2006-01-02")
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"results":[
+			{"id":"1","content":"Write report","priority":1,"due":{"date":%q},"duration":{"amount":50,"unit":"minute"}},
+			{"id":"2","content":"Call bank","priority":4},
+			{"id":"3","content":"Tidy desk","priority":1,"labels":["next"]}],"next_cursor":""}`, today)
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	result := callTool(t, cs, "todoist_daily_plan", map[string]interface{}{})
+	text := resultText(result)
+	for _, want := range []string{
+		"3 tasks, 1h 50m planned of 1h capacity (2 without a duration estimated at 30m each)",
+		"**Overcommitted by 50m.**",
+		"1. Call bank (ID: 2) [p1] ~30m\n--- capacity reached ---\n2. Write report (ID: 1) [today] 50m\n3. Tidy desk (ID: 3) [next] ~30m\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+}
+
+func TestScheduleDayTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"1","content":"Team sync","priority":1,"due":{"date":"2099-01-05","datetime":"2099-01-05T10:00:00"},"duration":{"amount":60,"unit":"minute"}},
+			{"id":"2","content":"Draft proposal","priority":4,"due":{"date":"2099-01-05"},"duration":{"amount":90,"unit":"minute"}},
+			{"id":"3","content":"Water plants","priority":1,"due":{"date":"2099-01-05","string":"every day","recurring":true}}],"next_cursor":""}`))
+	})
+	var mu sync.Mutex
+	updates := map[string]map[string]interface{}{}
+	rt.handle("POST", "/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		updates[strings.TrimPrefix(r.URL.Path, "/tasks/")] = body
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"id":"2"}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	args := map[string]interface{}{"date": "2099-01-05", "work_start": "9:00", "work_end": "13:00"}
+	text := resultText(callTool(t, cs, "todoist_schedule_day", args))
+	for _, want := range []string{
+		"Dry run: nothing was changed",
+		"- 09:00-09:30 Water plants (ID: 3) [today] ~30m (recurring, not written)\n" +
+			"- 10:00-11:00 Team sync (ID: 1) [today] 1h (already scheduled)\n" +
+			"- 11:00-12:30 Draft proposal (ID: 2) [today, p1] 1h 30m\n",
+		"2 tasks scheduled, 1h free",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("dry run missing %q:\n%s", want, text)
+		}
+	}
+	if len(updates) != 0 {
+		t.Fatalf("dry run updated tasks: %v", updates)
+	}
+
+	args["write_back"] = true
+	text = resultText(callTool(t, cs, "todoist_schedule_day", args))
+	if !strings.Contains(text, "2 tasks scheduled, 1h free, 1 updated") {
+		t.Errorf("unexpected result:\n%s", text)
+	}
+	want := time.Date(2099, 1, 5, 11, 0, 0, 0, time.Local).UTC().Format(time.RFC3339)
+	if len(updates) != 1 || updates["2"]["due_datetime"] != want || updates["2"]["duration"] != nil {
+		t.Errorf("updates = %v, want task 2 at %s", updates, want)
+	}
+}
+
+func TestRescheduleOverdueTool(t *testing.T) {
+	today := time.Now().Format("2006-01-02")
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"1","content":"Pay invoice","priority":4,"due":{"date":"2020-01-06"}},
+			{"id":"2","content":"Water plants","priority":1,"due":{"date":"2020-01-07","string":"every day","recurring":true}}],"next_cursor":""}`))
+	})
+	var mu sync.Mutex
+	var updates []map[string]interface{}
+	var failID string
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []struct {
+			UUID string                 `json:"uuid"`
+			Args map[string]interface{} `json:"args"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		var status []string
+		mu.Lock()
+		for _, cmd := range cmds {
+			if cmd.Args["id"] == failID {
+				status = append(status, fmt.Sprintf("%q:{\"error\":\"Item not found\",\"error_code\":22}", cmd.UUID))
+				continue
+			}
+			updates = append(updates, cmd.Args)
+			status = append(status, fmt.Sprintf("%q:\"ok\"", cmd.UUID))
+		}
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%s}}`, strings.Join(status, ","))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	text := resultText(callTool(t, cs, "todoist_reschedule_overdue", map[string]interface{}{"days": 1, "dry_run": true}))
+	for _, want := range []string{
+		"Dry run: would reschedule 2 overdue tasks",
+		"(2 due)\n- Pay invoice (ID: 1): 2020-01-06 -> " + today + "\n- Water plants (ID: 2): 2020-01-07 -> " + today + ` (keeps "every day")`,
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("dry run missing %q:\n%s", want, text)
+		}
+	}
+	if len(updates) != 0 {
+		t.Fatalf("dry run sent updates: %v", updates)
+	}
+
+	result := callTool(t, cs, "todoist_reschedule_overdue", map[string]interface{}{"days": 1})
+	if text := resultText(result); !strings.Contains(text, "Rescheduled 2 overdue tasks") || !strings.Contains(text, "To undo") {
+		t.Errorf("unexpected result:\n%s", text)
+	}
+	if len(updates) != 2 || updates[1]["due"].(map[string]interface{})["string"] != "every day" {
+		t.Fatalf("updates = %v", updates)
+	}
+
+	// Pass the moves back to restore the old dates.
+	data, _ := json.Marshal(result.StructuredContent)
+	var out struct {
+		Moves []interface{} `json:"moves"`
+	}
+	_ = json.Unmarshal(data, &out)
+	updates = nil
+	text = resultText(callTool(t, cs, "todoist_reschedule_overdue", map[string]interface{}{"undo": out.Moves}))
+	if !strings.Contains(text, "Restored the previous due dates of 2 tasks") {
+		t.Errorf("unexpected undo result:\n%s", text)
+	}
+	if len(updates) != 2 || updates[0]["due"].(map[string]interface{})["date"] != "2020-01-06" {
+		t.Errorf("undo updates = %v", updates)
+	}
+
+	// A partly applied request returns the moves that did happen.
+	failID = "2"
+	result = callTool(t, cs, "todoist_reschedule_overdue", map[string]interface{}{"days": 1})
+	text = resultText(result)
+	if !result.IsError || !strings.Contains(text, "failed after 1 of 2 tasks") || !strings.Contains(text, "- Pay invoice (ID: 1): 2020-01-06 -> "+today) {
+		t.Errorf("unexpected partial result:\n%s", text)
+	}
+	data, _ = json.Marshal(result.StructuredContent)
+	out.Moves = nil
+	_ = json.Unmarshal(data, &out)
+	if len(out.Moves) != 1 {
+		t.Errorf("moves = %v", out.Moves)
+	}
+}
+
+func TestNextActionsTool(t *testing.T) {
+	t.Setenv("TODOIST_CONTEXT_LABELS", "@phone, @office")
+	rt := newRouter()
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"inbox","name":"Inbox","inbox_project":true},{"id":"p1","name":"Work"},{"id":"p2","name":"Taxes"},{"id":"p3","name":"Someday"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[
+			{"id":"1","content":"Call supplier","project_id":"p1","labels":["phone"],"priority":4},
+			{"id":"2","content":"Print slides","project_id":"p1","labels":["office"]},
+			{"id":"3","content":"Receipts from Sam","project_id":"p2","labels":["waiting"]},
+			{"id":"4","content":"Learn Rust","project_id":"p3"}],"next_cursor":""}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	text := resultText(callTool(t, cs, "todoist_next_actions", map[string]interface{}{}))
+	for _, want := range []string{
+		"## Next Actions (2)",
+		"### @phone (1)\n- Call supplier (ID: 1) [Work] [P4]\n\n### @office (1)\n- Print slides (ID: 2) [Work]\n",
+		"## Projects Without a Next Action (1)\n- Taxes (ID: p2): 1 open, 1 waiting\n",
+	} {
+		if !strings.Contains(text, want) {
+			t.Errorf("result missing %q:\n%s", want, text)
+		}
+	}
+	if strings.Contains(text, "Learn Rust") || strings.Contains(text, "Someday (ID") {
+		t.Errorf("someday items listed:\n%s", text)
+	}
+
+	text = resultText(callTool(t, cs, "todoist_next_actions", map[string]interface{}{"context": "@office"}))
+	if !strings.Contains(text, "## Next Actions (1)") || strings.Contains(text, "Call supplier") || strings.Contains(text, "Projects Without") {
+		t.Errorf("unexpected context result:\n%s", text)
+	}
+}
+
+func TestWaitingForTool(t *testing.T) {
+	ago := func(days int) string { return time.Now().AddDate(0, 0, -days).UTC().Format(time.RFC3339) }
+	rt := newRouter()
+	rt.handle("GET", "/user", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"me","full_name":"Me"}`))
+	})
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"results":[
+			{"id":"1","content":"Contract from legal","labels":["waiting"],"added_at":%q},
+			{"id":"2","content":"Slides from Ana","responsible_uid":"ana","added_at":%q},
+			{"id":"3","content":"Write memo","responsible_uid":"me","added_at":%q}],"next_cursor":""}`, ago(12), ago(3), ago(1))
+	})
+	var mu sync.Mutex
+	var comments []string
+	rt.handle("POST", "/comments", func(w http.ResponseWriter, r *http.Request) {
+		var body map[string]interface{}
+		_ = json.NewDecoder(r.Body).Decode(&body)
+		mu.Lock()
+		comments = append(comments, fmt.Sprint(body["task_id"], ":", body["content"]))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"id":"c1"}`))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	text := resultText(callTool(t, cs, "todoist_waiting_for", map[string]interface{}{}))
+	if want := "## Waiting For (2)\n" +
+		"- Contract from legal (ID: 1) [@waiting]: 12 days since created\n" +
+		"- Slides from Ana (ID: 2) [assigned to ana]: 3 days since created\n"; !strings.Contains(text, want) {
+		t.Errorf("result missing %q:\n%s", want, text)
+	}
+
+	text = resultText(callTool(t, cs, "todoist_waiting_for", map[string]interface{}{"min_days": 7, "follow_up_comment": "Any news?"}))
+	if !strings.Contains(text, "## Waiting For (1)") || !strings.Contains(text, "Follow-up comment added to 1 tasks") {
+		t.Errorf("unexpected result:\n%s", text)
+	}
+	if len(comments) != 1 || comments[0] != "1:Any news?" {
+		t.Errorf("comments = %v", comments)
+	}
+}
+
+func TestSomedayTools(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"inbox","name":"Inbox","inbox_project":true},{"id":"sd","name":"Someday"},{"id":"ideas","name":"Ideas","parent_id":"sd"},{"id":"home","name":"Home Projects"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/user", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"me","inbox_project_id":"inbox"}`))
+	})
+	task := `{"id":"7","content":"Build a treehouse","project_id":"ideas","added_at":"2020-01-01T00:00:00Z"}`
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = fmt.Fprintf(w, `{"results":[%s,{"id":"8","content":"Pay bills","project_id":"home","added_at":"2020-01-01T00:00:00Z"}],"next_cursor":""}`, task)
+	})
+	rt.handle("GET", "/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(task))
+	})
+	var mu sync.Mutex
+	var calls []string
+	record := func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		mu.Lock()
+		calls = append(calls, r.URL.Path+" "+string(body))
+		mu.Unlock()
+		_, _ = w.Write([]byte(`{"id":"7","content":"Build a treehouse","project_id":"home","due":{"date":"2026-11-02","string":"next monday"}}`))
+	}
+	rt.handle("POST", "/tasks/", record)
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	text := resultText(callTool(t, cs, "todoist_someday_list", map[string]interface{}{}))
+	if !strings.Contains(text, "## Someday/Maybe (1)\n- Build a treehouse (ID: 7): ") || !strings.Contains(text, " days old\n") || strings.Contains(text, "Pay bills") {
+		t.Errorf("unexpected list:\n%s", text)
+	}
+
+	text = resultText(callTool(t, cs, "todoist_someday_activate", map[string]interface{}{
+		"task_name": "treehouse", "project_name": "home projects", "due_string": "next monday",
+	}))
+	if want := `Activated "Build a treehouse" (ID: 7) (moved to project home), due 2026-11-02`; text != want {
+		t.Errorf("result = %q, want %q", text, want)
+	}
+	if len(calls) != 2 || calls[0] != `/tasks/7/move {"project_id":"home"}` || calls[1] != `/tasks/7 {"due_string":"next monday"}` {
+		t.Errorf("calls = %q", calls)
+	}
+
+	// Items in a Someday sub-project go to the inbox by default.
+	calls = nil
+	callTool(t, cs, "todoist_someday_activate", map[string]interface{}{"task_id": "7"})
+	if len(calls) != 1 || calls[0] != `/tasks/7/move {"project_id":"inbox"}` {
+		t.Errorf("calls = %q", calls)
+	}
+}
+
+func TestProcessInboxItemTool(t *testing.T) {
+	rt := newRouter()
+	rt.handle("GET", "/tasks", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"5","content":"Renew passport","project_id":"inbox"}],"next_cursor":""}`))
+	})
+	rt.handle("GET", "/tasks/", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"id":"5","content":"Renew passport","project_id":"inbox"}`))
+	})
+	rt.handle("GET", "/projects", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write([]byte(`{"results":[{"id":"inbox","name":"Inbox","inbox_project":true},{"id":"adm","name":"Admin"}],"next_cursor":""}`))
+	})
+	var mu sync.Mutex
+	var types []string
+	var args []map[string]interface{}
+	var failType string
+	rt.handle("POST", "/sync", func(w http.ResponseWriter, r *http.Request) {
+		_ = r.ParseForm()
+		var cmds []struct {
+			Type string                 `json:"type"`
+			UUID string                 `json:"uuid"`
+			Args map[string]interface{} `json:"args"`
+		}
+		_ = json.Unmarshal([]byte(r.PostForm.Get("commands")), &cmds)
+		var status []string
+		mu.Lock()
+		for _, cmd := range cmds {
+			types = append(types, cmd.Type)
+			args = append(args, cmd.Args)
+			if cmd.Type == failType {
+				status = append(status, fmt.Sprintf("%q:{\"error\":\"Invalid date\",\"error_code\":22}", cmd.UUID))
+				continue
+			}
+			status = append(status, fmt.Sprintf("%q:\"ok\"", cmd.UUID))
+		}
+		mu.Unlock()
+		_, _ = fmt.Fprintf(w, `{"sync_status":{%s}}`, strings.Join(status, ","))
+	})
+	cs, cleanup := setupTest(t, rt)
+	defer cleanup()
+
+	text := resultText(callTool(t, cs, "todoist_process_inbox_item", map[string]interface{}{
+		"task_name": "passport", "action": "file", "project_name": "admin", "labels": []string{"@errands"}, "due_string": "next week",
+	}))
+	if want := `Inbox item "Renew passport" (ID: 5) filed into project adm with @errands, due next week`; text != want {
+		t.Errorf("result = %q, want %q", text, want)
+	}
+	if strings.Join(types, ",") != "item_move,item_update" || args[0]["project_id"] != "adm" || fmt.Sprint(args[1]["labels"]) != "[errands]" {
+		t.Errorf("commands = %v %v", types, args)
+	}
+
+	result := callTool(t, cs, "todoist_process_inbox_item", map[string]interface{}{"task_id": "5", "action": "archive"})
+	if !result.IsError || !strings.Contains(resultText(result), `unknown action "archive"`) {
+		t.Errorf("expected unknown action error, got %q", resultText(result))
+	}
+
+	failType = "item_update"
+	result = callTool(t, cs, "todoist_process_inbox_item", map[string]interface{}{
+		"task_id": "5", "action": "file", "project_id": "adm", "due_string": "someday soon",
+	})
+	if text := resultText(result); !result.IsError || !strings.HasPrefix(text, `Inbox item "Renew passport" (ID: 5) was only partly processed: `) ||
+		!strings.HasSuffix(text, "\nApplied: moved the task to project adm") {
+		t.Errorf("unexpected partial result: %q", text)
+	}
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"flag"
 	"fmt"
 	"log"
 	"os"
@@ -12,6 +13,10 @@ import (
 )
 
 func main() {
+	httpAddr := flag.String("http", "", "serve MCP over streamable HTTP on this address (e.g. :8080, bound to localhost unless a host is given) instead of stdio; requires TODOIST_MCP_TOKEN")
+	feedPath := flag.String("ics-feed", "", "with -http, also serve an iCalendar feed of tasks at this path (e.g. /calendar.ics); requires TODOIST_FEED_TOKEN")
+	flag.Parse()
+
 	token := os.Getenv("TODOIST_API_TOKEN")
 	if token == "" {
 		log.Fatal("Error: TODOIST_API_TOKEN environment variable is required")
@@ -19,6 +24,13 @@ func main() {
 
 	client := todoist.NewClient(token)
 
+	if flag.NArg() > 0 {
+		if err := runCommand(client, flag.Arg(0), flag.Args()[1:], os.Stdout); err != nil {
+			log.Fatalf("Error: %v", err)
+		}
+		return
+	}
+
 	server := mcp.NewServer(&mcp.Implementation{
 		Name:    "todoist-mcp-server",
 		Version: "1.0.0",
@@ -26,6 +38,16 @@ func main() {
 
 	tools.RegisterAll(server, client)
 
+	if *httpAddr != "" {
+		if err := serveHTTP(*httpAddr, *feedPath, server, client); err != nil {
+			log.Fatalf("Server error: %v", err)
+		}
+		return
+	}
+	if *feedPath != "" {
+		log.Fatal("Error: -ics-feed requires -http")
+	}
+
 	fmt.Fprintf(os.Stderr, "Todoist MCP Server starting...\n")
 
 	if err := server.Run(context.Background(), &mcp.StdioTransport{}); err != nil {
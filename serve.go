@@ -0,0 +1,102 @@
+package main
+
+import (
+	"crypto/subtle"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/modelcontextprotocol/go-sdk/mcp"
+	"github.com/nsega/mcp-todoist/internal/todoist"
+	"github.com/nsega/mcp-todoist/internal/tools"
+)
+
+// serveHTTP serves the MCP server over streamable HTTP at /mcp and, if
+// feedPath is set, an iCalendar feed of tasks at feedPath. Requests to
+// /mcp must carry TODOIST_MCP_TOKEN as a bearer token, and tool calls
+// naming a local file_path are refused, since anyone who can reach the
+// port acts with the server's Todoist token and host access.
+func serveHTTP(addr, feedPath string, server *mcp.Server, c *todoist.Client) error {
+	mcpToken := os.Getenv("TODOIST_MCP_TOKEN")
+	if mcpToken == "" {
+		return fmt.Errorf("-http requires TODOIST_MCP_TOKEN to be set")
+	}
+	feedToken := os.Getenv("TODOIST_FEED_TOKEN")
+	if feedPath != "" && feedToken == "" {
+		return fmt.Errorf("-ics-feed requires TODOIST_FEED_TOKEN to be set")
+	}
+	addr, err := listenAddr(addr)
+	if err != nil {
+		return err
+	}
+
+	tools.RejectLocalFiles(server)
+
+	mux := http.NewServeMux()
+	mux.Handle("/mcp", requireBearer(mcpToken, mcp.NewStreamableHTTPHandler(func(*http.Request) *mcp.Server { return server }, nil)))
+	if feedPath != "" {
+		mux.Handle(feedPath, icsFeedHandler(c, feedToken))
+		fmt.Fprintf(os.Stderr, "Serving calendar feed at %s\n", feedPath)
+	}
+	fmt.Fprintf(os.Stderr, "Todoist MCP Server listening on %s\n", addr)
+	return http.ListenAndServe(addr, mux)
+}
+
+// listenAddr binds addresses without a host, such as ":8080", to the
+// loopback interface. Listening on all interfaces needs an explicit
+// host, e.g. "0.0.0.0:8080".
+func listenAddr(addr string) (string, error) {
+	host, port, err := net.SplitHostPort(addr)
+	if err != nil {
+		return "", fmt.Errorf("invalid -http address %q: %w", addr, err)
+	}
+	if host == "" {
+		host = "127.0.0.1"
+	}
+	return net.JoinHostPort(host, port), nil
+}
+
+// requireBearer rejects requests whose Authorization header does not
+// carry token as a bearer token.
+func requireBearer(token string, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(got), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "invalid or missing bearer token", http.StatusUnauthorized)
+			return
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// icsFeedHandler serves active tasks as a subscribable calendar. Query
+// parameters: project_id, filter, and events=1 for VEVENTs. Requests must
+// carry token as ?token=, since calendar apps cannot send headers; an
+// empty token rejects every request.
+func icsFeedHandler(c *todoist.Client, token string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodGet && r.Method != http.MethodHead {
+			http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+			return
+		}
+		q := r.URL.Query()
+		if token == "" || subtle.ConstantTimeCompare([]byte(q.Get("token")), []byte(token)) != 1 {
+			http.Error(w, "invalid feed token", http.StatusUnauthorized)
+			return
+		}
+
+		tasks, err := c.GetTasks(q.Get("project_id"), q.Get("filter"))
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadGateway)
+			return
+		}
+		w.Header().Set("Content-Type", "text/calendar; charset=utf-8")
+		opts := todoist.ICSOptions{Events: q.Get("events") == "1" || q.Get("events") == "true", Name: "Todoist"}
+		if _, err := todoist.WriteICS(w, tasks, opts); err != nil {
+			fmt.Fprintf(os.Stderr, "calendar feed: %v\n", err)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package main
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/nsega/mcp-todoist/internal/todoist"
+)
+
+func TestICSFeedHandler(t *testing.T) {
+	api := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/tasks" || r.URL.Query().Get("project_id") != "p1" {
+			t.Errorf("unexpected request %s", r.URL)
+		}
+		_, _ = w.Write([]byte(`{"results":[{"id":"1","content":"Dentist","due":{"date":"2026-11-03"}}],"next_cursor":null}`))
+	}))
+	defer api.Close()
+	feed := icsFeedHandler(todoist.NewClient("test-token", todoist.WithBaseURL(api.URL)), "s3cret")
+
+	rec := httptest.NewRecorder()
+	feed(rec, httptest.NewRequest("GET", "/calendar.ics?project_id=p1", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("without token: status %d", rec.Code)
+	}
+
+	rec = httptest.NewRecorder()
+	feed(rec, httptest.NewRequest("GET", "/calendar.ics?project_id=p1&events=1&token=s3cret", nil))
+	if rec.Code != http.StatusOK || rec.Header().Get("Content-Type") != "text/calendar; charset=utf-8" {
+		t.Fatalf("status %d, content type %q", rec.Code, rec.Header().Get("Content-Type"))
+	}
+	if !strings.Contains(rec.Body.String(), "BEGIN:VEVENT\r\nUID:1@todoist.com") {
+		t.Errorf("unexpected feed:\n%s", rec.Body.String())
+	}
+}
+
+func TestICSFeedHandler_noToken(t *testing.T) {
+	feed := icsFeedHandler(todoist.NewClient("test-token", todoist.WithBaseURL("http://127.0.0.1:0")), "")
+	rec := httptest.NewRecorder()
+	feed(rec, httptest.NewRequest("GET", "/calendar.ics", nil))
+	if rec.Code != http.StatusUnauthorized {
+		t.Errorf("status %d, want 401 when no feed token is configured", rec.Code)
+	}
+}
+
+func TestRequireBearer(t *testing.T) {
+	h := requireBearer("s3cret", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	for header, want := range map[string]int{
+		"":              http.StatusUnauthorized,
+		"Bearer wrong":  http.StatusUnauthorized,
+		"s3cret":        http.StatusUnauthorized,
+		"Bearer s3cret": http.StatusNoContent,
+	} {
+		req := httptest.NewRequest("POST", "/mcp", nil)
+		if header != "" {
+			req.Header.Set("Authorization", header)
+		}
+		rec := httptest.NewRecorder()
+		h.ServeHTTP(rec, req)
+		if rec.Code != want {
+			t.Errorf("Authorization %q: status %d, want %d", header, rec.Code, want)
+		}
+	}
+}
+
+func TestListenAddr(t *testing.T) {
+	for in, want := range map[string]string{
+		":8080":        "127.0.0.1:8080",
+		"0.0.0.0:8080": "0.0.0.0:8080",
+		"localhost:90": "localhost:90",
+	} {
+		got, err := listenAddr(in)
+		if err != nil || got != want {
+			t.Errorf("listenAddr(%q) = %q, %v; want %q", in, got, err, want)
+		}
+	}
+	if _, err := listenAddr("8080"); err == nil {
+		t.Error("expected error for address without port separator")
+	}
+}